@@ -0,0 +1,95 @@
+package iocdi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Ambiguity describes an autowired interface field that does not have exactly one candidate. See Ambiguities.
+type Ambiguity struct {
+	// Receiver is the ID of the bean owning the field, and Field the field's name.
+	Receiver string
+	Field    string
+	// Type is the field's interface type, e.g. "main.Store".
+	Type string
+	// Candidates lists the IDs of the registered beans implementing the interface, sorted; empty when none does.
+	Candidates []string
+	// Primary is the candidate registered with Primary that autowiring chooses, or empty when the ambiguity is
+	// unresolved.
+	Primary string
+}
+
+// Resolved reports whether autowiring can wire the field anyway, because a single candidate is Primary.
+func (a Ambiguity) Resolved() bool {
+	return a.Primary != emptyString
+}
+
+func (a Ambiguity) String() string {
+	switch {
+	case len(a.Candidates) == 0:
+		return fmt.Sprintf("field '%s' of bean '%s' (%s) has no candidates", a.Field, a.Receiver, a.Type)
+	case a.Resolved():
+		return fmt.Sprintf("field '%s' of bean '%s' (%s) has several candidates, resolved to primary '%s': %s",
+			a.Field, a.Receiver, a.Type, a.Primary, strings.Join(a.Candidates, ", "))
+	}
+	return fmt.Sprintf("field '%s' of bean '%s' (%s) is ambiguous; candidates: %s",
+		a.Field, a.Receiver, a.Type, strings.Join(a.Candidates, ", "))
+}
+
+// Ambiguities returns, in bean-ID and field order, every untagged interface field autowiring considers that
+// has no candidate or several. Ambiguities resolved by a Primary bean are included; see Ambiguity.Resolved.
+// Without WithAutowire untagged fields are not wired and nothing is reported. It does not build the container.
+func (c *Container) Ambiguities() []Ambiguity {
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+	return c.ambiguities()
+}
+
+// ambiguities collects the Ambiguities.
+// Callers must hold regMu.
+func (c *Container) ambiguities() []Ambiguity {
+	found := make([]Ambiguity, 0)
+	if !c.autowire {
+		return found
+	}
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		if bn.literal || bn.beanType == nil || bn.beanType.Kind() != reflect.Ptr || bn.beanType.Elem().Kind() != reflect.Struct {
+			continue
+		}
+		st := bn.beanType.Elem()
+		for i := 0; i < st.NumField(); i++ {
+			sf := st.Field(i)
+			if _, tagged := sf.Tag.Lookup(string(inject)); tagged || !sf.IsExported() || sf.Type.Kind() != reflect.Interface {
+				continue
+			}
+			candidates, primaries := c.assignableBeans(sf.Type, id)
+			if len(candidates) == 1 {
+				continue
+			}
+			a := Ambiguity{Receiver: id, Field: sf.Name, Type: sf.Type.String(), Candidates: candidates}
+			if len(primaries) == 1 {
+				a.Primary = primaries[0]
+			}
+			found = append(found, a)
+		}
+	}
+	return found
+}
+
+// checkAmbiguities fails with every unresolved ambiguity under WithStrictAutowire.
+// Callers must hold regMu.
+func (c *Container) checkAmbiguities() error {
+	if !c.strictAutowire {
+		return nil
+	}
+	var errs []error
+	for _, a := range c.ambiguities() {
+		if !a.Resolved() {
+			errs = append(errs, errors.New("autowire: "+a.String()))
+		}
+	}
+	return errors.Join(errs...)
+}
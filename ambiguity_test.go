@@ -0,0 +1,73 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type greeterPair struct {
+	First  greeter
+	Second greeter
+}
+
+func TestStrictAutowire_ListsEveryCandidate(t *testing.T) {
+	c := New(WithStrictAutowire())
+	require.NoError(t, c.Register("pair", reflect.TypeOf((*greeterPair)(nil))))
+	require.NoError(t, c.Register("english", reflect.TypeOf((*englishGreeter)(nil))))
+	require.NoError(t, c.Register("french", reflect.TypeOf((*frenchGreeter)(nil))))
+
+	err := c.Build()
+	require.EqualError(t, err,
+		"autowire: field 'First' of bean 'pair' (iocdi.greeter) is ambiguous; candidates: english, french\n"+
+			"autowire: field 'Second' of bean 'pair' (iocdi.greeter) is ambiguous; candidates: english, french")
+	for _, info := range c.Beans() {
+		require.False(t, info.Instantiated, info.ID)
+	}
+
+	require.Equal(t, []Ambiguity{
+		{Receiver: "pair", Field: "First", Type: "iocdi.greeter", Candidates: []string{"english", "french"}},
+		{Receiver: "pair", Field: "Second", Type: "iocdi.greeter", Candidates: []string{"english", "french"}},
+	}, c.Ambiguities())
+}
+
+func TestStrictAutowire_NoCandidates(t *testing.T) {
+	c := New(WithStrictAutowire())
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*autowiredReceiver)(nil))))
+
+	require.EqualError(t, c.Build(), "autowire: field 'Greeter' of bean 'receiver' (iocdi.greeter) has no candidates")
+
+	// Without the strict option the field is left nil
+	c = New(WithAutowire())
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*autowiredReceiver)(nil))))
+	r, err := ResolveAs[*autowiredReceiver](c, "receiver")
+	require.NoError(t, err)
+	require.Nil(t, r.Greeter)
+	require.Equal(t, []Ambiguity{
+		{Receiver: "receiver", Field: "Greeter", Type: "iocdi.greeter", Candidates: []string{}},
+	}, c.Ambiguities())
+}
+
+func TestStrictAutowire_PrimaryResolves(t *testing.T) {
+	c := New(WithStrictAutowire())
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*autowiredReceiver)(nil))))
+	require.NoError(t, c.Register("tagged", reflect.TypeOf((*taggedGreeterReceiver)(nil))))
+	require.NoError(t, c.Register("english", reflect.TypeOf((*englishGreeter)(nil))))
+	require.NoError(t, c.Register("french", reflect.TypeOf((*frenchGreeter)(nil)), Primary()))
+
+	ambiguities := c.Ambiguities()
+	require.Len(t, ambiguities, 1, "tagged fields are not reported")
+	require.True(t, ambiguities[0].Resolved())
+	require.Equal(t, "french", ambiguities[0].Primary)
+
+	r, err := ResolveAs[*autowiredReceiver](c, "receiver")
+	require.NoError(t, err)
+	require.Equal(t, "bonjour", r.Greeter.Greet())
+}
+
+func TestAmbiguities_AutowireDisabled(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("pair", reflect.TypeOf((*greeterPair)(nil))))
+	require.Empty(t, c.Ambiguities())
+}
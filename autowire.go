@@ -0,0 +1,149 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// autowiredField records the bean chosen for an untagged field during Build.
+type autowiredField struct {
+	field string
+	id    string
+}
+
+// edges returns the IDs of every bean the receiver depends on: the tagged dependencies, any dependencies
+// chosen by autowiring and the members of every group the receiver collects.
+// Callers must hold regMu.
+func (c *Container) edges(b bean) []string {
+	if len(b.autowired) == 0 && len(b.groupFields) == 0 {
+		return b.dependencies
+	}
+	ids := make([]string, 0, len(b.dependencies)+len(b.autowired))
+	ids = append(ids, b.dependencies...)
+	for _, af := range b.autowired {
+		ids = append(ids, af.id)
+	}
+	for _, gf := range b.groupFields {
+		for _, id := range c.groupMembers(gf.group) {
+			if id != b.id {
+				ids = appendUnique(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+// autowiredID returns the bean chosen for the named untagged field, if any.
+func (b bean) autowiredID(field string) (string, bool) {
+	for _, af := range b.autowired {
+		if af.field == field {
+			return af.id, true
+		}
+	}
+	return emptyString, false
+}
+
+// autowiredField returns the name of the untagged field autowiring wired to depID, if any.
+func (b bean) autowiredField(depID string) (string, bool) {
+	for _, af := range b.autowired {
+		if af.id == depID {
+			return af.field, true
+		}
+	}
+	return emptyString, false
+}
+
+// resolveAutowired chooses a bean for every exported, untagged interface or pointer-to-struct field of
+// every registered struct bean, other than fields of an empty interface type such as any. Fields without a candidate are left alone, a single candidate is chosen
+// directly and several candidates are narrowed to the bean registered with Primary.
+// Callers must hold regMu.
+func (c *Container) resolveAutowired() error {
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		if bn.initialized {
+			continue // keep the wiring chosen by the build that initialized it
+		}
+		autowired, err := c.autowireFields(bn)
+		if err != nil {
+			return err
+		}
+		bn.autowired = autowired
+		c.registeredBeans[id] = bn
+	}
+	return nil
+}
+
+// autowireFields chooses the beans for the bean's untagged fields, or returns nil when autowiring is disabled.
+// Callers must hold regMu.
+func (c *Container) autowireFields(bn bean) ([]autowiredField, error) {
+	if !c.autowire || bn.beanType == nil || bn.beanType.Kind() != reflect.Ptr || bn.beanType.Elem().Kind() != reflect.Struct {
+		return nil, nil
+	}
+	var autowired []autowiredField
+	st := bn.beanType.Elem()
+	for i := 0; i < st.NumField(); i++ {
+		sf := st.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if _, tagged := sf.Tag.Lookup(string(inject)); tagged {
+			continue
+		}
+		if sf.Type.Kind() != reflect.Interface && !(sf.Type.Kind() == reflect.Ptr && sf.Type.Elem().Kind() == reflect.Struct) {
+			continue
+		}
+		if sf.Type.Kind() == reflect.Interface && sf.Type.NumMethod() == 0 {
+			continue // every bean is assignable to an empty interface
+		}
+
+		depID, ok, err := c.autowireCandidate(bn.id, sf)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			autowired = append(autowired, autowiredField{field: sf.Name, id: depID})
+		}
+	}
+	return autowired, nil
+}
+
+// autowireCandidate picks the bean that satisfies field sf of the receiver bean.
+func (c *Container) autowireCandidate(receiverID string, sf reflect.StructField) (string, bool, error) {
+	candidates, primaries := c.assignableBeans(sf.Type, receiverID)
+	switch {
+	case len(candidates) == 0:
+		return emptyString, false, nil
+	case len(candidates) == 1:
+		return candidates[0], true, nil
+	case len(primaries) == 1:
+		return primaries[0], true, nil
+	case len(primaries) > 1:
+		return emptyString, false, fmt.Errorf("autowire: field '%s' of bean '%s' (%v) has multiple primary candidates: %s",
+			sf.Name, receiverID, sf.Type, strings.Join(primaries, ", "))
+	default:
+		return emptyString, false, fmt.Errorf("autowire: field '%s' of bean '%s' (%v) is ambiguous; candidates: %s",
+			sf.Name, receiverID, sf.Type, strings.Join(candidates, ", "))
+	}
+}
+
+// assignableBeans returns the sorted IDs of the registered beans whose type is assignable to t, other than
+// exclude, along with the subset registered with Primary.
+// Callers must hold regMu.
+func (c *Container) assignableBeans(t reflect.Type, exclude string) (candidates, primaries []string) {
+	candidates = make([]string, 0)
+	primaries = make([]string, 0)
+	for id, bn := range c.registeredBeans {
+		if id == exclude || bn.beanType == nil || !bn.beanType.AssignableTo(t) {
+			continue
+		}
+		candidates = append(candidates, id)
+		if bn.primary {
+			primaries = append(primaries, id)
+		}
+	}
+	sort.Strings(candidates)
+	sort.Strings(primaries)
+	return candidates, primaries
+}
@@ -0,0 +1,109 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type greeter interface{ Greet() string }
+
+type englishGreeter struct{ _ byte }
+
+func (g *englishGreeter) Greet() string { return "hello" }
+
+type frenchGreeter struct{ _ byte }
+
+func (g *frenchGreeter) Greet() string { return "bonjour" }
+
+type autowiredReceiver struct {
+	Greeter greeter
+}
+
+type taggedGreeterReceiver struct {
+	Greeter greeter `di.inject:"french"`
+}
+
+func TestAutowire_SingleCandidate(t *testing.T) {
+	c := New(WithAutowire())
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*autowiredReceiver)(nil))))
+	require.NoError(t, c.Register("english", reflect.TypeOf((*englishGreeter)(nil))))
+
+	r, err := ResolveAs[*autowiredReceiver](c, "receiver")
+	require.NoError(t, err)
+	require.NotNil(t, r.Greeter)
+	require.Equal(t, "hello", r.Greeter.Greet())
+}
+
+type autowiredAnyReceiver struct {
+	Greeter greeter
+	Payload any
+}
+
+func TestAutowire_SkipsEmptyInterfaceFields(t *testing.T) {
+	c := New(WithAutowire())
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*autowiredAnyReceiver)(nil))))
+	require.NoError(t, c.Register("english", reflect.TypeOf((*englishGreeter)(nil))))
+	require.NoError(t, c.RegisterInstance("greeting", "hi"))
+
+	r, err := ResolveAs[*autowiredAnyReceiver](c, "receiver")
+	require.NoError(t, err)
+	require.Equal(t, "hello", r.Greeter.Greet())
+	require.Nil(t, r.Payload)
+}
+
+func TestAutowire_PrimarySelected(t *testing.T) {
+	c := New(WithAutowire())
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*autowiredReceiver)(nil))))
+	require.NoError(t, c.Register("english", reflect.TypeOf((*englishGreeter)(nil))))
+	require.NoError(t, c.Register("french", reflect.TypeOf((*frenchGreeter)(nil)), Primary()))
+
+	r, err := ResolveAs[*autowiredReceiver](c, "receiver")
+	require.NoError(t, err)
+	require.Equal(t, "bonjour", r.Greeter.Greet())
+}
+
+func TestAutowire_AmbiguousWithoutPrimary(t *testing.T) {
+	c := New(WithAutowire())
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*autowiredReceiver)(nil))))
+	require.NoError(t, c.Register("english", reflect.TypeOf((*englishGreeter)(nil))))
+	require.NoError(t, c.Register("french", reflect.TypeOf((*frenchGreeter)(nil))))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "field 'Greeter' of bean 'receiver'")
+	require.Contains(t, err.Error(), "candidates: english, french")
+}
+
+func TestAutowire_DoublePrimaryFailsBuild(t *testing.T) {
+	c := New(WithAutowire())
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*autowiredReceiver)(nil))))
+	require.NoError(t, c.Register("english", reflect.TypeOf((*englishGreeter)(nil)), Primary()))
+	require.NoError(t, c.RegisterInstance("french", &frenchGreeter{}, Primary()))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "multiple primary candidates: english, french")
+}
+
+func TestAutowire_ExplicitTagIgnoresPrimary(t *testing.T) {
+	c := New(WithAutowire())
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*taggedGreeterReceiver)(nil))))
+	require.NoError(t, c.Register("english", reflect.TypeOf((*englishGreeter)(nil)), Primary()))
+	require.NoError(t, c.Register("french", reflect.TypeOf((*frenchGreeter)(nil))))
+
+	r, err := ResolveAs[*taggedGreeterReceiver](c, "receiver")
+	require.NoError(t, err)
+	require.Equal(t, "bonjour", r.Greeter.Greet())
+}
+
+func TestAutowire_DisabledByDefault(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*autowiredReceiver)(nil))))
+	require.NoError(t, c.Register("english", reflect.TypeOf((*englishGreeter)(nil))))
+
+	r, err := ResolveAs[*autowiredReceiver](c, "receiver")
+	require.NoError(t, err)
+	require.Nil(t, r.Greeter)
+}
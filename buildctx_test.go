@@ -0,0 +1,128 @@
+package iocdi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ctxInitLog    []string
+	ctxInitCancel context.CancelFunc
+)
+
+// ctxFirst cancels the build context from its initializer
+type ctxFirst struct{}
+
+func (b *ctxFirst) InitializeCtx(ctx context.Context) error {
+	ctxInitLog = append(ctxInitLog, "first")
+	if ctxInitCancel != nil {
+		ctxInitCancel()
+	}
+	return nil
+}
+
+type ctxSecond struct {
+	First *ctxFirst `di.inject:"first"`
+}
+
+func (b *ctxSecond) Initialize() error {
+	ctxInitLog = append(ctxInitLog, "second")
+	return nil
+}
+
+type ctxThird struct {
+	Second *ctxSecond `di.inject:"second"`
+}
+
+func (b *ctxThird) Initialize() error {
+	ctxInitLog = append(ctxInitLog, "third")
+	return nil
+}
+
+// ctxBoth implements both initializers; only the context-aware one should run
+type ctxBoth struct {
+	ctxValue any
+	plain    bool
+}
+
+type ctxKey struct{}
+
+func (b *ctxBoth) Initialize() error {
+	b.plain = true
+	return nil
+}
+
+func (b *ctxBoth) InitializeCtx(ctx context.Context) error {
+	b.ctxValue = ctx.Value(ctxKey{})
+	return nil
+}
+
+func TestBuildContext_CancelStopsRemainingInitializers(t *testing.T) {
+	ctxInitLog = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctxInitCancel = cancel
+	defer func() { ctxInitCancel = nil }()
+
+	c := New()
+	require.NoError(t, c.Register("first", reflect.TypeOf((*ctxFirst)(nil))))
+	require.NoError(t, c.Register("second", reflect.TypeOf((*ctxSecond)(nil))))
+	require.NoError(t, c.Register("third", reflect.TypeOf((*ctxThird)(nil))))
+
+	err := c.BuildContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualError(t, err, "initializer for bean 'second' not run: context canceled")
+	require.Equal(t, []string{"first"}, ctxInitLog)
+}
+
+func TestBuildContext_PrefersContextInitializer(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("both", reflect.TypeOf((*ctxBoth)(nil))))
+	require.NoError(t, c.BuildContext(context.WithValue(context.Background(), ctxKey{}, "marker")))
+
+	b, err := ResolveAs[*ctxBoth](c, "both")
+	require.NoError(t, err)
+	require.Equal(t, "marker", b.ctxValue)
+	require.False(t, b.plain)
+}
+
+// ctxSlow blocks its initializer until the build context is done
+type ctxSlow struct{}
+
+func (b *ctxSlow) InitializeCtx(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestResolveCtx_CancelDuringImplicitBuild(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*ctxSlow)(nil))))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := c.ResolveCtx(ctx, "slow")
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+	require.EqualError(t, err, "initializer for bean 'slow' failed: context deadline exceeded")
+	require.Equal(t, StateBuildFailed, c.State())
+}
+
+func TestResolveCtx_BuiltContainer(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("first", reflect.TypeOf((*ctxFirst)(nil))))
+	require.NoError(t, c.Build())
+
+	first, err := ResolveAsCtx[*ctxFirst](context.Background(), c, "First")
+	require.NoError(t, err)
+	require.Same(t, c.Resolve("first"), first)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.ResolveCtx(ctx, "first")
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualError(t, err, "resolve bean 'first': context canceled")
+}
@@ -0,0 +1,63 @@
+package iocdi
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// buildCallbacks holds the callbacks registered with OnBuildComplete.
+type buildCallbacks struct {
+	mu  sync.Mutex
+	fns []func(c *Container)
+	// fired is set once the callbacks ran for a successful Build; later registrations run immediately.
+	fired bool
+}
+
+// OnBuildComplete registers fn to run once Build has succeeded, after every initializer, for logic that belongs
+// to no single bean (a startup banner, signal handlers, a readiness gauge). Callbacks run in registration order
+// before Build returns, outside the container's locks, so they may resolve beans. They do not run if Build fails.
+// When the container is already built, fn runs immediately.
+//
+// A panicking callback is recovered and reported: from Build, or from OnBuildComplete when fn runs immediately.
+func (c *Container) OnBuildComplete(fn func(c *Container)) error {
+	if fn == nil {
+		return nil
+	}
+	c.completed.mu.Lock()
+	if !c.completed.fired {
+		c.completed.fns = append(c.completed.fns, fn)
+		c.completed.mu.Unlock()
+		return nil
+	}
+	c.completed.mu.Unlock()
+	return c.callBuildComplete(0, fn)
+}
+
+// runBuildComplete runs the registered callbacks after a successful Build, joining any recovered panics.
+func (c *Container) runBuildComplete() error {
+	c.completed.mu.Lock()
+	c.completed.fired = true
+	fns := slices.Clone(c.completed.fns)
+	c.completed.mu.Unlock()
+
+	var errs []error
+	for i, fn := range fns {
+		if err := c.callBuildComplete(i, fn); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// callBuildComplete runs a single callback, converting a panic into an error naming the callback's position.
+func (c *Container) callBuildComplete(i int, fn func(c *Container)) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("build-complete callback %d panicked: %v", i, r)
+		}
+	}()
+	fn(c)
+	return nil
+}
@@ -0,0 +1,56 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnBuildComplete_RunsInOrderAfterInitializers(t *testing.T) {
+	phaseLog = nil
+	c := New()
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*phaseRepo)(nil))))
+	require.NoError(t, c.OnBuildComplete(func(c *Container) {
+		// Callbacks may resolve: the container is built and unlocked
+		_, err := c.ResolveSafe("repo")
+		require.NoError(t, err)
+		phaseLog = append(phaseLog, "banner")
+	}))
+	require.NoError(t, c.OnBuildComplete(func(c *Container) {
+		phaseLog = append(phaseLog, "ready")
+	}))
+
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"init:repo", "banner", "ready"}, phaseLog)
+
+	// Building again does not re-run them; registering on a built container runs immediately
+	require.NoError(t, c.Build())
+	require.NoError(t, c.OnBuildComplete(func(c *Container) {
+		phaseLog = append(phaseLog, "late")
+	}))
+	require.Equal(t, []string{"init:repo", "banner", "ready", "late"}, phaseLog)
+}
+
+func TestOnBuildComplete_NotRunWhenBuildFails(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("broken", reflect.TypeOf((*lazyBroken)(nil))))
+	ran := false
+	require.NoError(t, c.OnBuildComplete(func(c *Container) { ran = true }))
+
+	require.Error(t, c.Build())
+	require.False(t, ran)
+}
+
+func TestOnBuildComplete_PanicsAreReported(t *testing.T) {
+	c := New()
+	ran := false
+	require.NoError(t, c.OnBuildComplete(func(c *Container) { panic("banner font missing") }))
+	require.NoError(t, c.OnBuildComplete(func(c *Container) { ran = true }))
+
+	require.EqualError(t, c.Build(), "build-complete callback 0 panicked: banner font missing")
+	require.True(t, ran)
+	require.True(t, c.built.Load())
+
+	require.EqualError(t, c.OnBuildComplete(func(c *Container) { panic("late") }), "build-complete callback 0 panicked: late")
+}
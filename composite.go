@@ -0,0 +1,86 @@
+package iocdi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Composite is a lookup facade over several independently built containers, such as core, plugin and tenant
+// containers. Members are queried in order and the first container registering the bean wins. The composite
+// itself never builds or changes its members; resolving from a member triggers only that member's own
+// implicit Build.
+type Composite struct {
+	members         []*Container
+	detectConflicts bool
+}
+
+// NewComposite returns a composite querying the containers in the given order.
+func NewComposite(containers ...*Container) *Composite {
+	return &Composite{members: containers}
+}
+
+// WithConflictDetection returns a copy of the composite that fails resolution when more than one member
+// registers the requested bean, instead of letting the first one win.
+func (cp *Composite) WithConflictDetection() *Composite {
+	dup := *cp
+	dup.detectConflicts = true
+	return &dup
+}
+
+// Contains reports whether any member registers the bean. It does not build the members.
+func (cp *Composite) Contains(beanID string) bool {
+	return len(cp.holders(beanID)) > 0
+}
+
+// Resolve returns a bean instance by its ID or panics if it cannot be resolved.
+// Prefer ResolveSafe in production code to handle errors gracefully.
+func (cp *Composite) Resolve(beanID string) any {
+	v, err := cp.ResolveSafe(beanID)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ResolveSafe resolves the bean from the first member registering it. A bean found in no member yields an
+// error matching ErrBeanNotFound that lists the members searched, by index.
+func (cp *Composite) ResolveSafe(beanID string) (any, error) {
+	if beanID == emptyString {
+		return nil, ErrBeanIdParamIsEmpty
+	}
+
+	holders := cp.holders(beanID)
+	switch {
+	case len(holders) == 0:
+		return nil, fmt.Errorf("%w; searched containers: %s", &BeanError{ID: strings.ToLower(beanID), Err: ErrBeanNotFound},
+			joinIndexes(len(cp.members)))
+	case len(holders) > 1 && cp.detectConflicts:
+		indexes := make([]string, len(holders))
+		for i, h := range holders {
+			indexes[i] = strconv.Itoa(h)
+		}
+		return nil, fmt.Errorf("bean '%s' is registered in several containers: %s", strings.ToLower(beanID), strings.Join(indexes, ", "))
+	}
+	return cp.members[holders[0]].ResolveSafe(beanID)
+}
+
+// holders returns the indexes of the members registering the bean, in order.
+func (cp *Composite) holders(beanID string) []int {
+	var holders []int
+	for i, c := range cp.members {
+		if c.Contains(beanID) {
+			holders = append(holders, i)
+		}
+	}
+	return holders
+}
+
+// joinIndexes returns "0, 1, ..., n-1".
+func joinIndexes(n int) string {
+	indexes := make([]string, n)
+	for i := range indexes {
+		indexes[i] = strconv.Itoa(i)
+	}
+	return strings.Join(indexes, ", ")
+}
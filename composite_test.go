@@ -0,0 +1,58 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newCompositeMembers(t *testing.T) (core, plugins *Container) {
+	t.Helper()
+	core = New()
+	require.NoError(t, core.Register("notifier", reflect.TypeOf((*byTypeMail)(nil))))
+	require.NoError(t, core.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+	plugins = New()
+	require.NoError(t, plugins.Register("notifier", reflect.TypeOf((*byTypeSMS)(nil))))
+	require.NoError(t, plugins.Register("push", reflect.TypeOf((*byTypePush)(nil))))
+	return core, plugins
+}
+
+func TestComposite_FirstWins(t *testing.T) {
+	core, plugins := newCompositeMembers(t)
+	cp := NewComposite(core, plugins)
+
+	v, err := cp.ResolveSafe("notifier")
+	require.NoError(t, err)
+	require.IsType(t, &byTypeMail{}, v)
+
+	require.IsType(t, &byTypeSMS{}, NewComposite(plugins, core).Resolve("notifier"))
+	require.IsType(t, &byTypePush{}, cp.Resolve("Push"))
+	require.True(t, cp.Contains("clock"))
+	require.False(t, cp.Contains("cache"))
+}
+
+func TestComposite_ConflictDetection(t *testing.T) {
+	core, plugins := newCompositeMembers(t)
+	cp := NewComposite(core, plugins).WithConflictDetection()
+
+	_, err := cp.ResolveSafe("notifier")
+	require.EqualError(t, err, "bean 'notifier' is registered in several containers: 0, 1")
+
+	_, err = cp.ResolveSafe("push")
+	require.NoError(t, err)
+	// The original composite is unchanged
+	_, err = NewComposite(core, plugins).ResolveSafe("notifier")
+	require.NoError(t, err)
+}
+
+func TestComposite_NotFound(t *testing.T) {
+	core, plugins := newCompositeMembers(t)
+	cp := NewComposite(core, plugins, New())
+
+	_, err := cp.ResolveSafe("Cache")
+	require.ErrorIs(t, err, ErrBeanNotFound)
+	require.EqualError(t, err, "bean 'cache' not found; searched containers: 0, 1, 2")
+	require.Equal(t, StateRegistering, core.State())
+	require.Equal(t, StateRegistering, plugins.State())
+}
@@ -0,0 +1,74 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// requirement is a single receiver field's demand for a dependency ID.
+type requirement struct {
+	receiver string
+	field    string
+	typ      reflect.Type
+}
+
+// fieldType renders the declared type of the receiving field; pointer-to-struct fields record their struct type.
+func (r requirement) fieldType() reflect.Type {
+	if r.typ.Kind() == reflect.Struct {
+		return reflect.PointerTo(r.typ)
+	}
+	return r.typ
+}
+
+// checkRequirementConflicts fails when the same dependency ID is required with incompatible types by
+// different receivers, e.g. "client" as *http.Client in one bean and as *grpc.Client in another.
+// Identical types, an interface paired with a type implementing it, named basic types of the same kind (int and
+// `type Port int`) and types bridged by a registered converter are compatible.
+// Callers must hold regMu.
+func (c *Container) checkRequirementConflicts() error {
+	byID := make(map[string][]requirement)
+	for _, id := range sortedKeys(c.registeredBeans) {
+		for _, fd := range c.registeredBeans[id].fields {
+			byID[fd.id] = append(byID[fd.id], requirement{receiver: id, field: fd.field, typ: fd.typ})
+		}
+	}
+
+	for _, depID := range sortedKeys(byID) {
+		reqs := byID[depID]
+		if !c.requirementsCompatible(reqs) {
+			parts := make([]string, 0, len(reqs))
+			for _, r := range reqs {
+				parts = append(parts, fmt.Sprintf("'%s' field %s (%v)", r.receiver, r.field, r.fieldType()))
+			}
+			return fmt.Errorf("dependency '%s' is required with conflicting types: %s", depID, strings.Join(parts, ", "))
+		}
+	}
+	return nil
+}
+
+// requirementsCompatible reports whether every pair of requirements can be satisfied by the same bean.
+// Callers must hold regMu.
+func (c *Container) requirementsCompatible(reqs []requirement) bool {
+	for i := 0; i < len(reqs); i++ {
+		for j := i + 1; j < len(reqs); j++ {
+			if !c.typesCompatible(reqs[i].fieldType(), reqs[j].fieldType()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// typesCompatible reports whether a and b are identical, one is an interface the other implements, they are
+// named basic types convertible to each other, or a converter bridges them in either direction.
+// Callers must hold regMu.
+func (c *Container) typesCompatible(a, b reflect.Type) bool {
+	if a == b || namedConvertible(a, b) || namedConvertible(b, a) || c.hasConverter(a, b) || c.hasConverter(b, a) {
+		return true
+	}
+	if b.Kind() == reflect.Interface && a.Implements(b) {
+		return true
+	}
+	return a.Kind() == reflect.Interface && b.Implements(a)
+}
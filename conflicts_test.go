@@ -0,0 +1,116 @@
+package iocdi
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type httpClient struct{ _ byte }
+
+func (h *httpClient) A() int { return 1 }
+
+type grpcClient struct{ _ byte }
+
+type httpConsumer struct {
+	Client *httpClient `di.inject:"client"`
+}
+
+type grpcConsumer struct {
+	Client *grpcClient `di.inject:"client"`
+}
+
+type ifaceConsumer struct {
+	Client testIface `di.inject:"client"`
+}
+
+type stringConsumer struct {
+	Client string `di.inject:"client"`
+}
+
+func TestRequirementConflict_IncompatibleTypes(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("http", reflect.TypeOf((*httpConsumer)(nil))))
+	require.NoError(t, c.Register("grpc", reflect.TypeOf((*grpcConsumer)(nil))))
+	require.NoError(t, c.Register("client", reflect.TypeOf((*httpClient)(nil))))
+
+	err := c.Build()
+	require.EqualError(t, err, "dependency 'client' is required with conflicting types: "+
+		"'grpc' field Client (*iocdi.grpcClient), 'http' field Client (*iocdi.httpClient)")
+}
+
+func TestRequirementConflict_InterfaceImplementationIsCompatible(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("http", reflect.TypeOf((*httpConsumer)(nil))))
+	require.NoError(t, c.Register("iface", reflect.TypeOf((*ifaceConsumer)(nil))))
+	require.NoError(t, c.Register("client", reflect.TypeOf((*httpClient)(nil))))
+
+	require.NoError(t, c.Build())
+	hc, err := ResolveAs[*httpConsumer](c, "http")
+	require.NoError(t, err)
+	ic, err := ResolveAs[*ifaceConsumer](c, "iface")
+	require.NoError(t, err)
+	require.Same(t, hc.Client, ic.Client)
+}
+
+type rawPortConsumer struct {
+	Port int `di.inject:"port"`
+}
+
+type namedPortConsumer struct {
+	Port testPort `di.inject:"port"`
+}
+
+type testPort int
+
+type stringPortConsumer struct {
+	Port string `di.inject:"port"`
+}
+
+func TestRequirementConflict_NamedBasicTypesAreCompatible(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("raw", reflect.TypeOf((*rawPortConsumer)(nil))))
+	require.NoError(t, c.Register("named", reflect.TypeOf((*namedPortConsumer)(nil))))
+	require.NoError(t, c.RegisterInstance("port", 8080))
+
+	require.NoError(t, c.Build())
+	raw, err := ResolveAs[*rawPortConsumer](c, "raw")
+	require.NoError(t, err)
+	named, err := ResolveAs[*namedPortConsumer](c, "named")
+	require.NoError(t, err)
+	require.Equal(t, 8080, raw.Port)
+	require.Equal(t, testPort(8080), named.Port)
+}
+
+func TestRequirementConflict_ConvertedTypesAreCompatible(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterConverter(reflect.TypeOf(""), reflect.TypeOf(0), func(v any) (any, error) {
+		return strconv.Atoi(v.(string))
+	}))
+	require.NoError(t, c.Register("raw", reflect.TypeOf((*rawPortConsumer)(nil))))
+	require.NoError(t, c.Register("str", reflect.TypeOf((*stringPortConsumer)(nil))))
+	require.NoError(t, c.RegisterInstance("port", "8080"))
+
+	require.NoError(t, c.Build())
+	raw, err := ResolveAs[*rawPortConsumer](c, "raw")
+	require.NoError(t, err)
+	require.Equal(t, 8080, raw.Port)
+}
+
+func TestRequirementConflict_ThreeWay(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("http", reflect.TypeOf((*httpConsumer)(nil))))
+	require.NoError(t, c.Register("grpc", reflect.TypeOf((*grpcConsumer)(nil))))
+	require.NoError(t, c.Register("str", reflect.TypeOf((*stringConsumer)(nil))))
+	require.NoError(t, c.RegisterInstance("client", "localhost"))
+
+	err := c.Build()
+	require.Error(t, err)
+	msg := err.Error()
+	require.Contains(t, msg, "dependency 'client' is required with conflicting types")
+	require.Contains(t, msg, "'grpc' field Client (*iocdi.grpcClient)")
+	require.Contains(t, msg, "'http' field Client (*iocdi.httpClient)")
+	require.Contains(t, msg, "'str' field Client (string)")
+}
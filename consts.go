@@ -9,4 +9,24 @@ type tag string
 
 const (
 	inject tag = "di.inject" // di.inject is the default tag for constructor injection. The field MUST be exported.
+	value  tag = "di.value"  // di.value holds an inline constant that is parsed to the field's kind at Build.
+	env    tag = "di.env"    // di.env names an environment variable read at Build, e.g. `di.env:"PORT,required"`.
+	group  tag = "di.group"  // di.group on any field of a bean's struct declares the bean's group memberships.
+)
+
+// excluded is the `di.inject` tag value that marks a field the container must never touch, mirroring encoding/json.
+const excluded = "-"
+
+const (
+	injectPrototype = "prototype" // di.inject option: the field receives a fresh instance instead of the shared singleton.
+	injectCopy      = "copy"      // di.inject option: like prototype, but the instance is cloned from the registered one.
+	injectScope     = "scope"     // di.inject option: `scope=bean` prefixes the dependency ID with the receiver's bean ID.
+)
+
+// scopeBean is the only value of the `scope` option.
+const scopeBean = "bean"
+
+const (
+	envRequired = "required" // di.env option: Build fails when the variable is unset.
+	envDefault  = "default"  // di.env option: value used when the variable is unset, e.g. `default=8080`.
 )
@@ -1,11 +1,17 @@
 package iocdi
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type bean struct {
@@ -15,6 +21,43 @@ type bean struct {
 	singleton       bool
 	hasDependencies bool
 	dependencies    []string
+	// fields records, in field order, the tagged fields that create each dependency edge.
+	fields []fieldDependency
+	// copyMode controls whether prototype instances clone the registered instance.
+	copyMode copyMode
+	// primary marks the preferred candidate among several beans satisfying the same autowired field.
+	primary bool
+	// groups lists the groups the bean is a member of.
+	groups []string
+	// groupFields lists the slice fields collecting group members.
+	groupFields []groupField
+	// autowired holds the beans chosen during Build for untagged fields when autowiring is enabled.
+	autowired []autowiredField
+	// initTimeout overrides the container-wide initializer timeout when positive.
+	initTimeout time.Duration
+	// initPriority orders the bean among beans whose dependencies are equally satisfied; lower runs earlier.
+	initPriority int
+	// initialized is set once a Build has wired the bean and its Initialize, if any, succeeded.
+	initialized bool
+	// lazy defers building the bean to its first resolution; deferred is set by Build when nothing eager needs it.
+	lazy     bool
+	deferred bool
+	// supplied marks instances given by the caller (RegisterInstance, ReplaceInstance) rather than created by Build.
+	supplied bool
+	// literal marks beans synthesized from the LiteralProvider; ephemeral ones are injected but never stored.
+	literal   bool
+	ephemeral bool
+	// request is the lookup that synthesized a literal bean, repeated by RefreshLiterals.
+	request LiteralRequest
+	// synthetic marks beans synthesized from the MissingBeanProvider.
+	synthetic bool
+	// trackPrototypes keeps the prototypes created from the bean so Close can destroy them.
+	trackPrototypes bool
+	// prototype gives every resolution and every receiver a fresh instance instead of a shared singleton.
+	prototype bool
+	// registration numbers the first registration of the ID, from 1; it is zero for beans the container
+	// synthesized. IDOf prefers the lowest.
+	registration uint64
 }
 
 type Container struct {
@@ -23,6 +66,13 @@ type Container struct {
 	regMu sync.RWMutex
 	// Indicates whether the container has been built/finalized.
 	built atomic.Bool
+	// Indicates whether the container has been closed; a closed container cannot be used again.
+	closed atomic.Bool
+
+	// initOrder records the bean IDs in the order Build initialized them; Close walks it in reverse.
+	initOrder []string
+	// started records, in start order, the beans reached by Start and not yet stopped.
+	started []string
 
 	// requiredDependency maps bean identifiers to their corresponding reflect.Type, identifying dependencies
 	// required by registered beans. For example, if `Service` has a dependency on `Config`, then `Config` will be
@@ -32,13 +82,99 @@ type Container struct {
 	// registeredBeans stores all registered beans mapped by their unique string identifiers.
 	// This is the source of truth for all beans.
 	registeredBeans map[string]bean
+	// registrations counts the IDs registered so far, numbering each bean's registration.
+	registrations uint64
+
+	// converters bridges type gaps between a dependency and its receiving field, keyed by (source, destination).
+	converters map[converterKey]Converter
+
+	// autowire enables by-type wiring of untagged interface and pointer-to-struct fields; strictAutowire fails
+	// Build on autowired interface fields without exactly one candidate.
+	autowire       bool
+	strictAutowire bool
+	// strictGroups makes collecting an empty group a Build error.
+	strictGroups bool
+	// strictWarnings holds the warning codes that fail Build; see WithStrictWarnings.
+	strictWarnings map[WarningCode]bool
+	// eagerCycleCheck reports cycles from the Register call that closes them.
+	eagerCycleCheck bool
+	// lenientTags ignores unknown tag options instead of failing registration.
+	lenientTags bool
+	// noImplicitBuild makes resolution before Build fail instead of building.
+	noImplicitBuild bool
+	// splitLiterals lets a LiteralProvider supply a slice field as one comma-separated string.
+	splitLiterals bool
+	// literalTemplates expands ${id} placeholders in string literals and string beans.
+	literalTemplates bool
+	// eagerLiterals makes the precheck fetch literal values instead of deferring them to injection.
+	eagerLiterals bool
+	// validateStrings passes registered string beans through the literal validator at Build.
+	validateStrings bool
+	// secrets holds the IDs marked with MarkSecret; resolvableSecrets lets resolution return them.
+	secrets           map[string]bool
+	resolvableSecrets bool
+	// initTimeout bounds each initializer during Build; zero means no limit.
+	initTimeout time.Duration
+	// healthTimeout and healthConcurrency configure Health; zero selects the defaults.
+	healthTimeout     time.Duration
+	healthConcurrency int
+
+	// envLookuper resolves environment variables for fields tagged with `di.env`.
+	envLookuper Lookuper
+	// literalProviders are consulted in order before the global LiteralProvider; see AddLiteralProvider.
+	literalProviders atomic.Pointer[[]literalSource]
+	// capturedLiterals is the literal provider state in effect since Build started; nil before that and after
+	// Reset. Guarded by regMu.
+	capturedLiterals *literalSnapshot
+	// staticLiterals holds the values given to WithLiterals; it is never modified after New.
+	staticLiterals map[string]any
+	// literalValidator checks literal values before injection; see SetLiteralValidator.
+	literalValidator atomic.Pointer[LiteralValidator]
+	// missingBeanProvider synthesizes unregistered dependencies; see SetMissingBeanProvider.
+	missingBeanProvider atomic.Pointer[MissingBeanProvider]
+	// logger receives debug output about the container's internals; see SetLogger.
+	logger atomic.Pointer[slog.Logger]
+	// recorder collects the timings of the Build in progress; nil outside Build. Guarded by regMu.
+	recorder *buildRecorder
+	// lastReport is the report of the most recent Build; see LastBuildReport.
+	lastReport atomic.Pointer[BuildReport]
+	// metrics receives counters and durations; see SetMetricsSink.
+	metrics atomic.Pointer[MetricsSink]
+	// tracer receives spans around Build steps; see SetTraceHook.
+	tracer atomic.Pointer[TraceHook]
+	// resolved maps the IDs of the beans ResolveSafe serves without locking to their instances; nil while
+	// unbuilt and while Build or Extend runs. See publishResolved.
+	resolved atomic.Pointer[map[string]any]
+	// injections receives every injected and skipped field; see SetRecorder.
+	injections atomic.Pointer[Recorder]
+	// warnings collects the fields left unset; see Warnings.
+	warnings warnings
+
+	// subs receives lifecycle events; see Subscribe.
+	subs subscribers
+	// hooks run at the phases of Build; see OnPhase.
+	hooks phaseHooks
+	// completed runs after a successful Build; see OnBuildComplete.
+	completed buildCallbacks
+	// prototypes tracks the prototypes of beans registered with TrackPrototypes.
+	prototypes prototypeTracker
+	// building is set while Build runs, keeping registration closed while phase hooks release regMu.
+	building atomic.Bool
+	// buildErr holds the error of the last failed Build, or nil once a Build succeeds; see State.
+	buildErr atomic.Pointer[error]
 }
 
-func New() *Container {
-	return &Container{
+// New creates an empty container configured by the given options.
+func New(opts ...Option) *Container {
+	c := &Container{
 		requiredDependency: make(map[string]reflect.Type),
 		registeredBeans:    make(map[string]bean),
+		envLookuper:        defaultLookuper,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 // Register registers a bean by its reflect.Type.
@@ -49,17 +185,29 @@ func New() *Container {
 //
 // This method only supports registering structs and pointers to structs; simple types (e.g., string)
 // must be registered as instances using RegisterInstance.
-func (c *Container) Register(beanID string, beanType reflect.Type) error {
+func (c *Container) Register(beanID string, beanType reflect.Type, opts ...RegisterOption) error {
 	if beanID == emptyString {
 		return ErrBeanIdParamIsEmpty
 	}
 	if beanType == nil {
 		return ErrBeanTypeParamIsNil
 	}
+	if c.closed.Load() {
+		return ErrContainerClosed
+	}
 	if c.built.Load() {
 		return ErrRegistrationClosed
 	}
 
+	b, err := c.typeBean(beanID, beanType, opts)
+	if err != nil {
+		return err
+	}
+	return c.storeBean(b)
+}
+
+// typeBean validates a type registration and returns the bean to store. See Register.
+func (c *Container) typeBean(beanID string, beanType reflect.Type, opts []RegisterOption) (bean, error) {
 	beanID = strings.ToLower(beanID)
 
 	// Normalize struct kind to pointer-to-struct
@@ -71,22 +219,30 @@ func (c *Container) Register(beanID string, beanType reflect.Type) error {
 	default:
 		// For non-struct simple types (e.g., string) this registration style is not supported.
 		// Use RegisterInstance for simple literals instead.
-		return ErrBeanTypeNotSupported
+		return bean{}, ErrBeanTypeNotSupported
+	}
+
+	if err := c.checkTags(beanID, beanType); err != nil {
+		return bean{}, err
 	}
 
-	hasDeps, deps := c.checkForDependency(beanType)
+	fields := dependencyFields(beanID, beanType)
+	memberOf, collectors := discoverGroups(beanType)
 	b := bean{
 		id:              beanID,
 		beanType:        beanType,
 		instance:        nil, // instance will be created during Build
 		singleton:       false,
-		hasDependencies: hasDeps,
-		dependencies:    deps,
+		hasDependencies: len(fields) > 0,
+		dependencies:    dependencyIDs(fields),
+		fields:          fields,
+		groups:          memberOf,
+		groupFields:     collectors,
 	}
-	c.regMu.Lock()
-	c.registeredBeans[beanID] = b
-	c.regMu.Unlock()
-	return nil
+	for _, opt := range opts {
+		opt(&b)
+	}
+	return b, nil
 }
 
 // RegisterInstance registers a concrete instance for type T.
@@ -94,17 +250,29 @@ func (c *Container) Register(beanID string, beanType reflect.Type) error {
 // The 'beanID' parameter is case-sensitive with regard to the bean identifier and the
 // coresponding receiving bean tag. The case of the bean identifier must match the case of the
 // tag in the receiving bean.
-func (c *Container) RegisterInstance(beanID string, instance any) error {
+func (c *Container) RegisterInstance(beanID string, instance any, opts ...RegisterOption) error {
 	if beanID == emptyString {
 		return ErrBeanIdParamIsEmpty
 	}
 	if instance == nil {
 		return ErrBeanParamIsNil
 	}
+	if c.closed.Load() {
+		return ErrContainerClosed
+	}
 	if c.built.Load() {
 		return ErrRegistrationClosed
 	}
 
+	b, err := c.instanceBean(beanID, instance, opts)
+	if err != nil {
+		return err
+	}
+	return c.storeBean(b)
+}
+
+// instanceBean validates an instance registration and returns the bean to store. See RegisterInstance.
+func (c *Container) instanceBean(beanID string, instance any, opts []RegisterOption) (bean, error) {
 	beanID = strings.ToLower(beanID) // Enforce lower-case bean identifiers
 
 	beanType := reflect.TypeOf(instance)
@@ -118,20 +286,63 @@ func (c *Container) RegisterInstance(beanID string, instance any) error {
 		beanType = ptr.Type()
 	}
 
-	has, deps := c.checkForDependency(beanType)
+	if err := c.checkTags(beanID, beanType); err != nil {
+		return bean{}, err
+	}
+
+	fields := dependencyFields(beanID, beanType)
+	memberOf, collectors := discoverGroups(beanType)
 	b := bean{
 		id:              beanID,
 		beanType:        beanType,
 		instance:        instance,
 		singleton:       true,
-		hasDependencies: has,
-		dependencies:    deps,
+		supplied:        true,
+		hasDependencies: len(fields) > 0,
+		dependencies:    dependencyIDs(fields),
+		fields:          fields,
+		groups:          memberOf,
+		groupFields:     collectors,
+	}
+	for _, opt := range opts {
+		opt(&b)
 	}
+	return b, nil
+}
 
+// storeBean records the bean's required dependencies and stores it under its ID, replacing any previous
+// registration. With eager cycle checking enabled, a registration that closes a cycle is rejected and
+// the previous state restored.
+func (c *Container) storeBean(b bean) (err error) {
 	c.regMu.Lock()
-	c.registeredBeans[beanID] = b
-	c.regMu.Unlock()
+	defer c.regMu.Unlock()
+	defer func() {
+		c.emit(Event{Kind: EventRegistered, BeanID: b.id, Err: err})
+	}()
 
+	if c.building.Load() {
+		return ErrRegistrationClosed
+	}
+
+	prev, existed := c.registeredBeans[b.id]
+	if existed {
+		b.registration = prev.registration
+	} else {
+		c.registrations++
+		b.registration = c.registrations
+	}
+	c.registeredBeans[b.id] = b
+	if c.eagerCycleCheck {
+		if err := c.cycleThrough(b.id); err != nil {
+			if existed {
+				c.registeredBeans[b.id] = prev
+			} else {
+				delete(c.registeredBeans, b.id)
+			}
+			return err
+		}
+	}
+	c.requireDependencies(b.fields)
 	return nil
 }
 
@@ -139,137 +350,353 @@ func (c *Container) RegisterInstance(beanID string, instance any) error {
 // instantiating all registered beans, and injecting dependencies.
 //
 // If the container has already been built, this method is a no-op.
-func (c *Container) Build() (err error) {
+func (c *Container) Build() error {
+	return c.BuildContext(context.Background())
+}
+
+// BuildContext is like Build but bounds initialization with ctx: the context is handed to beans implementing
+// ContextInitializer and checked before each initializer runs, so cancellation stops the remaining sequence.
+func (c *Container) BuildContext(ctx context.Context) error {
+	completed, err := c.buildIfNeeded(ctx)
+	if err != nil || !completed {
+		return err
+	}
+	// Callbacks run outside the build lock so they may resolve or start the container
+	return c.runBuildComplete()
+}
+
+// buildIfNeeded builds the container under the build lock unless it is already built, reporting whether this
+// call completed the build.
+func (c *Container) buildIfNeeded(ctx context.Context) (bool, error) {
 	c.buildLock.Lock()
 	defer c.buildLock.Unlock()
 
+	if c.closed.Load() {
+		return false, ErrContainerClosed
+	}
+
 	// Idempotent: if already built, nothing to do.
 	if c.built.Load() {
-		return nil
+		return false, nil
 	}
 
+	if err := c.build(ctx, nil); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// build performs the Build steps. Beans already initialized by an earlier build (see Extend) keep their
+// instance and wiring and are neither re-injected, re-validated nor re-initialized.
+// A failed build restores the beans as they were before the call: instances it created and beans synthesized
+// from the LiteralProvider are discarded. Fields already injected into supplied instances are not unset.
+// Callers must hold buildLock.
+// With roots, only their transitive dependency closure is built (see Preload).
+func (c *Container) build(ctx context.Context, roots []string) (err error) {
 	// All map reads/writes inside Build happen under regMu for safety against concurrent registration.
 	c.regMu.Lock()
+	c.building.Store(true)
+	c.unpublishResolved()
+	// Beans are stored by value, so the snapshot captures which instances existed before this attempt
+	snapshot := maps.Clone(c.registeredBeans)
+	requiredSnapshot := maps.Clone(c.requiredDependency)
+	// The precheck and injection must agree on the literal providers, however they change meanwhile
+	captured := c.captureLiteralProviders()
+	c.recorder = newBuildRecorder()
+	c.resetWarnings()
+	endSpan := c.startBuildSpan(len(c.registeredBeans))
 	defer func() {
+		// The report is taken before a failure discards the beans this attempt synthesized
+		rep := c.report(c.recorder, err)
+		c.lastReport.Store(rep)
+		c.recorder = nil
+		if s := c.metricsSink(); s != nil {
+			s.ObserveBuild(rep.Total)
+		}
 		// Mark as built only on successful completion.
 		if err == nil {
+			c.buildErr.Store(nil)
+			c.publishResolved()
 			c.built.Store(true)
+		} else {
+			// Drop the instances and literal and synthetic beans this attempt created so a later Build starts afresh
+			c.registeredBeans = snapshot
+			c.requiredDependency = requiredSnapshot
+			if captured {
+				c.capturedLiterals = nil
+			}
+			failure := err
+			c.buildErr.Store(&failure)
 		}
+		c.building.Store(false)
+		endSpan(err)
 		c.regMu.Unlock()
 	}()
 
-	// First, check if the required dependencies have been registered
-	// and there is type compatibility between the required dependency and the registered bean.
-	for beanID, requiredType := range c.requiredDependency {
-		regBean, ok := c.registeredBeans[beanID]
-		if !ok {
-			// Allow missing string dependencies to be provided by a LiteralProvider at injection time.
-			if requiredType.Kind() == reflect.String {
-				if lp := loadLiteralProvider(); lp != nil {
-					// Defer resolution to injection; skip strict precheck for this dependency.
-					continue
-				}
+	if l := c.log(); l != nil {
+		l.Debug("iocdi: build started", "beans", len(c.registeredBeans), "preload", roots != nil)
+		defer func() {
+			if err != nil {
+				l.Debug("iocdi: build failed", "error", err)
+			} else {
+				l.Debug("iocdi: build completed")
 			}
-			return fmt.Errorf("bean `%s` is required but not registered", beanID)
-		}
+		}()
+	}
 
-		registeredType := regBean.beanType
-		compatible := false
-
-		switch requiredType.Kind() {
-		case reflect.Struct:
-			// Require pointer to struct of exactly the same underlying type
-			compatible = registeredType.Kind() == reflect.Ptr && registeredType.Elem() == requiredType
-		case reflect.Interface:
-			// allow concrete (typically pointer-to-struct) that implements the interface
-			compatible = registeredType.Implements(requiredType)
-		default:
-			// Simple types (e.g., string) must match exactly
-			compatible = registeredType == requiredType
-		}
+	// Choose beans for untagged fields before anything relies on the dependency edges
+	c.logStep("autowire")
+	if err = c.checkAmbiguities(); err != nil {
+		return err
+	}
+	if err = c.resolveAutowired(); err != nil {
+		return err
+	}
 
-		if !compatible {
-			return fmt.Errorf("bean '%s' type mismatch: required %v, registered %v", beanID, requiredType, registeredType)
+	// Lazy beans that nothing eager depends on, and beans outside a preload, are left for their first resolution
+	c.markDeferred(roots)
+
+	// Receivers must agree on the type they expect under each dependency ID. The requirements are then derived
+	// afresh, so a registration replaced by one without the field no longer demands its dependency
+	if err = c.checkRequirementConflicts(); err != nil {
+		return err
+	}
+	c.requiredDependency = c.derivedRequirements()
+
+	// First, check if the required dependencies have been registered
+	// and there is type compatibility between the required dependency and the registered bean.
+	// A preload only checks what the beans it builds require.
+	required := sortedKeys(c.requiredDependency)
+	if roots != nil {
+		required = c.requiredByBuilt()
+	}
+	// Unregistered dependencies may be synthesized by the MissingBeanProvider, which can add requirements of its own
+	if c.missingBeanProvider.Load() != nil {
+		if err = c.supplyMissing(required, false); err != nil {
+			return err
 		}
+		if required = sortedKeys(c.requiredDependency); roots != nil {
+			required = c.requiredByBuilt()
+		}
+	}
+	c.logStep("precheck")
+	if err = c.checkRequired(required); err != nil {
+		return err
+	}
+	if err = c.expandStringBeans(); err != nil {
+		return err
+	}
+	if err = c.validateStringBeans(); err != nil {
+		return err
 	}
 
-	// The dependencies are all registered, so we can instantiate the beans
-	for _, bn := range c.registeredBeans {
-		if bn.instance != nil {
-			continue // Already instantiated
+	// The dependencies are all registered, so we can instantiate the beans (in bean-ID order for reproducibility)
+	c.logStep("instantiate")
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		if bn.instance != nil || bn.deferred || bn.prototype {
+			continue // Already instantiated, built on first resolution, or created per resolution
 		}
 
 		if bn.beanType.Kind() == reflect.Ptr && bn.beanType.Elem().Kind() == reflect.Struct {
-			//			fmt.Println("Creating instance of bean:", bn.id, "of type", bn.beanType)
-			instance, ierr := createInstance(bn.beanType)
+			start := time.Now()
+			instance, ierr := createInstance(bn.id, bn.beanType)
 			if ierr != nil {
 				return ierr
 			}
+			c.recorder.instantiated(bn.id, start)
 			bn.instance = instance
 			bn.singleton = true
 			c.registeredBeans[bn.id] = bn
+			c.emit(Event{Kind: EventInstantiated, BeanID: bn.id})
 		}
 	}
 
+	if err = c.runPhase(PhasePreInject); err != nil {
+		return err
+	}
+
 	// Inject dependencies
+	c.logStep("inject")
 	if err = c.injectDependencies(); err != nil {
 		return err
 	}
+	if err = c.checkWarnings(); err != nil {
+		return err
+	}
 
-	// Call Initializer on beans that implement it, after injection is complete
-	// Ensure initializers run in dependency order: a bean's dependencies are initialized before the bean itself.
-	// We perform a DFS topological traversal using the same dependency edges captured at registration time.
-	visited := make(map[string]bool)
-	onPath := make(map[string]bool)
-	order := make([]string, 0, len(c.registeredBeans))
+	if err = c.runPhase(PhasePostInject); err != nil {
+		return err
+	}
 
-	var visit func(string) error
-	visit = func(id string) error {
-		if visited[id] {
-			return nil
-		}
-		if onPath[id] {
-			return fmt.Errorf("initializer order: dependency cycle detected at '%s'", id)
-		}
-		onPath[id] = true
+	// Call Initializer on beans that implement it, after injection is complete.
+	// Initializers run in dependency order: a bean's dependencies are initialized before the bean itself.
+	order, err := c.initializationOrder()
+	if err != nil {
+		return err
+	}
+
+	// Let beans assert their own wiring invariants before any Initialize side effects happen.
+	// Every failing bean is reported, not just the first.
+	c.logStep("validate")
+	var validationErrs []error
+	for _, id := range order {
 		bn := c.registeredBeans[id]
-		if bn.hasDependencies {
-			for _, dep := range bn.dependencies {
-				if _, ok := c.registeredBeans[dep]; !ok {
-					return fmt.Errorf("initializer order: dependency '%s' required by '%s' not registered", dep, id)
-				}
-				if err := visit(dep); err != nil {
-					return err
-				}
+		if bn.initialized {
+			continue
+		}
+		if v, ok := bn.instance.(Validator); ok {
+			if verr := v.ValidateWiring(); verr != nil {
+				validationErrs = append(validationErrs, fmt.Errorf("validation for bean '%s' failed: %w", id, verr))
 			}
 		}
-		onPath[id] = false
-		visited[id] = true
-		order = append(order, id)
-		return nil
+	}
+	if err = errors.Join(validationErrs...); err != nil {
+		return err
 	}
 
-	for id := range c.registeredBeans {
-		if err := visit(id); err != nil {
-			return err
-		}
+	if err = c.runPhase(PhasePreInit); err != nil {
+		return err
 	}
 
+	// On failure, the beans initialized so far are torn down again so they don't leak the resources they acquired
+	c.logStep("initialize")
+	initialized := make([]string, 0, len(order))
 	for _, id := range order {
 		bn := c.registeredBeans[id]
-		if bn.instance == nil {
+		if bn.initialized || bn.instance == nil || !isInitializer(bn.instance) {
 			continue
 		}
-		if initr, ok := bn.instance.(Initializer); ok {
-			if ierr := initr.Initialize(); ierr != nil {
-				return fmt.Errorf("initializer for bean '%s' failed: %w", id, ierr)
-			}
+		if cerr := ctx.Err(); cerr != nil {
+			return errors.Join(fmt.Errorf("initializer for bean '%s' not run: %w", id, cerr), c.rollbackInitialized(ctx, initialized))
 		}
+		if l := c.log(); l != nil {
+			l.Debug("iocdi: running initializer", "bean", id)
+		}
+		start := time.Now()
+		endInit := c.startSpan(spanInit, id, bn.beanType)
+		ierr := initializeWithin(ctx, id, bn.instance, c.initTimeoutFor(bn))
+		endInit(ierr)
+		c.recorder.initialized(id, start)
+		c.observeInit(id, start)
+		c.emit(Event{Kind: EventInitialized, BeanID: id, Err: ierr})
+		if ierr != nil {
+			return errors.Join(&InitError{BeanID: id, Err: ierr}, c.rollbackInitialized(ctx, initialized))
+		}
+		initialized = append(initialized, id)
+	}
+	if perr := c.runPhase(PhasePostInit); perr != nil {
+		return errors.Join(perr, c.rollbackInitialized(ctx, initialized))
+	}
+	for _, id := range order {
+		bn := c.registeredBeans[id]
+		bn.initialized = true
+		c.registeredBeans[id] = bn
 	}
+	c.initOrder = order
 
 	return err
 }
 
+// prefetchLiteral asks the literal providers for a missing dependency during the precheck, on behalf of the
+// first receiver naming it, and stores the value so injection does not ask again. Ephemeral values are still
+// fetched again per field.
+// Callers must hold regMu.
+func (c *Container) prefetchLiteral(id string) error {
+	_, ok, err := c.literalBean(c.literalRequest(c.firstReceiver(id), id))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("bean `%s` is required but no literal provider supplies it", id)
+	}
+	return nil
+}
+
+// firstReceiver returns the ID of the first bean, in bean-ID order, depending on the bean with the ID.
+// Callers must hold regMu.
+func (c *Container) firstReceiver(id string) string {
+	for _, rid := range sortedKeys(c.registeredBeans) {
+		if slices.Contains(c.edges(c.registeredBeans[rid]), id) {
+			return rid
+		}
+	}
+	return emptyString
+}
+
+// maxPrecheckErrors caps the failures checkRequired reports, so a badly broken container does not bury the
+// first problems under pages of output.
+const maxPrecheckErrors = 50
+
+// checkRequired verifies that each of the given required dependencies is registered with a type compatible
+// with the type its receivers require. Missing basic-kind and collection dependencies pass when a LiteralProvider
+// is set; with WithEagerLiteralCheck the provider must supply them right away. Every failure is reported, up to
+// maxPrecheckErrors, in one joined error.
+// Callers must hold regMu.
+func (c *Container) checkRequired(ids []string) error {
+	var errs []error
+	failed := 0
+	fail := func(err error) {
+		if failed++; failed <= maxPrecheckErrors {
+			errs = append(errs, err)
+		}
+	}
+	for _, beanID := range ids {
+		requiredType, ok := c.requiredDependency[beanID]
+		if !ok {
+			continue
+		}
+		regBean, ok := c.registeredBeans[beanID]
+		if !ok {
+			// Allow missing basic-kind and collection dependencies to be provided by a LiteralProvider at injection time.
+			if isLiteralKind(requiredType) && c.hasLiteralProvider() {
+				if !c.eagerLiterals {
+					// Defer resolution to injection; skip strict precheck for this dependency.
+					continue
+				}
+				if err := c.prefetchLiteral(beanID); err != nil {
+					fail(err)
+				}
+				continue
+			}
+			fail(c.missingRequirement(beanID))
+			continue
+		}
+
+		if !c.satisfies(regBean.beanType, requiredType) {
+			fail(&TypeMismatchError{BeanID: beanID, Required: requiredType, Registered: regBean.beanType})
+		}
+	}
+	if failed > maxPrecheckErrors {
+		errs = append(errs, fmt.Errorf("%d more precheck failures not shown", failed-maxPrecheckErrors))
+	}
+	return errors.Join(errs...)
+}
+
+// missingRequirement returns the precheck error for a required dependency that is not registered, naming the
+// first bean requiring it.
+// Callers must hold regMu.
+func (c *Container) missingRequirement(id string) error {
+	receiver := c.firstReceiver(id)
+	return &MissingDependencyError{Receiver: receiver, Dependency: id, Field: c.edgeField(receiver, id), precheck: true}
+}
+
+// satisfies reports whether a bean registered with registeredType can be injected where requiredType is required.
+func (c *Container) satisfies(registeredType, requiredType reflect.Type) bool {
+	switch requiredType.Kind() {
+	case reflect.Struct:
+		// Require pointer to struct of exactly the same underlying type
+		return registeredType.Kind() == reflect.Ptr && registeredType.Elem() == requiredType
+	case reflect.Interface:
+		// allow concrete (typically pointer-to-struct) that implements the interface
+		return registeredType.Implements(requiredType)
+	}
+	// Simple types (e.g., string) must match exactly, share the same basic kind (named types),
+	// or be bridged by a converter
+	return registeredType == requiredType || namedConvertible(registeredType, requiredType) ||
+		c.hasConverter(registeredType, requiredType)
+}
+
 // Resolve returns a bean instance by its ID or panics if it cannot be resolved.
 // Prefer ResolveSafe in production code to handle errors gracefully.
 func (c *Container) Resolve(beanID string) any {
@@ -282,30 +709,62 @@ func (c *Container) Resolve(beanID string) any {
 
 // ResolveSafe returns a bean instance by its ID.
 // It ensures the container is built before resolving and returns an error on failure.
-func (c *Container) ResolveSafe(beanID string) (any, error) {
+// With WithNoImplicitBuild, resolving before Build fails with ErrContainerNotBuilt instead.
+func (c *Container) ResolveSafe(beanID string) (instance any, err error) {
 	if beanID == emptyString {
 		return nil, ErrBeanIdParamIsEmpty
 	}
 
 	beanID = strings.ToLower(beanID)
+	defer func() {
+		c.emit(Event{Kind: EventResolved, BeanID: beanID, Err: err})
+		if s := c.metricsSink(); s != nil {
+			s.IncResolve(beanID, err == nil)
+		}
+	}()
+
+	// Once built, the published instances are served without taking any lock
+	if instance, ok := c.resolvePublished(beanID); ok {
+		return instance, nil
+	}
 
 	// Ensure the container is built before resolving.
+	endSpan := endNothing
 	if !c.built.Load() {
-		if err := c.Build(); err != nil {
-			return nil, err
-		}
+		endSpan = c.startSpan(spanResolve, beanID, nil)
+	}
+	err = c.ensureBuilt()
+	endSpan(err)
+	if err != nil {
+		return nil, err
 	}
 
 	// Look up the bean safely under read lock.
 	c.regMu.RLock()
 	bn, ok := c.registeredBeans[beanID]
+	withheld := c.withheld(beanID)
 	c.regMu.RUnlock()
 	if !ok {
-		return nil, fmt.Errorf("bean '%s' not found", beanID)
+		return nil, &BeanError{ID: beanID, Err: ErrBeanNotFound}
+	}
+	if withheld {
+		return nil, &BeanError{ID: beanID, Err: ErrSecretBean}
+	}
+
+	if bn.deferred && !bn.initialized {
+		if err := c.materialize(beanID); err != nil {
+			return nil, err
+		}
+		c.regMu.RLock()
+		bn = c.registeredBeans[beanID]
+		c.regMu.RUnlock()
 	}
 
+	if bn.prototype {
+		return c.prototypeInstance(bn)
+	}
 	if bn.instance == nil {
-		return nil, fmt.Errorf("bean '%s' is not initialized", beanID)
+		return nil, &BeanError{ID: beanID, Err: ErrBeanNotInitialized}
 	}
 
 	return bn.instance, nil
@@ -313,8 +772,9 @@ func (c *Container) ResolveSafe(beanID string) (any, error) {
 
 // ResolveAs returns a bean instance by its ID and casts it to type T.
 // It ensures the container is built before resolving and returns an error on failure.
-func ResolveAs[T any](c *Container, beanID string) (T, error) {
-	v, err := c.ResolveSafe(beanID)
+// Any Resolver works, such as a Scope, a Composite or a test fake.
+func ResolveAs[T any](r Resolver, beanID string) (T, error) {
+	v, err := r.ResolveSafe(beanID)
 	if err != nil {
 		var zero T
 		return zero, err
@@ -445,18 +445,10 @@ func TestCycleDetection_TwoNode(t *testing.T) {
 	err := c.Build()
 	require.Error(t, err)
 
-	msg := err.Error()
-	require.Contains(t, msg, "dependency cycle detected:")
-
-	// Accept either traversal depending on map iteration order
-	acceptable := []string{
-		"a -> b -> a",
-		"b -> a -> b",
-	}
-	require.True(t, containsAny(msg, acceptable), "error path was %q; expected one of %v", msg, acceptable)
+	require.EqualError(t, err, "dependency cycle detected: a (field B) -> b (field A) -> a")
 }
 
-// Three-node cycle: A -> B -> C -> A (order may rotate depending on traversal)
+// Three-node cycle: A -> B -> C -> A, reported from its smallest ID whichever bean the traversal enters first
 type cycleA3 struct {
 	B *cycleB3 `di.inject:"B3"`
 }
@@ -477,15 +469,7 @@ func TestCycleDetection_ThreeNode(t *testing.T) {
 	err := c.Build()
 	require.Error(t, err)
 
-	msg := err.Error()
-	require.Contains(t, msg, "dependency cycle detected:")
-
-	acceptable := []string{
-		"a3 -> b3 -> c3 -> a3",
-		"b3 -> c3 -> a3 -> b3",
-		"c3 -> a3 -> b3 -> c3",
-	}
-	require.True(t, containsAny(msg, acceptable), "error path was %q; expected one of %v", msg, acceptable)
+	require.EqualError(t, err, "dependency cycle detected: a3 (field B) -> b3 (field C) -> c3 (field A) -> a3")
 }
 
 // Self-cycle: A -> A
@@ -501,20 +485,39 @@ func TestCycleDetection_SelfCycle(t *testing.T) {
 	err := c.Build()
 	require.Error(t, err)
 
-	msg := err.Error()
-	require.Contains(t, msg, "dependency cycle detected:")
 	// Path should show a direct loop
-	require.True(t, containsAny(msg, []string{"aself -> aself"}), "error path was %q; expected %q", msg, "aself -> aself")
+	require.EqualError(t, err, "dependency cycle detected: aself (field A) -> aself")
 }
 
-// Helper: returns true if s contains any of the needles
-func containsAny(s string, needles []string) bool {
-	for _, n := range needles {
-		if strings.Contains(s, n) {
-			return true
-		}
-	}
-	return false
+// Field names along the cycle path identify the struct fields that create each edge.
+type fieldCycleLeft struct {
+	Right *fieldCycleRight `di.inject:"Right"`
+}
+type fieldCycleRight struct {
+	Name string
+	Back *fieldCycleLeft `di.inject:"Left"`
+}
+
+func TestCycleDetection_FieldNamesInPath(t *testing.T) {
+	c := New()
+
+	require.NoError(t, c.Register("Left", reflect.TypeOf((*fieldCycleLeft)(nil))))
+	require.NoError(t, c.Register("Right", reflect.TypeOf((*fieldCycleRight)(nil))))
+
+	err := c.Build()
+	require.Error(t, err)
+	msg := err.Error()
+	require.Contains(t, msg, "left (field Right) -> right")
+	require.Contains(t, msg, "right (field Back) -> left")
+}
+
+func TestCycleDetection_SelfCycleFieldName(t *testing.T) {
+	c := New()
+
+	require.NoError(t, c.Register("Aself", reflect.TypeOf((*selfCycleA)(nil))))
+
+	err := c.Build()
+	require.EqualError(t, err, "dependency cycle detected: aself (field A) -> aself")
 }
 
 // Resolve/ResolveSafe tests implemented as suite methods to match the pattern in container_test.go.
@@ -734,3 +737,67 @@ func TestInitializer_OrderByDependencies_Depth3(t *testing.T) {
 	// Expected order: B -> A -> C
 	require.Equal(t, []string{"B", "A", "C"}, initOrder)
 }
+
+// --- Deterministic ordering tests ---
+
+var independentInitOrder []string
+
+type independentBean struct{ name string }
+
+func (b *independentBean) Initialize() error {
+	independentInitOrder = append(independentInitOrder, b.name)
+	return nil
+}
+
+func TestInitializer_IndependentBeansInSortedIDOrder(t *testing.T) {
+	for run := 0; run < 5; run++ {
+		independentInitOrder = nil
+		c := New()
+		require.NoError(t, c.RegisterInstance("zeta", &independentBean{name: "zeta"}))
+		require.NoError(t, c.RegisterInstance("alpha", &independentBean{name: "alpha"}))
+		require.NoError(t, c.RegisterInstance("mu", &independentBean{name: "mu"}))
+		require.NoError(t, c.RegisterInstance("beta", &independentBean{name: "beta"}))
+
+		require.NoError(t, c.Build())
+		require.Equal(t, []string{"alpha", "beta", "mu", "zeta"}, independentInitOrder)
+	}
+}
+
+// A root outside the cycle still yields the canonical cycle only.
+type cycleRoot struct {
+	B *cycleB `di.inject:"B"`
+}
+
+func TestCycleDetection_CanonicalFromOutsideRoot(t *testing.T) {
+	c := New()
+
+	require.NoError(t, c.Register("0root", reflect.TypeOf((*cycleRoot)(nil))))
+	require.NoError(t, c.Register("A", reflect.TypeOf((*cycleA)(nil))))
+	require.NoError(t, c.Register("B", reflect.TypeOf((*cycleB)(nil))))
+
+	err := c.Build()
+	require.EqualError(t, err, "dependency cycle detected: a (field B) -> b (field A) -> a")
+}
+
+// Two roots entering the same cycle at different beans report it once, in the same canonical form.
+type cycleRootA struct {
+	A *cycleA `di.inject:"A"`
+}
+
+func TestCycleDetection_SameCycleFromTwoRoots(t *testing.T) {
+	const want = "dependency cycle detected: a (field B) -> b (field A) -> a"
+	c := New()
+
+	require.NoError(t, c.Register("0root", reflect.TypeOf((*cycleRoot)(nil))))
+	require.NoError(t, c.Register("1root", reflect.TypeOf((*cycleRootA)(nil))))
+	require.NoError(t, c.Register("A", reflect.TypeOf((*cycleA)(nil))))
+	require.NoError(t, c.Register("B", reflect.TypeOf((*cycleB)(nil))))
+
+	require.EqualError(t, c.Build(), want)
+	require.EqualError(t, c.Validate(), want)
+	require.Equal(t, [][]string{{"a", "b", "a"}}, c.GraphStats().Cycles)
+
+	order, err := c.initializationOrder()
+	require.Nil(t, order)
+	require.EqualError(t, err, "initializer order: "+want)
+}
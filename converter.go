@@ -0,0 +1,69 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Converter converts a dependency value into the type required by a receiving field.
+// The returned value must be assignable to the destination type the converter was registered for.
+type Converter func(v any) (any, error)
+
+// converterKey identifies a converter by its source and destination types.
+type converterKey struct {
+	from reflect.Type
+	to   reflect.Type
+}
+
+// RegisterConverter registers a Converter that bridges a type gap the container cannot handle natively,
+// e.g. a registered string bean injected into a uuid.UUID field. Converters are consulted when a tagged
+// field matches a dependency by ID but the types are incompatible, including values supplied by a
+// LiteralProvider. Registering a second converter for the same pair replaces the first.
+func (c *Container) RegisterConverter(from, to reflect.Type, fn Converter) error {
+	if from == nil || to == nil {
+		return ErrBeanTypeParamIsNil
+	}
+	if fn == nil {
+		return ErrConverterParamIsNil
+	}
+	if c.built.Load() {
+		return ErrRegistrationClosed
+	}
+
+	c.regMu.Lock()
+	defer c.regMu.Unlock()
+	if c.converters == nil {
+		c.converters = make(map[converterKey]Converter)
+	}
+	c.converters[converterKey{from: from, to: to}] = fn
+	return nil
+}
+
+// hasConverter reports whether a converter is registered for the given pair.
+// Callers must hold regMu.
+func (c *Container) hasConverter(from, to reflect.Type) bool {
+	_, ok := c.converters[converterKey{from: from, to: to}]
+	return ok
+}
+
+// convert runs the converter registered for (src type -> to) on src.
+// The boolean result is false when no converter is registered for the pair.
+// Callers must hold regMu.
+func (c *Container) convert(src reflect.Value, to reflect.Type) (reflect.Value, bool, error) {
+	fn, ok := c.converters[converterKey{from: src.Type(), to: to}]
+	if !ok {
+		return reflect.Value{}, false, nil
+	}
+	out, err := fn(src.Interface())
+	if err != nil {
+		return reflect.Value{}, true, err
+	}
+	if out == nil {
+		return reflect.Value{}, true, fmt.Errorf("converter from %v to %v returned nil", src.Type(), to)
+	}
+	ov := reflect.ValueOf(out)
+	if !ov.Type().AssignableTo(to) {
+		return reflect.Value{}, true, fmt.Errorf("converter from %v to %v returned %v", src.Type(), to, ov.Type())
+	}
+	return ov, true, nil
+}
@@ -0,0 +1,84 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type portReceiver struct {
+	Port int `di.inject:"Port"`
+}
+
+func atoiConverter(v any) (any, error) {
+	return strconv.Atoi(v.(string))
+}
+
+func TestConverter_StringToInt(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterConverter(reflect.TypeOf(""), reflect.TypeOf(0), atoiConverter))
+	require.NoError(t, c.Register("Receiver", reflect.TypeOf((*portReceiver)(nil))))
+	require.NoError(t, c.RegisterInstance("Port", "8080"))
+
+	require.NoError(t, c.Build())
+	r, err := ResolveAs[*portReceiver](c, "Receiver")
+	require.NoError(t, err)
+	require.Equal(t, 8080, r.Port)
+}
+
+func TestConverter_MissingConverterIsTypeMismatch(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("Receiver", reflect.TypeOf((*portReceiver)(nil))))
+	require.NoError(t, c.RegisterInstance("Port", "8080"))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "type mismatch")
+}
+
+func TestConverter_ErrorFailsInjection(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterConverter(reflect.TypeOf(""), reflect.TypeOf(0), func(v any) (any, error) {
+		return nil, errors.New("not a number")
+	}))
+	require.NoError(t, c.Register("Receiver", reflect.TypeOf((*portReceiver)(nil))))
+	require.NoError(t, c.RegisterInstance("Port", "http"))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "converting 'port' for field 'Port' of receiver bean 'receiver'")
+	require.Contains(t, err.Error(), "not a number")
+}
+
+func TestConverter_AppliesToLiteralProviderOutput(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(func(id string, typ reflect.Type) (any, bool, error) {
+		if id == "workingdir" {
+			return []byte("/from/bytes"), true, nil
+		}
+		return nil, false, nil
+	})
+
+	c := New()
+	require.NoError(t, c.RegisterConverter(reflect.TypeOf([]byte(nil)), reflect.TypeOf(""), func(v any) (any, error) {
+		return string(v.([]byte)), nil
+	}))
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+
+	require.NoError(t, c.Build())
+	cfg, err := ResolveAs[*Config](c, "ServiceBeanConfig")
+	require.NoError(t, err)
+	require.Equal(t, "/from/bytes", cfg.WorkingDir)
+}
+
+func TestConverter_RegisterValidation(t *testing.T) {
+	c := New()
+	require.ErrorIs(t, c.RegisterConverter(nil, reflect.TypeOf(0), atoiConverter), ErrBeanTypeParamIsNil)
+	require.ErrorIs(t, c.RegisterConverter(reflect.TypeOf(""), reflect.TypeOf(0), nil), ErrConverterParamIsNil)
+
+	require.NoError(t, c.Build())
+	require.ErrorIs(t, c.RegisterConverter(reflect.TypeOf(""), reflect.TypeOf(0), atoiConverter), ErrRegistrationClosed)
+}
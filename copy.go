@@ -0,0 +1,113 @@
+package iocdi
+
+import "reflect"
+
+// copyMode selects how prototype instances are derived from a bean's registered instance.
+type copyMode int
+
+const (
+	copyNone            copyMode = iota // prototypes start from a fresh zero value
+	copyShallowPointers                 // prototypes clone the template; pointers are shared
+	copyDeepPointers                    // prototypes clone the template, including pointed-to values
+)
+
+// CopyFromTemplate makes prototype injections of this bean clone the registered instance rather than start
+// from a zero value. Exported fields are copied recursively with maps and slices duplicated; pointers are
+// shared with the template. Channels and funcs are always copied by reference. Unexported fields are copied
+// shallowly.
+func CopyFromTemplate() RegisterOption {
+	return func(b *bean) {
+		b.copyMode = copyShallowPointers
+	}
+}
+
+// DeepCopyFromTemplate is like CopyFromTemplate but also duplicates the values behind pointers.
+func DeepCopyFromTemplate() RegisterOption {
+	return func(b *bean) {
+		b.copyMode = copyDeepPointers
+	}
+}
+
+// cloneInstance returns a copy of a pointer-to-struct instance according to the copy mode.
+func cloneInstance(instance any, mode copyMode) any {
+	src := reflect.ValueOf(instance)
+	c := copier{deepPointers: mode == copyDeepPointers, seen: make(map[copiedPointer]reflect.Value)}
+	return c.copyValue(src).Interface()
+}
+
+type copier struct {
+	deepPointers bool
+	seen         map[copiedPointer]reflect.Value // pointer targets already copied, for self-referencing graphs
+}
+
+// copiedPointer identifies a pointer target by type as well as address, since a struct and its first field
+// share an address.
+type copiedPointer struct {
+	typ  reflect.Type
+	addr uintptr
+}
+
+func (c copier) copyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		// The top-level instance is always cloned; nested pointers only in deep mode.
+		if len(c.seen) > 0 && !c.deepPointers {
+			return v
+		}
+		key := copiedPointer{typ: v.Type(), addr: v.Pointer()}
+		if cp, ok := c.seen[key]; ok {
+			return cp
+		}
+		cp := reflect.New(v.Type().Elem())
+		c.seen[key] = cp
+		cp.Elem().Set(c.copyValue(v.Elem()))
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v) // shallow copy, including unexported fields
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				cp.Field(i).Set(c.copyValue(v.Field(i)))
+			}
+		}
+		return cp
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), c.copyValue(iter.Value()))
+		}
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Cap())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(c.copyValue(v.Index(i)))
+		}
+		return cp
+	case reflect.Array:
+		cp := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(c.copyValue(v.Index(i)))
+		}
+		return cp
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(c.copyValue(v.Elem()))
+		return cp
+	default:
+		// Basic kinds are values; channels, funcs and unsafe pointers are copied by reference.
+		return v
+	}
+}
@@ -0,0 +1,136 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type routeTable struct {
+	Routes  map[string]string
+	Order   []string
+	Limits  *routeLimits
+	Handler func() string
+}
+
+type routeLimits struct {
+	Max int
+}
+
+type routeConsumerA struct {
+	Table *routeTable `di.inject:"Table,prototype"`
+}
+
+type routeConsumerB struct {
+	Table *routeTable `di.inject:"Table,prototype"`
+}
+
+type copyTagConsumer struct {
+	Table *routeTable `di.inject:"Table,copy"`
+}
+
+func newRouteTemplate() *routeTable {
+	return &routeTable{
+		Routes:  map[string]string{"/": "index"},
+		Order:   []string{"/"},
+		Limits:  &routeLimits{Max: 10},
+		Handler: func() string { return "ok" },
+	}
+}
+
+func TestCopyFromTemplate_MapsAndSlicesAreIndependent(t *testing.T) {
+	template := newRouteTemplate()
+	c := New()
+	require.NoError(t, c.RegisterInstance("Table", template, CopyFromTemplate()))
+	require.NoError(t, c.Register("A", reflect.TypeOf((*routeConsumerA)(nil))))
+	require.NoError(t, c.Register("B", reflect.TypeOf((*routeConsumerB)(nil))))
+	require.NoError(t, c.Build())
+
+	a, err := ResolveAs[*routeConsumerA](c, "A")
+	require.NoError(t, err)
+	b, err := ResolveAs[*routeConsumerB](c, "B")
+	require.NoError(t, err)
+
+	require.NotSame(t, template, a.Table)
+	require.Equal(t, "index", a.Table.Routes["/"])
+	require.Equal(t, "ok", a.Table.Handler())
+
+	a.Table.Routes["/admin"] = "admin"
+	a.Table.Order = append(a.Table.Order, "/admin")
+	a.Table.Order[0] = "/changed"
+
+	require.NotContains(t, template.Routes, "/admin")
+	require.NotContains(t, b.Table.Routes, "/admin")
+	require.Equal(t, []string{"/"}, template.Order)
+	require.Equal(t, []string{"/"}, b.Table.Order)
+
+	// Pointers are shared in the default (shallow) mode.
+	require.Same(t, template.Limits, a.Table.Limits)
+}
+
+func TestDeepCopyFromTemplate_DuplicatesPointers(t *testing.T) {
+	template := newRouteTemplate()
+	c := New()
+	require.NoError(t, c.RegisterInstance("Table", template, DeepCopyFromTemplate()))
+	require.NoError(t, c.Register("A", reflect.TypeOf((*routeConsumerA)(nil))))
+	require.NoError(t, c.Build())
+
+	a, err := ResolveAs[*routeConsumerA](c, "A")
+	require.NoError(t, err)
+	require.NotSame(t, template.Limits, a.Table.Limits)
+	a.Table.Limits.Max = 99
+	require.Equal(t, 10, template.Limits.Max)
+}
+
+// routeCursor points into itself: Current starts out at the address of Limits.
+type routeCursor struct {
+	Limits  routeLimits
+	Current *routeLimits
+}
+
+type routeCursorConsumer struct {
+	Cursor *routeCursor `di.inject:"Cursor,prototype"`
+}
+
+func TestDeepCopyFromTemplate_PointerToFirstField(t *testing.T) {
+	template := &routeCursor{Limits: routeLimits{Max: 10}}
+	template.Current = &template.Limits
+	c := New()
+	require.NoError(t, c.RegisterInstance("Cursor", template, DeepCopyFromTemplate()))
+	require.NoError(t, c.Register("Consumer", reflect.TypeOf((*routeCursorConsumer)(nil))))
+	require.NoError(t, c.Build())
+
+	rc, err := ResolveAs[*routeCursorConsumer](c, "Consumer")
+	require.NoError(t, err)
+	require.NotSame(t, template.Current, rc.Cursor.Current)
+	require.Equal(t, 10, rc.Cursor.Current.Max)
+	rc.Cursor.Current.Max = 99
+	require.Equal(t, 10, template.Limits.Max)
+}
+
+func TestCopyTagOption_ClonesWithoutRegistrationOption(t *testing.T) {
+	template := newRouteTemplate()
+	c := New()
+	require.NoError(t, c.RegisterInstance("Table", template))
+	require.NoError(t, c.Register("Consumer", reflect.TypeOf((*copyTagConsumer)(nil))))
+	require.NoError(t, c.Build())
+
+	cc, err := ResolveAs[*copyTagConsumer](c, "Consumer")
+	require.NoError(t, err)
+	require.NotSame(t, template, cc.Table)
+	require.Equal(t, template.Routes, cc.Table.Routes)
+	cc.Table.Routes["/x"] = "x"
+	require.NotContains(t, template.Routes, "/x")
+}
+
+func TestPrototypeWithoutCopyStartsFromZeroValue(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("Table", newRouteTemplate()))
+	require.NoError(t, c.Register("A", reflect.TypeOf((*routeConsumerA)(nil))))
+	require.NoError(t, c.Build())
+
+	a, err := ResolveAs[*routeConsumerA](c, "A")
+	require.NoError(t, err)
+	require.Nil(t, a.Table.Routes)
+}
@@ -0,0 +1,47 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEagerCycleCheck_ReportedOnClosingRegistration(t *testing.T) {
+	c := New(WithEagerCycleCheck())
+
+	// B is not registered yet; a missing dependency is not an error at this stage.
+	require.NoError(t, c.Register("A", reflect.TypeOf((*cycleA)(nil))))
+
+	err := c.Register("B", reflect.TypeOf((*cycleB)(nil)))
+	require.EqualError(t, err, "dependency cycle detected: a (field B) -> b (field A) -> a")
+
+	// The rejected registration is not kept.
+	require.NotContains(t, c.registeredBeans, "b")
+}
+
+func TestEagerCycleCheck_SelfCycle(t *testing.T) {
+	c := New(WithEagerCycleCheck())
+
+	err := c.Register("Aself", reflect.TypeOf((*selfCycleA)(nil)))
+	require.EqualError(t, err, "dependency cycle detected: aself (field A) -> aself")
+}
+
+func TestEagerCycleCheck_AcyclicGraphRegisters(t *testing.T) {
+	c := New(WithEagerCycleCheck())
+
+	require.NoError(t, c.Register("OrderC", reflect.TypeOf((*orderC)(nil))))
+	require.NoError(t, c.Register("OrderA", reflect.TypeOf((*orderA)(nil))))
+	require.NoError(t, c.Register("OrderB", reflect.TypeOf((*orderB)(nil))))
+}
+
+func TestEagerCycleCheck_DisabledByDefault(t *testing.T) {
+	c := New()
+
+	require.NoError(t, c.Register("A", reflect.TypeOf((*cycleA)(nil))))
+	require.NoError(t, c.Register("B", reflect.TypeOf((*cycleB)(nil))))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dependency cycle detected:")
+}
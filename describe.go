@@ -0,0 +1,280 @@
+package iocdi
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// BeanInfo summarizes a registered bean. See Beans.
+type BeanInfo struct {
+	// ID is the lower-cased bean ID.
+	ID string
+	// Type is the registered type of the bean, e.g. "*main.Service".
+	Type string
+	// Singleton reports whether the bean holds a shared instance.
+	Singleton bool
+	// HasDependencies reports whether the bean has `di.inject` fields.
+	HasDependencies bool
+	// Dependencies lists the IDs named by the bean's `di.inject` tags, in field order.
+	Dependencies []string
+	// Instantiated reports whether the bean has an instance; before Build only registered instances have one.
+	Instantiated bool
+	// Initialized reports whether a Build wired the bean and its Initialize, if any, succeeded.
+	Initialized bool
+}
+
+// BeanIDs returns the IDs of the registered beans in sorted order. It does not build the container.
+func (c *Container) BeanIDs() []string {
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+	return sortedKeys(c.registeredBeans)
+}
+
+// Beans returns a summary of every registered bean, sorted by ID, including the beans synthesized from the
+// LiteralProvider during Build. The summaries are copies. It does not build the container.
+func (c *Container) Beans() []BeanInfo {
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	infos := make([]BeanInfo, 0, len(c.registeredBeans))
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		info := BeanInfo{
+			ID:              bn.id,
+			Singleton:       bn.singleton,
+			HasDependencies: bn.hasDependencies,
+			Dependencies:    slices.Clone(bn.dependencies),
+			Instantiated:    bn.instance != nil,
+			Initialized:     bn.initialized,
+		}
+		if bn.beanType != nil {
+			info.Type = bn.beanType.String()
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// BeanDescription reports what the container knows about a single bean. See DescribeBean.
+type BeanDescription struct {
+	// ID is the lower-cased bean ID.
+	ID string
+	// Type is the registered type of the bean, e.g. "*main.Service".
+	Type string
+	// Singleton reports whether the bean holds a shared instance.
+	Singleton bool
+	// Supplied reports whether the instance was given by the caller (RegisterInstance, ReplaceInstance).
+	Supplied bool
+	// Literal reports whether the bean was synthesized from the LiteralProvider.
+	Literal bool
+	// Synthetic reports whether the bean was synthesized from the MissingBeanProvider.
+	Synthetic bool
+	// Secret reports whether the bean was marked with MarkSecret.
+	Secret bool
+	// Value renders the instance of a bean of a basic kind, such as a literal, or «redacted» for a secret.
+	// It is empty for other beans.
+	Value string
+	// Instantiated reports whether the bean has an instance.
+	Instantiated bool
+	// Initialized reports whether a Build wired the bean and its Initialize, if any, succeeded.
+	Initialized bool
+	// Dependencies lists the bean's dependency edges: tagged fields in field order, then autowired fields.
+	Dependencies []DependencyEdge
+	// Primary, Prototype, Lazy, Groups and InitPriority echo the registration options.
+	Primary      bool
+	Prototype    bool
+	Lazy         bool
+	Groups       []string
+	InitPriority int
+}
+
+// DependencySource tells how a dependency edge is satisfied.
+type DependencySource int
+
+const (
+	// DependencyMissing means no bean is registered under the dependency ID. Before Build this includes
+	// strings the LiteralProvider will supply.
+	DependencyMissing DependencySource = iota
+	// DependencyRegistered means the dependency is a registered bean.
+	DependencyRegistered
+	// DependencyLiteral means the dependency was synthesized from the LiteralProvider.
+	DependencyLiteral
+)
+
+func (s DependencySource) String() string {
+	switch s {
+	case DependencyMissing:
+		return "missing"
+	case DependencyRegistered:
+		return "registered"
+	case DependencyLiteral:
+		return "literal"
+	}
+	return "unknown"
+}
+
+// DependencyEdge describes one dependency of a bean and the field that created it.
+type DependencyEdge struct {
+	// ID is the lower-cased ID of the dependency.
+	ID string
+	// Field is the name of the receiving struct field.
+	Field string
+	// Prototype reports whether the field receives a fresh instance rather than the shared singleton.
+	Prototype bool
+	// Autowired reports whether the edge was chosen by autowiring rather than a `di.inject` tag.
+	Autowired bool
+	// Source tells how the dependency is satisfied.
+	Source DependencySource
+}
+
+// DescribeBean returns a description of the registered bean, including its dependency edges, to debug why a
+// field was left nil. It does not build the container.
+func (c *Container) DescribeBean(beanID string) (BeanDescription, error) {
+	if beanID == emptyString {
+		return BeanDescription{}, ErrBeanIdParamIsEmpty
+	}
+
+	beanID = strings.ToLower(beanID)
+
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	bn, ok := c.registeredBeans[beanID]
+	if !ok {
+		return BeanDescription{}, &BeanError{ID: beanID, Err: ErrBeanNotFound}
+	}
+	d := BeanDescription{
+		ID:           bn.id,
+		Singleton:    bn.singleton,
+		Supplied:     bn.supplied,
+		Literal:      bn.literal,
+		Synthetic:    bn.synthetic,
+		Secret:       c.secrets[bn.id],
+		Instantiated: bn.instance != nil,
+		Initialized:  bn.initialized,
+		Primary:      bn.primary,
+		Prototype:    bn.prototype,
+		Lazy:         bn.lazy,
+		Groups:       slices.Clone(bn.groups),
+		InitPriority: bn.initPriority,
+	}
+	if bn.beanType != nil {
+		d.Type = bn.beanType.String()
+	}
+	if bn.instance != nil && isLiteralKind(bn.beanType) {
+		d.Value = c.displayValue(bn.id, bn.instance)
+	}
+	for _, fd := range bn.fields {
+		d.Dependencies = append(d.Dependencies, DependencyEdge{
+			ID:        fd.id,
+			Field:     fd.field,
+			Prototype: fd.prototype,
+			Source:    c.dependencySource(fd.id),
+		})
+	}
+	for _, af := range bn.autowired {
+		d.Dependencies = append(d.Dependencies, DependencyEdge{
+			ID:        af.id,
+			Field:     af.field,
+			Autowired: true,
+			Source:    c.dependencySource(af.id),
+		})
+	}
+	return d, nil
+}
+
+// dependencySource tells how the dependency with the ID is satisfied.
+// Callers must hold regMu.
+func (c *Container) dependencySource(id string) DependencySource {
+	dep, ok := c.registeredBeans[id]
+	switch {
+	case !ok:
+		return DependencyMissing
+	case dep.literal:
+		return DependencyLiteral
+	}
+	return DependencyRegistered
+}
+
+// Describe writes a human-readable overview of the container for debug dumps: its state, a table of the beans
+// with their type, scope, state, dependency count and the dependencies supplied by a literal source or missing,
+// then the initialization order and the error of the last failed Build, if any. The output is sorted and does
+// not vary between runs. It is safe on a container in any state and does not build it.
+func (c *Container) Describe(w io.Writer) error {
+	state := c.State()
+	buildErr := c.BuildError()
+
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "State: %s\n\n", state)
+
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTYPE\tSCOPE\tSTATE\tDEPS\tFLAGS")
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		typ := "-"
+		if bn.beanType != nil {
+			typ = bn.beanType.String()
+		}
+		deps := c.edges(bn)
+		flags := make([]string, 0)
+		for _, dep := range deps {
+			switch c.satisfiedBy(dep) {
+			case DependencyLiteral:
+				flags = append(flags, "literal:"+dep)
+			case DependencyMissing:
+				flags = append(flags, "missing:"+dep)
+			}
+		}
+		if len(flags) == 0 {
+			flags = append(flags, "-")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\n", id, typ, bn.scope(), bn.state(), len(deps), strings.Join(flags, ","))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	sb.WriteString("\nInitialization order:\n")
+	if len(c.initOrder) == 0 {
+		sb.WriteString("  (none)\n")
+	}
+	for i, id := range c.initOrder {
+		fmt.Fprintf(&sb, "  %d. %s\n", i+1, id)
+	}
+	if buildErr != nil {
+		fmt.Fprintf(&sb, "\nBuild error:\n  %s\n", strings.ReplaceAll(buildErr.Error(), "\n", "\n  "))
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// scope names how the bean's instances are shared, for Describe.
+func (b bean) scope() string {
+	switch {
+	case b.prototype:
+		return "prototype"
+	case b.literal:
+		return "literal"
+	case b.lazy:
+		return "lazy"
+	}
+	return "singleton"
+}
+
+// state names how far the bean has been built, for Describe.
+func (b bean) state() string {
+	switch {
+	case b.initialized:
+		return "initialized"
+	case b.instance != nil:
+		return "instantiated"
+	}
+	return "registered"
+}
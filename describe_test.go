@@ -0,0 +1,153 @@
+package iocdi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newServiceGraph registers the Service graph, leaving WorkingDir to the LiteralProvider.
+func newServiceGraph(t *testing.T, opts ...Option) *Container {
+	t.Helper()
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		if id == "workingdir" {
+			return "/srv/app", true, nil
+		}
+		return nil, false, nil
+	})
+
+	c := New(opts...)
+	require.NoError(t, c.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	require.NoError(t, c.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+	return c
+}
+
+func TestBeans(t *testing.T) {
+	c := newServiceGraph(t)
+	require.Equal(t, []string{"servicebean", "servicebeanconfig", "servicebeanlogger"}, c.BeanIDs())
+
+	beans := c.Beans()
+	require.Len(t, beans, 3)
+	require.Equal(t, BeanInfo{
+		ID:              "servicebean",
+		Type:            "*iocdi.Service",
+		HasDependencies: true,
+		Dependencies:    []string{"servicebeanconfig", "servicebeanlogger"},
+	}, beans[0])
+	require.Equal(t, []string{"workingdir"}, beans[1].Dependencies)
+	require.False(t, beans[2].HasDependencies)
+
+	// Copies: mutating the result leaves the container alone
+	beans[0].Dependencies[0] = "changed"
+	require.Equal(t, "servicebeanconfig", c.Beans()[0].Dependencies[0])
+
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"servicebean", "servicebeanconfig", "servicebeanlogger", "workingdir"}, c.BeanIDs())
+	beans = c.Beans()
+	require.Len(t, beans, 4)
+	for _, info := range beans {
+		require.True(t, info.Instantiated, info.ID)
+	}
+	require.True(t, beans[0].Singleton)
+	require.True(t, beans[0].Initialized)
+	require.Equal(t, "string", beans[3].Type)
+}
+
+func TestDescribeBean_Edges(t *testing.T) {
+	c := newServiceGraph(t)
+
+	d, err := c.DescribeBean("ServiceBeanConfig")
+	require.NoError(t, err)
+	require.Equal(t, []DependencyEdge{{ID: "workingdir", Field: "WorkingDir", Source: DependencyMissing}}, d.Dependencies)
+
+	require.NoError(t, c.Build())
+
+	d, err = c.DescribeBean("ServiceBean")
+	require.NoError(t, err)
+	require.Equal(t, "servicebean", d.ID)
+	require.Equal(t, "*iocdi.Service", d.Type)
+	require.True(t, d.Singleton)
+	require.False(t, d.Supplied)
+	require.True(t, d.Initialized)
+	require.Equal(t, []DependencyEdge{
+		{ID: "servicebeanconfig", Field: "Config", Source: DependencyRegistered},
+		{ID: "servicebeanlogger", Field: "Logger", Source: DependencyRegistered},
+	}, d.Dependencies)
+
+	d, err = c.DescribeBean("ServiceBeanConfig")
+	require.NoError(t, err)
+	require.Equal(t, []DependencyEdge{{ID: "workingdir", Field: "WorkingDir", Source: DependencyLiteral}}, d.Dependencies)
+	require.Equal(t, "literal", d.Dependencies[0].Source.String())
+
+	d, err = c.DescribeBean("workingdir")
+	require.NoError(t, err)
+	require.True(t, d.Literal)
+
+	_, err = c.DescribeBean("missing")
+	require.EqualError(t, err, "bean 'missing' not found")
+}
+
+func TestDescribeBean_RegistrationMetadata(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("mail", &byTypeMail{}, Primary(), InitPriority(-1), Lazy()))
+
+	d, err := c.DescribeBean("mail")
+	require.NoError(t, err)
+	require.True(t, d.Supplied)
+	require.True(t, d.Primary)
+	require.True(t, d.Lazy)
+	require.Equal(t, -1, d.InitPriority)
+	require.Empty(t, d.Dependencies)
+}
+
+func TestDescribe_Built(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*sleepyInit)(nil)), Lazy()))
+	require.NoError(t, c.Build())
+
+	var sb strings.Builder
+	require.NoError(t, c.Describe(&sb))
+	want := `State: Built
+
+ID                 TYPE               SCOPE      STATE        DEPS  FLAGS
+servicebean        *iocdi.Service     singleton  initialized  2     -
+servicebeanconfig  *iocdi.Config      singleton  initialized  1     literal:workingdir
+servicebeanlogger  *iocdi.Logger      singleton  initialized  0     -
+slow               *iocdi.sleepyInit  lazy       registered   1     -
+workingdir         string             literal    initialized  0     -
+
+Initialization order:
+  1. servicebeanlogger
+  2. workingdir
+  3. servicebeanconfig
+  4. servicebean
+`
+	require.Equal(t, want, sb.String())
+}
+
+func TestDescribe_BuildFailed(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+	require.NoError(t, c.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+	require.Error(t, c.Build())
+
+	var sb strings.Builder
+	require.NoError(t, c.Describe(&sb))
+	want := `State: BuildFailed
+
+ID                 TYPE            SCOPE      STATE       DEPS  FLAGS
+servicebean        *iocdi.Service  singleton  registered  2     missing:servicebeanconfig
+servicebeanlogger  *iocdi.Logger   singleton  registered  0     -
+
+Initialization order:
+  (none)
+
+Build error:
+  bean ` + "`servicebeanconfig`" + ` is required but not registered
+`
+	require.Equal(t, want, sb.String())
+}
@@ -0,0 +1,52 @@
+package iocdi
+
+import "context"
+
+// Destroyer is an optional interface that a bean may implement to release resources
+// (connections, files, goroutines) when the container is closed.
+//
+// The container calls Destroy() from Close(), walking beans in reverse initialization
+// order so dependents are destroyed before their dependencies. Errors from all beans are
+// collected; a failing Destroy does not prevent the remaining beans from being destroyed.
+type Destroyer interface {
+	Destroy() error
+}
+
+// ContextDestroyer is the context-aware variant of Destroyer. When a bean implements both, CloseContext calls
+// DestroyCtx instead of Destroy, passing its context so the bean can honor the shutdown deadline.
+type ContextDestroyer interface {
+	DestroyCtx(ctx context.Context) error
+}
+
+// isDestroyer reports whether instance implements Destroyer or ContextDestroyer.
+func isDestroyer(instance any) bool {
+	_, ok := instance.(Destroyer)
+	_, okCtx := instance.(ContextDestroyer)
+	return ok || okCtx
+}
+
+// destroyWithin destroys instance, preferring ContextDestroyer over Destroyer. A plain Destroy runs in a
+// goroutine that is abandoned when ctx is done. Panics are returned as a PanicError attributed to beanID.
+func destroyWithin(ctx context.Context, beanID string, instance any) error {
+	if d, ok := instance.(ContextDestroyer); ok {
+		return callRecovered(beanID, func() error { return d.DestroyCtx(ctx) })
+	}
+	d, ok := instance.(Destroyer)
+	if !ok {
+		return nil
+	}
+	if ctx.Done() == nil {
+		return callRecovered(beanID, d.Destroy)
+	}
+
+	done := make(chan error, 1) // buffered so an abandoned Destroy can still finish
+	go func() {
+		done <- callRecovered(beanID, d.Destroy)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,224 @@
+package iocdi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var lifecycleLog []string
+
+type lifecycleRepo struct{}
+
+func (r *lifecycleRepo) Initialize() error {
+	lifecycleLog = append(lifecycleLog, "init:repo")
+	return nil
+}
+
+func (r *lifecycleRepo) Destroy() error {
+	lifecycleLog = append(lifecycleLog, "destroy:repo")
+	return nil
+}
+
+type lifecycleService struct {
+	Repo *lifecycleRepo `di.inject:"repo"`
+}
+
+func (s *lifecycleService) Initialize() error {
+	lifecycleLog = append(lifecycleLog, "init:service")
+	return nil
+}
+
+func (s *lifecycleService) Destroy() error {
+	lifecycleLog = append(lifecycleLog, "destroy:service")
+	return errors.New("service flush failed")
+}
+
+type lifecycleHandler struct {
+	Service *lifecycleService `di.inject:"service"`
+}
+
+func (h *lifecycleHandler) Initialize() error {
+	lifecycleLog = append(lifecycleLog, "init:handler")
+	return nil
+}
+
+func (h *lifecycleHandler) Destroy() error {
+	lifecycleLog = append(lifecycleLog, "destroy:handler")
+	return errors.New("handler drain failed")
+}
+
+func newLifecycleContainer(t *testing.T) *Container {
+	t.Helper()
+	c := New()
+	require.NoError(t, c.Register("handler", reflect.TypeOf((*lifecycleHandler)(nil))))
+	require.NoError(t, c.Register("service", reflect.TypeOf((*lifecycleService)(nil))))
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*lifecycleRepo)(nil))))
+	return c
+}
+
+func TestClose_ReverseOfInitializeOrder(t *testing.T) {
+	lifecycleLog = nil
+	c := newLifecycleContainer(t)
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"init:repo", "init:service", "init:handler"}, lifecycleLog)
+
+	lifecycleLog = nil
+	err := c.Close()
+	require.Equal(t, []string{"destroy:handler", "destroy:service", "destroy:repo"}, lifecycleLog)
+
+	// Every failure is reported, not just the first
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "destroyer for bean 'handler' failed: handler drain failed")
+	require.Contains(t, err.Error(), "destroyer for bean 'service' failed: service flush failed")
+}
+
+func TestClose_Idempotent(t *testing.T) {
+	lifecycleLog = nil
+	c := newLifecycleContainer(t)
+	require.NoError(t, c.Build())
+	require.Error(t, c.Close())
+
+	lifecycleLog = nil
+	require.NoError(t, c.Close())
+	require.Empty(t, lifecycleLog)
+}
+
+func TestClose_ClosedContainerRejectsUse(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("name", "value"))
+	require.NoError(t, c.Build())
+	require.NoError(t, c.Close())
+
+	_, err := c.ResolveSafe("name")
+	require.ErrorIs(t, err, ErrContainerClosed)
+	require.ErrorIs(t, c.Build(), ErrContainerClosed)
+	require.ErrorIs(t, c.RegisterInstance("other", "value"), ErrContainerClosed)
+	require.ErrorIs(t, c.Inject(&struct {
+		Name string `di.inject:"name"`
+	}{}), ErrContainerClosed)
+}
+
+func TestClose_UnbuiltContainer(t *testing.T) {
+	lifecycleLog = nil
+	c := newLifecycleContainer(t)
+	require.NoError(t, c.Close())
+	require.Empty(t, lifecycleLog)
+	require.ErrorIs(t, c.Build(), ErrContainerClosed)
+}
+
+// closeLog is written from abandoned Destroy goroutines, so access is guarded
+var (
+	closeMu  sync.Mutex
+	closeLog []string
+)
+
+func recordClose(name string) {
+	closeMu.Lock()
+	defer closeMu.Unlock()
+	closeLog = append(closeLog, name)
+}
+
+func resetCloseLog() {
+	closeMu.Lock()
+	defer closeMu.Unlock()
+	closeLog = nil
+}
+
+func closeEntries() []string {
+	closeMu.Lock()
+	defer closeMu.Unlock()
+	return append([]string(nil), closeLog...)
+}
+
+type slowDestroyer struct{}
+
+func (s *slowDestroyer) Destroy() error {
+	recordClose("slow")
+	time.Sleep(300 * time.Millisecond)
+	return nil
+}
+
+type quickDestroyer struct {
+	Slow *slowDestroyer `di.inject:"slow"`
+}
+
+func (q *quickDestroyer) Destroy() error {
+	recordClose("quick")
+	return nil
+}
+
+// ctxDestroyer honors the deadline and is preferred over Destroy
+type ctxDestroyer struct {
+	Quick *quickDestroyer `di.inject:"quick"`
+	plain bool
+}
+
+func (d *ctxDestroyer) Destroy() error {
+	d.plain = true
+	return nil
+}
+
+func (d *ctxDestroyer) DestroyCtx(ctx context.Context) error {
+	recordClose("ctx")
+	return ctx.Err()
+}
+
+type baseDestroyer struct{}
+
+func (b *baseDestroyer) Destroy() error {
+	recordClose("base")
+	return nil
+}
+
+type topDestroyer struct {
+	Base *baseDestroyer `di.inject:"base"`
+	Slow *slowDestroyer `di.inject:"slow"`
+}
+
+func (t *topDestroyer) Destroy() error {
+	recordClose("top")
+	return nil
+}
+
+func TestCloseContext_AllComplete(t *testing.T) {
+	resetCloseLog()
+	c := New()
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*slowDestroyer)(nil))))
+	require.NoError(t, c.Register("quick", reflect.TypeOf((*quickDestroyer)(nil))))
+	require.NoError(t, c.Register("ctx", reflect.TypeOf((*ctxDestroyer)(nil))))
+	require.NoError(t, c.Build())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, c.CloseContext(ctx))
+	require.Equal(t, []string{"ctx", "quick", "slow"}, closeEntries())
+
+	d, _ := c.registeredBeans["ctx"].instance.(*ctxDestroyer)
+	require.False(t, d.plain)
+}
+
+func TestCloseContext_DeadlineSkipsRemainingBeans(t *testing.T) {
+	resetCloseLog()
+	c := New()
+	require.NoError(t, c.Register("base", reflect.TypeOf((*baseDestroyer)(nil))))
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*slowDestroyer)(nil))))
+	require.NoError(t, c.Register("top", reflect.TypeOf((*topDestroyer)(nil))))
+	require.NoError(t, c.Build())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := c.CloseContext(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Contains(t, err.Error(), "destroyer for bean 'slow' failed: context deadline exceeded")
+	require.Contains(t, err.Error(), "close aborted, beans not destroyed: base")
+
+	// top ran before slow; base was never reached
+	require.Equal(t, []string{"top", "slow"}, closeEntries())
+	require.NoError(t, c.Close())
+}
@@ -0,0 +1,124 @@
+package iocdi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GraphDiff lists the wiring differences between two containers. See Diff.
+type GraphDiff struct {
+	// Added and Removed list the IDs of the beans registered only in the second or only in the first container.
+	Added   []string
+	Removed []string
+	// Changed lists the beans registered in both whose type or dependency edges differ, sorted by ID.
+	Changed []BeanChange
+}
+
+// BeanChange describes how a bean registered in both containers of a Diff differs.
+type BeanChange struct {
+	ID string
+	// OldType and NewType are the bean's types in the first and second container; they are equal when only the
+	// edges changed.
+	OldType string
+	NewType string
+	// AddedEdges and RemovedEdges list the edges only in the second or only in the first container, sorted and
+	// rendered as "Field -> dependency".
+	AddedEdges   []string
+	RemovedEdges []string
+}
+
+// Empty reports whether the two containers are wired the same.
+func (d GraphDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String renders one difference per line, e.g. "+ bean cache" or "~ bean service: - edge Repo -> repo", and is
+// empty when there is none.
+func (d GraphDiff) String() string {
+	var sb strings.Builder
+	for _, id := range d.Added {
+		fmt.Fprintf(&sb, "+ bean %s\n", id)
+	}
+	for _, id := range d.Removed {
+		fmt.Fprintf(&sb, "- bean %s\n", id)
+	}
+	for _, ch := range d.Changed {
+		if ch.OldType != ch.NewType {
+			fmt.Fprintf(&sb, "~ bean %s: type %s -> %s\n", ch.ID, ch.OldType, ch.NewType)
+		}
+		for _, e := range ch.AddedEdges {
+			fmt.Fprintf(&sb, "~ bean %s: + edge %s\n", ch.ID, e)
+		}
+		for _, e := range ch.RemovedEdges {
+			fmt.Fprintf(&sb, "~ bean %s: - edge %s\n", ch.ID, e)
+		}
+	}
+	return sb.String()
+}
+
+// beanWiring is the part of a bean Diff compares and Fingerprint hashes.
+type beanWiring struct {
+	typ     string
+	scope   string
+	literal bool     // synthesized from a literal source
+	edges   []string // sorted "Field -> dependency"
+}
+
+// Diff compares the registered bean IDs, their types and their dependency edges of two containers, e.g. to
+// prove in a test that refactoring a composition root kept the wiring: build the old and the new root and
+// require Diff to be Empty. Instances are compared by type only, never by value. Edges from autowired fields are
+// only known after Build. Neither container is built.
+func Diff(a, b *Container) GraphDiff {
+	before, after := a.wiring(), b.wiring()
+
+	var d GraphDiff
+	for _, id := range sortedKeys(after) {
+		if _, ok := before[id]; !ok {
+			d.Added = append(d.Added, id)
+		}
+	}
+	for _, id := range sortedKeys(before) {
+		old := before[id]
+		cur, ok := after[id]
+		if !ok {
+			d.Removed = append(d.Removed, id)
+			continue
+		}
+		ch := BeanChange{ID: id, OldType: old.typ, NewType: cur.typ}
+		for _, e := range cur.edges {
+			if !slices.Contains(old.edges, e) {
+				ch.AddedEdges = append(ch.AddedEdges, e)
+			}
+		}
+		for _, e := range old.edges {
+			if !slices.Contains(cur.edges, e) {
+				ch.RemovedEdges = append(ch.RemovedEdges, e)
+			}
+		}
+		if ch.OldType != ch.NewType || len(ch.AddedEdges) > 0 || len(ch.RemovedEdges) > 0 {
+			d.Changed = append(d.Changed, ch)
+		}
+	}
+	return d
+}
+
+// wiring snapshots the type, scope and edges of every registered bean for Diff and Fingerprint.
+func (c *Container) wiring() map[string]beanWiring {
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	wiring := make(map[string]beanWiring, len(c.registeredBeans))
+	for id, bn := range c.registeredBeans {
+		w := beanWiring{scope: bn.scope(), literal: bn.literal}
+		if bn.beanType != nil {
+			w.typ = bn.beanType.String()
+		}
+		for _, dep := range c.edges(bn) {
+			w.edges = append(w.edges, c.edgeField(id, dep)+pathSep+dep)
+		}
+		slices.Sort(w.edges)
+		wiring[id] = w
+	}
+	return wiring
+}
@@ -0,0 +1,77 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type auditLogger struct{ _ byte }
+
+func TestDiff_SameWiring(t *testing.T) {
+	a := New()
+	require.NoError(t, a.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+	require.NoError(t, a.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	require.NoError(t, a.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+
+	b := New()
+	require.NoError(t, b.RegisterInstance("ServiceBeanLogger", &Logger{}))
+	require.NoError(t, b.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	require.NoError(t, b.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+
+	d := Diff(a, b)
+	require.True(t, d.Empty())
+	require.Empty(t, d.String())
+}
+
+func TestDiff_AddedAndRemovedBeans(t *testing.T) {
+	a := New()
+	require.NoError(t, a.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, a.Register("legacy", reflect.TypeOf((*Logger)(nil))))
+
+	b := New()
+	require.NoError(t, b.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, b.Register("audit", reflect.TypeOf((*auditLogger)(nil))))
+
+	d := Diff(a, b)
+	require.Equal(t, []string{"audit"}, d.Added)
+	require.Equal(t, []string{"legacy"}, d.Removed)
+	require.Empty(t, d.Changed)
+	require.Equal(t, "+ bean audit\n- bean legacy\n", d.String())
+}
+
+func TestDiff_RemovedEdge(t *testing.T) {
+	a := New()
+	require.NoError(t, a.Register("router", reflect.TypeOf((*router)(nil))))
+	require.NoError(t, a.Register("h1-users", reflect.TypeOf((*usersHandler)(nil)), InGroups("http.handlers")))
+	require.NoError(t, a.Register("h2-status", reflect.TypeOf((*statusHandler)(nil))))
+
+	b := New()
+	require.NoError(t, b.Register("router", reflect.TypeOf((*router)(nil))))
+	require.NoError(t, b.Register("h1-users", reflect.TypeOf((*usersHandler)(nil))))
+	require.NoError(t, b.Register("h2-status", reflect.TypeOf((*statusHandler)(nil))))
+
+	d := Diff(a, b)
+	require.Empty(t, d.Added)
+	require.Empty(t, d.Removed)
+	require.Equal(t, []BeanChange{{
+		ID:           "router",
+		OldType:      "*iocdi.router",
+		NewType:      "*iocdi.router",
+		RemovedEdges: []string{"Handlers -> h1-users"},
+	}}, d.Changed)
+	require.Equal(t, "~ bean router: - edge Handlers -> h1-users\n", d.String())
+}
+
+func TestDiff_TypeChange(t *testing.T) {
+	a := New()
+	require.NoError(t, a.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+
+	b := New()
+	require.NoError(t, b.Register("ServiceBeanLogger", reflect.TypeOf((*auditLogger)(nil))))
+
+	d := Diff(a, b)
+	require.Equal(t, []BeanChange{{ID: "servicebeanlogger", OldType: "*iocdi.Logger", NewType: "*iocdi.auditLogger"}}, d.Changed)
+	require.Equal(t, "~ bean servicebeanlogger: type *iocdi.Logger -> *iocdi.auditLogger\n", d.String())
+}
@@ -0,0 +1,150 @@
+package iocdi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Validate checks the wiring without building anything, e.g. in CI: every tagged field must have an injectable
+// type, receivers must agree on the type of each dependency, every dependency must be registered with a
+// compatible type or be left to a literal provider (which must supply it under WithEagerLiteralCheck), and the
+// declared edges must be free of cycles. Every problem found is reported in one joined error.
+//
+// No instance is created, injected or initialized, no bean is synthesized and the container stays unbuilt;
+// only literal providers are called, and only with WithEagerLiteralCheck. Dependencies left to a
+// MissingBeanProvider are assumed to be supplied, since asking it would create them. Autowired fields are only
+// chosen by Build and are not checked.
+func (c *Container) Validate() error {
+	if c.closed.Load() {
+		return ErrContainerClosed
+	}
+
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	var errs []error
+	for _, id := range sortedKeys(c.registeredBeans) {
+		if bn := c.registeredBeans[id]; !bn.literal && bn.beanType != nil {
+			errs = append(errs, uninjectableFields(id, bn.beanType)...)
+		}
+	}
+	if err := c.checkRequirementConflicts(); err != nil {
+		errs = append(errs, err)
+	}
+	required := c.derivedRequirements()
+	for _, id := range sortedKeys(required) {
+		if err := c.validateRequired(id, required[id]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	errs = append(errs, c.declaredCycles()...)
+	return errors.Join(errs...)
+}
+
+// validateRequired checks a single required dependency like the Build precheck, without storing anything.
+// Callers must hold regMu.
+func (c *Container) validateRequired(id string, requiredType reflect.Type) error {
+	if requiredType == nil {
+		return nil
+	}
+	if bn, ok := c.registeredBeans[id]; ok {
+		if !c.satisfies(bn.beanType, requiredType) {
+			return &TypeMismatchError{BeanID: id, Required: requiredType, Registered: bn.beanType}
+		}
+		return nil
+	}
+
+	switch {
+	case isLiteralKind(requiredType) && c.hasLiteralProvider():
+		if !c.eagerLiterals {
+			return nil
+		}
+		_, found, _, err := c.lookupLiteral(c.literalRequest(c.firstReceiver(id), id))
+		if err != nil {
+			return fmt.Errorf("literal provider error for '%s': %w", id, err)
+		}
+		if !found {
+			return fmt.Errorf("bean `%s` is required but no literal provider supplies it", id)
+		}
+		return nil
+	case c.missingBeanProvider.Load() != nil:
+		return nil
+	}
+	return c.missingRequirement(id)
+}
+
+// uninjectableFields reports the `di.inject` fields of a struct bean that Build would silently skip: unexported
+// fields, types the container cannot inject, and group tags on fields that are not slices.
+func uninjectableFields(id string, beanType reflect.Type) []error {
+	if beanType.Kind() == reflect.Ptr {
+		beanType = beanType.Elem()
+	}
+	if beanType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs []error
+	for i := 0; i < beanType.NumField(); i++ {
+		sf := beanType.Field(i)
+		tagName, _, ok := injectTag(sf)
+		if !ok || tagName == excluded {
+			continue
+		}
+		ft := sf.Type
+		switch {
+		case !sf.IsExported():
+			errs = append(errs, fmt.Errorf("bean '%s' field '%s' is tagged but unexported", id, sf.Name))
+		case strings.HasPrefix(tagName, groupPrefix):
+			if ft.Kind() != reflect.Slice {
+				errs = append(errs, fmt.Errorf("bean '%s' field '%s' collects a group but is %v, not a slice", id, sf.Name, ft))
+			}
+		case ft.Kind() == reflect.Ptr && ft.Elem().Kind() == reflect.Struct,
+			ft.Kind() == reflect.Interface, isLiteralKind(ft):
+		default:
+			errs = append(errs, fmt.Errorf("bean '%s' field '%s' of type %v cannot be injected", id, sf.Name, ft))
+		}
+	}
+	return errs
+}
+
+// declaredCycles reports every dependency cycle the DFS over the declared edges finds, each once.
+// Callers must hold regMu.
+func (c *Container) declaredCycles() []error {
+	const (
+		onPath = 1
+		done   = 2
+	)
+	state := make(map[string]int)
+	path := make([]string, 0, 16)
+	seen := make(map[string]bool)
+	var errs []error
+
+	var visit func(id string)
+	visit = func(id string) {
+		bn, ok := c.registeredBeans[id]
+		if !ok || state[id] == done {
+			return
+		}
+		if state[id] == onPath {
+			err := c.cycleError(path, id)
+			if !seen[err.Error()] {
+				seen[err.Error()] = true
+				errs = append(errs, err)
+			}
+			return
+		}
+		state[id] = onPath
+		path = append(path, id)
+		for _, dep := range c.edges(bn) {
+			visit(dep)
+		}
+		path = path[:len(path)-1]
+		state[id] = done
+	}
+	for _, id := range sortedKeys(c.registeredBeans) {
+		visit(id)
+	}
+	return errs
+}
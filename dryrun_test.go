@@ -0,0 +1,88 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireNothingInstantiated asserts the container is unbuilt and no bean registered by type has an instance.
+func requireNothingInstantiated(t *testing.T, c *Container) {
+	t.Helper()
+	require.Equal(t, StateRegistering, c.State())
+	for _, info := range c.Beans() {
+		require.False(t, info.Instantiated, info.ID)
+	}
+}
+
+func TestValidate_ServiceGraph(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*sleepyInit)(nil))))
+
+	require.NoError(t, c.Validate())
+	requireNothingInstantiated(t, c)
+	require.Len(t, c.BeanIDs(), 4, "no literal bean is synthesized")
+}
+
+func TestValidate_Cycle(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("a", reflect.TypeOf((*cycleA)(nil))))
+	require.NoError(t, c.Register("b", reflect.TypeOf((*cycleB)(nil))))
+	require.NoError(t, c.Register("aself", reflect.TypeOf((*selfCycleA)(nil))))
+
+	err := c.Validate()
+	require.EqualError(t, err, "dependency cycle detected: a (field B) -> b (field A) -> a\n"+
+		"dependency cycle detected: aself (field A) -> aself")
+	requireNothingInstantiated(t, c)
+}
+
+type mistypedReceiver struct {
+	Config *Config  `di.inject:"ServiceBeanConfig"`
+	Dir    string   `di.inject:"WorkingDir"`
+	Hidden *Logger  `di.inject:"ServiceBeanLogger"`
+	hidden *Logger  `di.inject:"ServiceBeanLogger"`
+	Funcs  func()   `di.inject:"callback"`
+	Group  *Logger  `di.inject:"group:loggers"`
+	Repo   *Service `di.inject:"repo"`
+}
+
+func TestValidate_AggregatesProblems(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*mistypedReceiver)(nil))))
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, c.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+
+	err := c.Validate()
+	require.EqualError(t, err, "bean 'receiver' field 'hidden' is tagged but unexported\n"+
+		"bean 'receiver' field 'Funcs' of type func() cannot be injected\n"+
+		"bean 'receiver' field 'Group' collects a group but is *iocdi.Logger, not a slice\n"+
+		"bean `group:loggers` is required but not registered\n"+
+		"bean `repo` is required but not registered\n"+
+		"bean 'servicebeanconfig' type mismatch: required iocdi.Config, registered *iocdi.Logger\n"+
+		"bean `workingdir` is required but not registered")
+	requireNothingInstantiated(t, c)
+}
+
+func TestValidate_EagerLiterals(t *testing.T) {
+	c := New(WithEagerLiteralCheck())
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return nil, false, nil
+	})
+	require.EqualError(t, c.Validate(), "bean `workingdir` is required but no literal provider supplies it")
+
+	boom := errors.New("boom")
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return nil, false, boom
+	})
+	require.ErrorIs(t, c.Validate(), boom)
+
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "/srv", true, nil
+	})
+	require.NoError(t, c.Validate())
+	require.NotContains(t, c.BeanIDs(), "workingdir")
+	requireNothingInstantiated(t, c)
+}
@@ -0,0 +1,148 @@
+package iocdi
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+)
+
+// ContainerDump is the document DumpJSON writes. Its layout is stable: beans are sorted by ID, dependencies are
+// in field order, and nothing varies between runs of the same wiring, so dumps from two deployments can be
+// diffed.
+type ContainerDump struct {
+	// State is the container's lifecycle state, e.g. "Built".
+	State string `json:"state"`
+	// LiteralProviders lists the literal sources in the order they are consulted.
+	LiteralProviders []LiteralProviderDump `json:"literalProviders"`
+	// Beans lists every registered bean, including literals synthesized by Build.
+	Beans []BeanDump `json:"beans"`
+}
+
+// LiteralProviderDump describes one literal source of a ContainerDump.
+type LiteralProviderDump struct {
+	// Kind is "static" for WithLiterals, "container" or "containerV2" for the container's provider chain, and
+	// "global" for the package-level provider.
+	Kind string `json:"kind"`
+	// IDs lists the IDs a static source supplies, sorted. Values are never dumped.
+	IDs []string `json:"ids,omitempty"`
+}
+
+// BeanDump describes one bean of a ContainerDump.
+type BeanDump struct {
+	ID           string           `json:"id"`
+	GoType       string           `json:"goType"`
+	Singleton    bool             `json:"singleton"`
+	Initialized  bool             `json:"initialized"`
+	Dependencies []DependencyDump `json:"dependencies"`
+	Metadata     BeanMetadata     `json:"metadata"`
+}
+
+// DependencyDump describes one dependency edge of a BeanDump.
+type DependencyDump struct {
+	ID    string `json:"id"`
+	Field string `json:"field"`
+	// Source is "bean", "literal" or "missing". Before Build, dependencies a literal source will supply are
+	// reported as "literal".
+	Source    string `json:"source"`
+	Autowired bool   `json:"autowired,omitempty"`
+	Prototype bool   `json:"prototype,omitempty"`
+}
+
+// BeanMetadata holds the registration options and markers of a BeanDump.
+type BeanMetadata struct {
+	Supplied     bool     `json:"supplied,omitempty"`
+	Literal      bool     `json:"literal,omitempty"`
+	Synthetic    bool     `json:"synthetic,omitempty"`
+	Secret       bool     `json:"secret,omitempty"`
+	Primary      bool     `json:"primary,omitempty"`
+	Prototype    bool     `json:"prototype,omitempty"`
+	Lazy         bool     `json:"lazy,omitempty"`
+	Groups       []string `json:"groups,omitempty"`
+	InitPriority int      `json:"initPriority,omitempty"`
+	// Value renders the instance of a bean of a basic kind, or «redacted» for a secret.
+	Value string `json:"value,omitempty"`
+}
+
+// DumpJSON writes the container's beans, their wiring and its literal sources as indented JSON, for support
+// tooling. See ContainerDump for the format. Secret values are redacted. It does not build the container.
+func (c *Container) DumpJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent(emptyString, "  ")
+	return enc.Encode(c.dump())
+}
+
+// dump collects the ContainerDump.
+func (c *Container) dump() ContainerDump {
+	state := c.State()
+
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	d := ContainerDump{State: state.String(), LiteralProviders: []LiteralProviderDump{}, Beans: []BeanDump{}}
+	if len(c.staticLiterals) > 0 {
+		d.LiteralProviders = append(d.LiteralProviders, LiteralProviderDump{Kind: "static", IDs: sortedKeys(c.staticLiterals)})
+	}
+	chain, global := c.literalSources()
+	for _, src := range chain {
+		kind := "container"
+		if src.perReceiver {
+			kind = "containerV2"
+		}
+		d.LiteralProviders = append(d.LiteralProviders, LiteralProviderDump{Kind: kind})
+	}
+	if global != nil {
+		d.LiteralProviders = append(d.LiteralProviders, LiteralProviderDump{Kind: "global"})
+	}
+
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		b := BeanDump{
+			ID:           bn.id,
+			Singleton:    bn.singleton,
+			Initialized:  bn.initialized,
+			Dependencies: []DependencyDump{},
+			Metadata: BeanMetadata{
+				Supplied:     bn.supplied,
+				Literal:      bn.literal,
+				Synthetic:    bn.synthetic,
+				Secret:       c.secrets[bn.id],
+				Primary:      bn.primary,
+				Prototype:    bn.prototype,
+				Lazy:         bn.lazy,
+				Groups:       slices.Clone(bn.groups),
+				InitPriority: bn.initPriority,
+			},
+		}
+		if bn.beanType != nil {
+			b.GoType = bn.beanType.String()
+		}
+		if bn.instance != nil && isLiteralKind(bn.beanType) {
+			b.Metadata.Value = c.displayValue(bn.id, bn.instance)
+		}
+		for _, fd := range bn.fields {
+			b.Dependencies = append(b.Dependencies, DependencyDump{
+				ID:        fd.id,
+				Field:     fd.field,
+				Source:    dumpSources[c.satisfiedBy(fd.id)],
+				Prototype: fd.prototype,
+			})
+		}
+		for _, af := range bn.autowired {
+			b.Dependencies = append(b.Dependencies, DependencyDump{
+				ID:        af.id,
+				Field:     af.field,
+				Source:    dumpSources[c.satisfiedBy(af.id)],
+				Autowired: true,
+			})
+		}
+		d.Beans = append(d.Beans, b)
+	}
+	return d
+}
+
+// dumpSources names the DependencySource values in a ContainerDump.
+var dumpSources = map[DependencySource]string{
+	DependencyMissing:    "missing",
+	DependencyRegistered: "bean",
+	DependencyLiteral:    "literal",
+}
@@ -0,0 +1,83 @@
+package iocdi
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func dumpJSON(t *testing.T, c *Container) (ContainerDump, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, c.DumpJSON(&buf))
+	var d ContainerDump
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &d))
+	return d, buf.String()
+}
+
+func TestDumpJSON_ServiceGraph(t *testing.T) {
+	c := newServiceGraph(t)
+
+	d, before := dumpJSON(t, c)
+	require.Equal(t, "Registering", d.State)
+	require.Equal(t, []LiteralProviderDump{{Kind: "global"}}, d.LiteralProviders)
+	require.Len(t, d.Beans, 3)
+	require.Equal(t, BeanDump{
+		ID:     "servicebean",
+		GoType: "*iocdi.Service",
+		Dependencies: []DependencyDump{
+			{ID: "servicebeanconfig", Field: "Config", Source: "bean"},
+			{ID: "servicebeanlogger", Field: "Logger", Source: "bean"},
+		},
+	}, d.Beans[0])
+	require.Equal(t, []DependencyDump{{ID: "workingdir", Field: "WorkingDir", Source: "literal"}}, d.Beans[1].Dependencies)
+	require.Empty(t, d.Beans[2].Dependencies)
+
+	// Stable output for the same wiring
+	_, again := dumpJSON(t, c)
+	require.Equal(t, before, again)
+
+	require.NoError(t, c.Build())
+	d, _ = dumpJSON(t, c)
+	require.Equal(t, "Built", d.State)
+	require.Len(t, d.Beans, 4)
+	require.True(t, d.Beans[0].Singleton)
+	for _, b := range d.Beans {
+		require.True(t, b.Initialized, b.ID)
+	}
+	require.Equal(t, BeanDump{
+		ID:           "workingdir",
+		GoType:       "string",
+		Initialized:  true,
+		Dependencies: []DependencyDump{},
+		Metadata:     BeanMetadata{Literal: true, Value: `"/srv/app"`},
+	}, d.Beans[3])
+}
+
+func TestDumpJSON_LiteralProviders(t *testing.T) {
+	c := New(WithLiterals(map[string]any{"Region": "eu", "APIKey": "k"}))
+	c.AddLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) { return nil, false, nil })
+	c.AddLiteralProviderV2(func(req LiteralRequest) (any, bool, error) { return nil, false, nil })
+
+	d, out := dumpJSON(t, c)
+	require.Equal(t, []LiteralProviderDump{
+		{Kind: "static", IDs: []string{"apikey", "region"}},
+		{Kind: "container"},
+		{Kind: "containerV2"},
+	}, d.LiteralProviders)
+	require.NotContains(t, out, `"eu"`)
+}
+
+func TestDumpJSON_RedactsSecrets(t *testing.T) {
+	c := newSecretContainer(t)
+	require.NoError(t, c.Build())
+
+	d, out := dumpJSON(t, c)
+	require.NotContains(t, out, "sk-live-123")
+	require.Equal(t, "apikey", d.Beans[0].ID)
+	require.Equal(t, BeanMetadata{Literal: true, Secret: true, Value: redacted}, d.Beans[0].Metadata)
+	require.Equal(t, `"eu-west-1"`, d.Beans[2].Metadata.Value)
+}
@@ -0,0 +1,91 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type envBean struct {
+	DatabaseURL string `di.env:"DATABASE_URL"`
+	Port        int    `di.env:"PORT,default=8080"`
+}
+
+type requiredEnvBean struct {
+	APIKey string `di.env:"API_KEY,required"`
+}
+
+func mapLookuper(vars map[string]string) Lookuper {
+	return LookuperFunc(func(key string) (string, bool) {
+		v, ok := vars[key]
+		return v, ok
+	})
+}
+
+func TestEnvTag_Set(t *testing.T) {
+	c := New(WithEnvLookuper(mapLookuper(map[string]string{
+		"DATABASE_URL": "postgres://localhost/app",
+		"PORT":         "9090",
+	})))
+	require.NoError(t, c.Register("EnvBean", reflect.TypeOf((*envBean)(nil))))
+	require.NoError(t, c.Build())
+
+	eb, err := ResolveAs[*envBean](c, "EnvBean")
+	require.NoError(t, err)
+	require.Equal(t, "postgres://localhost/app", eb.DatabaseURL)
+	require.Equal(t, 9090, eb.Port)
+}
+
+func TestEnvTag_UnsetOptional(t *testing.T) {
+	c := New(WithEnvLookuper(mapLookuper(nil)))
+	require.NoError(t, c.Register("EnvBean", reflect.TypeOf((*envBean)(nil))))
+	require.NoError(t, c.Build())
+
+	eb, err := ResolveAs[*envBean](c, "EnvBean")
+	require.NoError(t, err)
+	require.Empty(t, eb.DatabaseURL)
+	require.Equal(t, 8080, eb.Port, "default= applies when the variable is unset")
+}
+
+func TestEnvTag_UnsetRequired(t *testing.T) {
+	c := New(WithEnvLookuper(mapLookuper(nil)))
+	require.NoError(t, c.Register("RequiredEnvBean", reflect.TypeOf((*requiredEnvBean)(nil))))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "required environment variable 'API_KEY' is not set")
+}
+
+func TestEnvTag_IntConversionFailure(t *testing.T) {
+	c := New(WithEnvLookuper(mapLookuper(map[string]string{"PORT": "http"})))
+	require.NoError(t, c.Register("EnvBean", reflect.TypeOf((*envBean)(nil))))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "field 'Port' of bean 'envbean'")
+	require.Contains(t, err.Error(), "'PORT'")
+}
+
+type envOverInjectBean struct {
+	Dir string `di.inject:"WorkingDir" di.env:"WORKING_DIR"`
+}
+
+func TestEnvTag_PrecedenceOverInject(t *testing.T) {
+	register := func(c *Container) {
+		require.NoError(t, c.Register("EnvOverInject", reflect.TypeOf((*envOverInjectBean)(nil))))
+		require.NoError(t, c.RegisterInstance("WorkingDir", "/from/bean"))
+	}
+
+	c := New(WithEnvLookuper(mapLookuper(map[string]string{"WORKING_DIR": "/from/env"})))
+	register(c)
+	b, err := ResolveAs[*envOverInjectBean](c, "EnvOverInject")
+	require.NoError(t, err)
+	require.Equal(t, "/from/env", b.Dir)
+
+	c = New(WithEnvLookuper(mapLookuper(nil)))
+	register(c)
+	b, err = ResolveAs[*envOverInjectBean](c, "EnvOverInject")
+	require.NoError(t, err)
+	require.Equal(t, "/from/bean", b.Dir)
+}
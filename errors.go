@@ -1,11 +1,134 @@
 package iocdi
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 var (
-	ErrBeanIdParamIsEmpty   = errors.New("beanID parameter is empty")
-	ErrBeanTypeParamIsNil   = errors.New("beanType parameter is nil")
-	ErrBeanParamIsNil       = errors.New("bean parameter is nil")
-	ErrBeanTypeNotSupported = errors.New("beanType is not supported")
-	ErrRegistrationClosed   = errors.New("container already built; registration is closed")
+	ErrBeanIdParamIsEmpty       = errors.New("beanID parameter is empty")
+	ErrBeanTypeParamIsNil       = errors.New("beanType parameter is nil")
+	ErrBeanParamIsNil           = errors.New("bean parameter is nil")
+	ErrBeanTypeNotSupported     = errors.New("beanType is not supported")
+	ErrRegistrationClosed       = errors.New("container already built; registration is closed")
+	ErrConverterParamIsNil      = errors.New("converter parameter is nil")
+	ErrInjectTargetNotStructPtr = errors.New("inject target must be a pointer to a struct")
+	ErrNoInjectionTags          = errors.New("inject target has no di.inject tags")
+	ErrMalformedTag             = errors.New("malformed tag")
+	ErrContainerClosed          = errors.New("container is closed")
+	ErrContainerNotBuilt        = errors.New("container is not built")
+	ErrInitTimeout              = errors.New("initializer timed out")
+	ErrPrototypeNotTracked      = errors.New("prototype instance is not tracked")
+	ErrScopeClosed              = errors.New("scope is closed")
+	ErrBeanNotFound             = errors.New("bean not found")
+	ErrBeanNotInitialized       = errors.New("bean is not initialized")
+	ErrSecretBean               = errors.New("bean is secret")
 )
+
+// BeanError reports a failure concerning a single bean. Err is the cause, typically ErrBeanNotFound or
+// ErrBeanNotInitialized; match it with errors.Is and extract the ID with errors.As.
+type BeanError struct {
+	ID  string
+	Err error
+}
+
+func (e *BeanError) Error() string {
+	switch e.Err {
+	case ErrBeanNotFound:
+		return fmt.Sprintf("bean '%s' not found", e.ID)
+	case ErrBeanNotInitialized:
+		return fmt.Sprintf("bean '%s' is not initialized", e.ID)
+	case ErrSecretBean:
+		return fmt.Sprintf("bean '%s' is secret and cannot be resolved", e.ID)
+	}
+	return fmt.Sprintf("bean '%s': %v", e.ID, e.Err)
+}
+
+func (e *BeanError) Unwrap() error {
+	return e.Err
+}
+
+// MissingDependencyError reports a dependency that is neither registered nor supplied by a provider. Receiver and
+// Field name the bean and the field requiring it; when several beans require it, the precheck reports the first
+// in bean-ID order.
+type MissingDependencyError struct {
+	Receiver   string
+	Dependency string
+	Field      string
+
+	// precheck marks a failure found before any bean was wired
+	precheck bool
+}
+
+func (e *MissingDependencyError) Error() string {
+	if e.precheck || e.Receiver == emptyString {
+		return fmt.Sprintf("bean `%s` is required but not registered", e.Dependency)
+	}
+	return fmt.Sprintf("dependency bean '%s' for '%s' receiver bean not found", e.Dependency, e.Receiver)
+}
+
+// TypeMismatchError reports a registered bean whose type cannot be injected where its receivers require it.
+type TypeMismatchError struct {
+	BeanID     string
+	Required   reflect.Type
+	Registered reflect.Type
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("bean '%s' type mismatch: required %v, registered %v", e.BeanID, e.Required, e.Registered)
+}
+
+// CycleError reports a dependency cycle. Path starts at the cycle's smallest bean ID and ends with it again,
+// e.g. [a b a]; Fields[i], when known, names the field of Path[i] that depends on Path[i+1].
+type CycleError struct {
+	Path   []string
+	Fields []string
+}
+
+func (e *CycleError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("dependency cycle detected: ")
+	for i, id := range e.Path {
+		if i > 0 {
+			sb.WriteString(pathSep)
+		}
+		sb.WriteString(id)
+		if i < len(e.Fields) && e.Fields[i] != emptyString {
+			sb.WriteString(" (field ")
+			sb.WriteString(e.Fields[i])
+			sb.WriteString(")")
+		}
+	}
+	return sb.String()
+}
+
+// InitError reports a bean whose Initialize failed. Err is the initializer's error, or wraps ErrInitTimeout.
+type InitError struct {
+	BeanID string
+	Err    error
+}
+
+func (e *InitError) Error() string {
+	return fmt.Sprintf("initializer for bean '%s' failed: %v", e.BeanID, e.Err)
+}
+
+func (e *InitError) Unwrap() error {
+	return e.Err
+}
+
+// WiringError reports a failure while injecting a bean's dependencies. Chain lists the receivers being wired when
+// it happened, from the bean whose wiring pulled the others in down to the one that failed.
+type WiringError struct {
+	Chain []string
+	Err   error
+}
+
+func (e *WiringError) Error() string {
+	return fmt.Sprintf("while wiring %s: %v", strings.Join(e.Chain, pathSep), e.Err)
+}
+
+func (e *WiringError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,109 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingDependencyError_Precheck(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+
+	_, err := c.ResolveSafe("ServiceBeanConfig")
+	var missing *MissingDependencyError
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, "servicebeanconfig", missing.Receiver)
+	require.Equal(t, "workingdir", missing.Dependency)
+	require.Equal(t, "WorkingDir", missing.Field)
+	require.EqualError(t, missing, "bean `workingdir` is required but not registered")
+}
+
+func TestMissingDependencyError_Injection(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return nil, false, nil
+	})
+
+	err := c.Build()
+	var missing *MissingDependencyError
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, MissingDependencyError{Receiver: "servicebeanconfig", Dependency: "workingdir", Field: "WorkingDir"}, *missing)
+	require.Contains(t, err.Error(), "dependency bean 'workingdir' for 'servicebeanconfig' receiver bean not found")
+}
+
+func TestTypeMismatchError(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, c.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+
+	err := c.Build()
+	var mismatch *TypeMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	require.Equal(t, "servicebeanconfig", mismatch.BeanID)
+	require.Equal(t, reflect.TypeOf(Config{}), mismatch.Required)
+	require.Equal(t, reflect.TypeOf((*Logger)(nil)), mismatch.Registered)
+}
+
+func TestCycleError(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("b", reflect.TypeOf((*cycleB)(nil))))
+	require.NoError(t, c.Register("a", reflect.TypeOf((*cycleA)(nil))))
+
+	err := c.Build()
+	var cycle *CycleError
+	require.ErrorAs(t, err, &cycle)
+	require.Equal(t, []string{"a", "b", "a"}, cycle.Path)
+	require.Equal(t, []string{"B", "A"}, cycle.Fields)
+	require.EqualError(t, cycle, "dependency cycle detected: a (field B) -> b (field A) -> a")
+}
+
+func TestInitError(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("broken", reflect.TypeOf((*failingInit)(nil))))
+
+	err := c.Build()
+	var initErr *InitError
+	require.ErrorAs(t, err, &initErr)
+	require.Equal(t, "broken", initErr.BeanID)
+	require.EqualError(t, initErr.Err, "boom")
+	require.EqualError(t, initErr, "initializer for bean 'broken' failed: boom")
+
+	// Lazy beans report their initializer failures from ResolveSafe
+	c = New()
+	require.NoError(t, c.Register("broken", reflect.TypeOf((*failingInit)(nil)), Lazy()))
+	_, err = c.ResolveSafe("broken")
+	require.ErrorAs(t, err, &initErr)
+	require.Equal(t, "broken", initErr.BeanID)
+}
+
+type wiringApp struct {
+	Server *wiringServer `di.inject:"server"`
+}
+
+type wiringServer struct {
+	Repo *preloadRepo `di.inject:"repo"`
+}
+
+func TestWiringError_Chain(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("app", reflect.TypeOf((*wiringApp)(nil))))
+	require.NoError(t, c.Register("server", reflect.TypeOf((*wiringServer)(nil))))
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*preloadRepo)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return nil, false, nil
+	})
+
+	err := c.Build()
+	require.EqualError(t, err, "while wiring app -> server -> repo: dependency bean 'dsn' for 'repo' receiver bean not found")
+
+	var wiring *WiringError
+	require.ErrorAs(t, err, &wiring)
+	require.Equal(t, []string{"app", "server", "repo"}, wiring.Chain)
+	var missing *MissingDependencyError
+	require.ErrorAs(t, err, &missing)
+	require.Equal(t, "repo", missing.Receiver)
+}
@@ -0,0 +1,110 @@
+package iocdi
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventKind identifies the lifecycle step an Event reports.
+type EventKind int
+
+const (
+	// EventRegistered fires when Register or RegisterInstance stores a bean.
+	EventRegistered EventKind = iota + 1
+	// EventInstantiated fires when Build creates the instance of a bean registered by type.
+	EventInstantiated
+	// EventInjected fires when a dependency is set on a field of a receiver bean, or fails to be.
+	EventInjected
+	// EventInitialized fires after a bean's initializer ran during Build.
+	EventInitialized
+	// EventResolved fires when ResolveSafe returns a bean, or fails to.
+	EventResolved
+	// EventDestroyed fires after Close called a bean's Destroy.
+	EventDestroyed
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventRegistered:
+		return "Registered"
+	case EventInstantiated:
+		return "Instantiated"
+	case EventInjected:
+		return "Injected"
+	case EventInitialized:
+		return "Initialized"
+	case EventResolved:
+		return "Resolved"
+	case EventDestroyed:
+		return "Destroyed"
+	}
+	return "Unknown"
+}
+
+// Event describes a step in a bean's lifecycle. See Container.Subscribe.
+type Event struct {
+	Kind EventKind
+	// BeanID is the bean the event is about; for EventInjected it is the receiver.
+	BeanID string
+	// DependencyID and Field identify the injected dependency and the receiving field of EventInjected.
+	DependencyID string
+	Field        string
+	// Time is when the event fired.
+	Time time.Time
+	// Err is set when the step failed.
+	Err error
+}
+
+// subscribers holds the event callbacks registered with Subscribe. The slice is replaced, never modified, so
+// emit reads it without locking.
+type subscribers struct {
+	mu  sync.Mutex // serializes Subscribe
+	fns atomic.Pointer[[]func(Event)]
+}
+
+// Subscribe registers fn to receive the container's lifecycle events. Events are delivered synchronously
+// from Register, Build, ResolveSafe and Close, often while the container's locks are held, so fn must be
+// quick and must not call back into the container. A panicking subscriber is ignored.
+func (c *Container) Subscribe(fn func(e Event)) {
+	if fn == nil {
+		return
+	}
+	c.subs.mu.Lock()
+	defer c.subs.mu.Unlock()
+	var fns []func(Event)
+	if old := c.subs.fns.Load(); old != nil {
+		fns = slices.Clone(*old)
+	}
+	fns = append(fns, fn)
+	c.subs.fns.Store(&fns)
+}
+
+// emit stamps the event, logs it and delivers it to every subscriber in subscription order.
+func (c *Container) emit(e Event) {
+	l := c.log()
+	var fns []func(Event)
+	if p := c.subs.fns.Load(); p != nil {
+		fns = *p
+	}
+	if len(fns) == 0 && l == nil {
+		return
+	}
+
+	e.Time = time.Now()
+	if l != nil {
+		logEvent(l, e)
+	}
+	for _, fn := range fns {
+		deliver(fn, e)
+	}
+}
+
+// deliver calls fn, discarding any panic so a faulty subscriber cannot break the container.
+func deliver(fn func(Event), e Event) {
+	defer func() {
+		_ = recover()
+	}()
+	fn(e)
+}
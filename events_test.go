@@ -0,0 +1,77 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type eventConfig struct {
+	Name string
+}
+
+type eventService struct {
+	Config *eventConfig `di.inject:"config"`
+}
+
+func (s *eventService) Initialize() error { return nil }
+
+func (s *eventService) Destroy() error { return errors.New("flush failed") }
+
+func TestSubscribe_EventSequence(t *testing.T) {
+	c := New()
+	var events []Event
+	c.Subscribe(func(e Event) { events = append(events, e) })
+
+	require.NoError(t, c.RegisterInstance("config", &eventConfig{Name: "cfg"}))
+	require.NoError(t, c.Register("service", reflect.TypeOf((*eventService)(nil))))
+	require.NoError(t, c.Build())
+	_, err := c.ResolveSafe("service")
+	require.NoError(t, err)
+	_, err = c.ResolveSafe("missing")
+	require.Error(t, err)
+	require.Error(t, c.Close())
+
+	type step struct {
+		kind         EventKind
+		bean, dep, f string
+		failed       bool
+	}
+	got := make([]step, 0, len(events))
+	for _, e := range events {
+		require.False(t, e.Time.IsZero())
+		got = append(got, step{e.Kind, e.BeanID, e.DependencyID, e.Field, e.Err != nil})
+	}
+	require.Equal(t, []step{
+		{EventRegistered, "config", "", "", false},
+		{EventRegistered, "service", "", "", false},
+		{EventInstantiated, "service", "", "", false},
+		{EventInjected, "service", "config", "Config", false},
+		{EventInitialized, "service", "", "", false},
+		{EventResolved, "service", "", "", false},
+		{EventResolved, "missing", "", "", true},
+		{EventDestroyed, "service", "", "", true},
+	}, got)
+}
+
+func TestSubscribe_MultipleAndPanickingSubscribers(t *testing.T) {
+	c := New()
+	var first, second int
+	c.Subscribe(func(e Event) { first++ })
+	c.Subscribe(func(e Event) { panic("subscriber bug") })
+	c.Subscribe(func(e Event) { second++ })
+
+	require.NoError(t, c.RegisterInstance("config", &eventConfig{}))
+	require.NoError(t, c.Register("service", reflect.TypeOf((*eventService)(nil))))
+	require.NoError(t, c.Build())
+
+	require.Equal(t, 5, first)
+	require.Equal(t, first, second)
+}
+
+func TestEventKind_String(t *testing.T) {
+	require.Equal(t, "Injected", EventInjected.String())
+	require.Equal(t, "Unknown", EventKind(0).String())
+}
@@ -0,0 +1,26 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A test fixture wired from the same container as the application beans.
+func ExampleInjectStruct() {
+	c := New()
+	_ = c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil)))
+	_ = c.RegisterInstance("WorkingDir", "/srv/app")
+
+	type fixture struct {
+		Config *Config `di.inject:"ServiceBeanConfig"`
+		Dir    string  `di.inject:"WorkingDir"`
+	}
+
+	f := &fixture{}
+	if err := InjectStruct(c, f, RequireTags()); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(f.Dir, f.Config.WorkingDir)
+	// Output: /srv/app /srv/app
+}
@@ -0,0 +1,45 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type excludedFieldReceiver struct {
+	Logger  *Logger `di.inject:"-"`
+	Greeter greeter `di.inject:"-"`
+	Name    string  `di.inject:"-" di.value:"ignored"`
+	Config  *Config `di.inject:"ServiceBeanConfig"`
+}
+
+func TestExcludedField_NeverInjected(t *testing.T) {
+	c := New(WithAutowire())
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*excludedFieldReceiver)(nil))))
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	require.NoError(t, c.RegisterInstance("WorkingDir", "/srv"))
+	// Perfectly matching beans, including one registered under the literal ID "-".
+	require.NoError(t, c.RegisterInstance("-", &Logger{}))
+	require.NoError(t, c.Register("english", reflect.TypeOf((*englishGreeter)(nil))))
+
+	require.NoError(t, c.Build())
+	r, err := ResolveAs[*excludedFieldReceiver](c, "receiver")
+	require.NoError(t, err)
+	require.Nil(t, r.Logger)
+	require.Nil(t, r.Greeter)
+	require.Empty(t, r.Name)
+	require.NotNil(t, r.Config)
+
+	b := c.registeredBeans["receiver"]
+	require.Equal(t, []string{"servicebeanconfig"}, b.dependencies)
+	require.Empty(t, b.autowired)
+}
+
+func TestExcludedField_NotCountedAsInjectionTag(t *testing.T) {
+	type onlyExcluded struct {
+		Logger *Logger `di.inject:"-"`
+	}
+	c := New()
+	require.ErrorIs(t, InjectStruct(c, &onlyExcluded{}, RequireTags()), ErrNoInjectionTags)
+}
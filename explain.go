@@ -0,0 +1,130 @@
+package iocdi
+
+import (
+	"slices"
+	"strings"
+)
+
+// ExplainOption configures a single Explain call.
+type ExplainOption func(*explainConfig)
+
+type explainConfig struct {
+	depth int
+	ascii bool
+}
+
+// ExplainDepth limits the tree to n levels below the root; deeper dependencies are elided with an ellipsis.
+// Zero, the default, renders the whole tree.
+func ExplainDepth(n int) ExplainOption {
+	return func(cfg *explainConfig) {
+		cfg.depth = n
+	}
+}
+
+// ExplainASCII draws the tree with ASCII characters instead of Unicode box drawing.
+func ExplainASCII() ExplainOption {
+	return func(cfg *explainConfig) {
+		cfg.ascii = true
+	}
+}
+
+// treeStyle holds the strings used to draw a tree.
+type treeStyle struct {
+	branch, last, pipe, space, cycle, more string
+}
+
+var (
+	unicodeTree = treeStyle{branch: "├── ", last: "└── ", pipe: "│   ", space: "    ", cycle: " ↩", more: " …"}
+	asciiTree   = treeStyle{branch: "|-- ", last: "`-- ", pipe: "|   ", space: "    ", cycle: " (cycle)", more: " ..."}
+)
+
+// Explain renders the bean's dependency tree as text, for pasting into a support ticket:
+//
+//	servicebean
+//	├── servicebeanconfig
+//	│   └── workingdir (literal)
+//	└── servicebeanlogger
+//
+// Dependencies the LiteralProvider supplies are marked "(literal)", unregistered ones "(missing)", and a
+// dependency closing a cycle is marked with "↩" instead of being expanded again. Explain uses the declared
+// edges, so it works before Build.
+func (c *Container) Explain(beanID string, opts ...ExplainOption) (string, error) {
+	if beanID == emptyString {
+		return emptyString, ErrBeanIdParamIsEmpty
+	}
+	var cfg explainConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	style := unicodeTree
+	if cfg.ascii {
+		style = asciiTree
+	}
+
+	beanID = strings.ToLower(beanID)
+
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	if _, ok := c.registeredBeans[beanID]; !ok {
+		return emptyString, &BeanError{ID: beanID, Err: ErrBeanNotFound}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(beanID)
+	c.explainChildren(&sb, beanID, []string{beanID}, emptyString, cfg.depth, style)
+	return sb.String(), nil
+}
+
+// explainChildren writes the dependencies of the bean with the ID, one line each, below the bean's line.
+// Callers must hold regMu.
+func (c *Container) explainChildren(sb *strings.Builder, id string, path []string, prefix string, depth int, style treeStyle) {
+	bn, ok := c.registeredBeans[id]
+	if !ok {
+		return
+	}
+	deps := c.edges(bn)
+	for i, dep := range deps {
+		connector, indent := style.branch, style.pipe
+		if i == len(deps)-1 {
+			connector, indent = style.last, style.space
+		}
+		sb.WriteString("\n")
+		sb.WriteString(prefix)
+		sb.WriteString(connector)
+		sb.WriteString(dep)
+
+		switch {
+		case slices.Contains(path, dep):
+			sb.WriteString(style.cycle)
+		case c.literalSupplied(dep):
+			sb.WriteString(" (literal)")
+		case !c.isRegistered(dep):
+			sb.WriteString(" (missing)")
+		case depth == 1:
+			if len(c.edges(c.registeredBeans[dep])) > 0 {
+				sb.WriteString(style.more)
+			}
+		default:
+			c.explainChildren(sb, dep, append(path, dep), prefix+indent, max(depth-1, 0), style)
+		}
+	}
+}
+
+// isRegistered reports whether a bean is registered under the ID.
+// Callers must hold regMu.
+func (c *Container) isRegistered(id string) bool {
+	_, ok := c.registeredBeans[id]
+	return ok
+}
+
+// literalSupplied reports whether the dependency with the ID comes, or will come at Build, from the
+// LiteralProvider.
+// Callers must hold regMu.
+func (c *Container) literalSupplied(id string) bool {
+	if bn, ok := c.registeredBeans[id]; ok {
+		return bn.literal
+	}
+	t, ok := c.requiredDependency[id]
+	return ok && isLiteralKind(t) && c.hasLiteralProvider()
+}
@@ -0,0 +1,52 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain_ServiceGraph(t *testing.T) {
+	c := newServiceGraph(t)
+
+	want := "servicebean\n" +
+		"├── servicebeanconfig\n" +
+		"│   └── workingdir (literal)\n" +
+		"└── servicebeanlogger"
+	out, err := c.Explain("ServiceBean")
+	require.NoError(t, err)
+	require.Equal(t, want, out)
+
+	// The literal bean synthesized by Build keeps its marker
+	require.NoError(t, c.Build())
+	out, err = c.Explain("servicebean")
+	require.NoError(t, err)
+	require.Equal(t, want, out)
+
+	out, err = c.Explain("servicebean", ExplainASCII(), ExplainDepth(1))
+	require.NoError(t, err)
+	require.Equal(t, "servicebean\n|-- servicebeanconfig ...\n`-- servicebeanlogger", out)
+
+	_, err = c.Explain("missing")
+	require.EqualError(t, err, "bean 'missing' not found")
+}
+
+func TestExplain_MissingDependency(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*preloadRepo)(nil))))
+
+	out, err := c.Explain("repo")
+	require.NoError(t, err)
+	require.Equal(t, "repo\n└── dsn (missing)", out)
+}
+
+func TestExplain_Cycle(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("a", reflect.TypeOf((*cycleA)(nil))))
+	require.NoError(t, c.Register("b", reflect.TypeOf((*cycleB)(nil))))
+
+	out, err := c.Explain("a")
+	require.NoError(t, err)
+	require.Equal(t, "a\n└── b\n    └── a ↩", out)
+}
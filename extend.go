@@ -0,0 +1,45 @@
+package iocdi
+
+import (
+	"context"
+	"maps"
+)
+
+// Extend reopens registration on a built container for the duration of fn, then builds the beans fn registered.
+// Beans that were already initialized keep their instance and wiring, and their initializers do not run again;
+// new beans are instantiated, injected, validated and initialized as in Build and may depend on existing beans.
+// Re-registering an existing bean ID replaces it like a fresh registration. fn should only register beans:
+// Build and resolution wait for Extend to finish, so calling them from fn deadlocks.
+//
+// If fn fails, the container returns to its previous state. If the build fails, the container is left unbuilt
+// with the new registrations in place, so they can be corrected and Build retried.
+func (c *Container) Extend(fn func(c *Container) error) error {
+	c.buildLock.Lock()
+	defer c.buildLock.Unlock()
+
+	if c.closed.Load() {
+		return ErrContainerClosed
+	}
+
+	// Beans are stored by value, so the snapshot restores the registrations fn made before failing
+	c.regMu.RLock()
+	snapshot := maps.Clone(c.registeredBeans)
+	requiredSnapshot := maps.Clone(c.requiredDependency)
+	c.regMu.RUnlock()
+
+	// Resolution takes the locked path, and so waits, until the extended container is built
+	c.unpublishResolved()
+	wasBuilt := c.built.Swap(false)
+	if err := fn(c); err != nil {
+		c.regMu.Lock()
+		c.registeredBeans = snapshot
+		c.requiredDependency = requiredSnapshot
+		if wasBuilt {
+			c.publishResolved()
+		}
+		c.built.Store(wasBuilt)
+		c.regMu.Unlock()
+		return err
+	}
+	return c.build(context.Background(), nil)
+}
@@ -0,0 +1,106 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type extendCounter struct {
+	inits int
+}
+
+func (e *extendCounter) Initialize() error {
+	e.inits++
+	return nil
+}
+
+type extendPlugin struct {
+	Counter *extendCounter `di.inject:"counter"`
+	inits   int
+}
+
+func (e *extendPlugin) Initialize() error {
+	e.inits++
+	return nil
+}
+
+func TestExtend_InitializesOnlyNewBeans(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("counter", reflect.TypeOf((*extendCounter)(nil))))
+	require.NoError(t, c.Build())
+
+	d, err := c.DescribeBean("counter")
+	require.NoError(t, err)
+	require.True(t, d.Initialized)
+	require.Equal(t, "*iocdi.extendCounter", d.Type)
+
+	require.NoError(t, c.Extend(func(c *Container) error {
+		return c.Register("plugin", reflect.TypeOf((*extendPlugin)(nil)))
+	}))
+
+	counter, err := ResolveAs[*extendCounter](c, "counter")
+	require.NoError(t, err)
+	plugin, err := ResolveAs[*extendPlugin](c, "plugin")
+	require.NoError(t, err)
+	require.Equal(t, 1, counter.inits)
+	require.Equal(t, 1, plugin.inits)
+	require.Same(t, counter, plugin.Counter)
+
+	d, err = c.DescribeBean("Plugin")
+	require.NoError(t, err)
+	require.True(t, d.Initialized)
+	require.True(t, d.Instantiated)
+
+	// Registration is closed again afterwards
+	require.ErrorIs(t, c.RegisterInstance("late", "x"), ErrRegistrationClosed)
+}
+
+func TestExtend_FailingCallbackRestoresState(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("counter", reflect.TypeOf((*extendCounter)(nil))))
+	require.NoError(t, c.Build())
+
+	boom := errors.New("boom")
+	require.ErrorIs(t, c.Extend(func(c *Container) error { return boom }), boom)
+	require.True(t, c.built.Load())
+}
+
+func TestExtend_FailingCallbackDropsItsRegistrations(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("counter", reflect.TypeOf((*extendCounter)(nil))))
+	require.NoError(t, c.Build())
+	counter, err := ResolveAs[*extendCounter](c, "counter")
+	require.NoError(t, err)
+
+	boom := errors.New("boom")
+	require.ErrorIs(t, c.Extend(func(c *Container) error {
+		require.NoError(t, c.Register("plugin", reflect.TypeOf((*extendPlugin)(nil))))
+		require.NoError(t, c.RegisterInstance("counter", &extendCounter{}))
+		return boom
+	}), boom)
+
+	require.Equal(t, StateBuilt, c.State())
+	require.Equal(t, []string{"counter"}, c.BeanIDs())
+	_, err = c.ResolveSafe("plugin")
+	require.ErrorIs(t, err, ErrBeanNotFound)
+	same, err := ResolveAs[*extendCounter](c, "counter")
+	require.NoError(t, err)
+	require.Same(t, counter, same)
+	require.Empty(t, c.requiredDependency)
+}
+
+func TestDescribeBean_BeforeBuild(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("counter", reflect.TypeOf((*extendCounter)(nil))))
+
+	d, err := c.DescribeBean("counter")
+	require.NoError(t, err)
+	require.False(t, d.Instantiated)
+	require.False(t, d.Initialized)
+
+	_, err = c.DescribeBean("missing")
+	require.EqualError(t, err, "bean 'missing' not found")
+}
@@ -0,0 +1,145 @@
+package iocdi
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileLiteralProvider loads a JSON (.json) or YAML (.yaml, .yml) document once and serves literals from it by
+// key. Nested keys are addressed with dotted paths, so the dependency ID "db.host" reads {"db": {"host": ...}};
+// keys match case-insensitively. Values are converted to the target type: strings, bools, numbers, and
+// time.Duration from strings such as "5s". Arrays fill slice fields and objects fill map fields, converting each
+// element the same way. Missing keys are reported as not found. Read and decode errors are returned here rather
+// than at injection.
+func FileLiteralProvider(path string) (LiteralProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, &doc)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	default:
+		return nil, fmt.Errorf("literal file %s: unsupported extension %q", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("literal file %s: %w", path, err)
+	}
+
+	values := make(map[string]any)
+	flattenLiterals(emptyString, doc, values)
+
+	return func(id string, targetType reflect.Type) (any, bool, error) {
+		raw, ok := values[strings.ToLower(id)]
+		if !ok {
+			return nil, false, nil
+		}
+		if targetType == nil {
+			targetType = reflect.TypeOf(emptyString)
+		}
+		if _, isObject := raw.(map[string]any); isObject && isBasicKind(targetType.Kind()) {
+			return nil, false, nil // objects are addressed through their dotted leaves
+		}
+		v, err := decodedLiteral(raw, targetType)
+		if err != nil {
+			return nil, false, fmt.Errorf("literal file %s: key %q: %w", path, id, err)
+		}
+		return v.Interface(), true, nil
+	}, nil
+}
+
+// decodedLiteral converts a decoded value to the target type: an array to a slice and an object to a map,
+// element by element, and a scalar through convertString. A string for a slice is returned as is, for
+// WithCommaSeparatedLiterals to split.
+func decodedLiteral(raw any, targetType reflect.Type) (reflect.Value, error) {
+	switch targetType.Kind() {
+	case reflect.Slice:
+		if s, ok := raw.(string); ok {
+			return reflect.ValueOf(s), nil
+		}
+		items, ok := raw.([]any)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("holds %T, not an array", raw)
+		}
+		out := reflect.MakeSlice(targetType, 0, len(items))
+		for _, item := range items {
+			elem, err := decodedLiteral(item, targetType.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out = reflect.Append(out, elem)
+		}
+		return out, nil
+	case reflect.Map:
+		entries, ok := raw.(map[string]any)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("holds %T, not an object", raw)
+		}
+		out := reflect.MakeMapWithSize(targetType, len(entries))
+		for k, item := range entries {
+			elem, err := decodedLiteral(item, targetType.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.SetMapIndex(reflect.ValueOf(k).Convert(targetType.Key()), elem)
+		}
+		return out, nil
+	}
+	s, ok := scalarString(raw)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("holds %T, not a scalar", raw)
+	}
+	v, err := convertString(s, targetType)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("cannot convert %q to %v: %w", s, targetType, err)
+	}
+	return v, nil
+}
+
+// flattenLiterals records the nodes of a decoded document under their lower-cased dotted paths.
+func flattenLiterals(prefix string, node any, values map[string]any) {
+	if prefix != emptyString {
+		values[prefix] = node
+	}
+	m, ok := node.(map[string]any)
+	if !ok {
+		return
+	}
+	for k, v := range m {
+		key := strings.ToLower(k)
+		if prefix != emptyString {
+			key = prefix + "." + key
+		}
+		flattenLiterals(key, v, values)
+	}
+}
+
+// scalarString formats a decoded scalar as the string convertString parses.
+func scalarString(v any) (string, bool) {
+	switch x := v.(type) {
+	case string:
+		return x, true
+	case bool:
+		return strconv.FormatBool(x), true
+	case int:
+		return strconv.Itoa(x), true
+	case int64:
+		return strconv.FormatInt(x, 10), true
+	case uint64:
+		return strconv.FormatUint(x, 10), true
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64), true
+	}
+	return emptyString, false
+}
@@ -0,0 +1,145 @@
+package iocdi
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeLiteralFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+type fileDSN struct {
+	Host string `di.inject:"db.host"`
+}
+
+func TestFileLiteralProvider_JSON(t *testing.T) {
+	path := writeLiteralFile(t, "app.json", `{"WorkingDir": "/srv/json", "db": {"host": "db.internal", "port": 5432}, "debug": true, "timeout": "5s", "hosts": ["a", "b"]}`)
+	p, err := FileLiteralProvider(path)
+	require.NoError(t, err)
+
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	require.NoError(t, c.Register("dsn", reflect.TypeOf((*fileDSN)(nil))))
+	c.AddLiteralProvider(p)
+	require.NoError(t, c.Build())
+	require.Equal(t, "/srv/json", c.Resolve("servicebeanconfig").(*Config).WorkingDir)
+	require.Equal(t, "db.internal", c.Resolve("dsn").(*fileDSN).Host)
+
+	v, found, err := p("db.port", reflect.TypeOf(0))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, 5432, v)
+
+	v, _, err = p("debug", reflect.TypeOf(false))
+	require.NoError(t, err)
+	require.Equal(t, true, v)
+
+	v, _, err = p("timeout", reflect.TypeOf(time.Duration(0)))
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, v)
+
+	v, _, err = p("db.port", reflect.TypeOf(""))
+	require.NoError(t, err)
+	require.Equal(t, "5432", v)
+
+	_, found, err = p("db", reflect.TypeOf(""))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	_, _, err = p("hosts", reflect.TypeOf(""))
+	require.ErrorContains(t, err, `key "hosts": holds []interface {}, not a scalar`)
+}
+
+type fileKafka struct {
+	Brokers []string          `di.inject:"kafka.brokers"`
+	Ports   []int             `di.inject:"kafka.ports"`
+	Labels  map[string]string `di.inject:"kafka.labels"`
+}
+
+func TestFileLiteralProvider_Collections(t *testing.T) {
+	path := writeLiteralFile(t, "kafka.yaml", "kafka:\n  brokers: [kafka-1:9092, kafka-2:9092]\n  ports: [9092, 9093]\n  labels:\n    Team: core\n    tier: 1\n")
+	p, err := FileLiteralProvider(path)
+	require.NoError(t, err)
+
+	c := New()
+	require.NoError(t, c.Register("kafka", reflect.TypeOf((*fileKafka)(nil))))
+	c.AddLiteralProvider(p)
+	k, err := ResolveAs[*fileKafka](c, "kafka")
+	require.NoError(t, err)
+	require.Equal(t, []int{9092, 9093}, k.Ports)
+
+	v, found, err := p("kafka.brokers", reflect.TypeOf([]string(nil)))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, v)
+	v, _, err = p("kafka.ports", reflect.TypeOf([]int(nil)))
+	require.NoError(t, err)
+	require.Equal(t, []int{9092, 9093}, v)
+	v, _, err = p("kafka.labels", reflect.TypeOf(map[string]string(nil)))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"Team": "core", "tier": "1"}, v)
+
+	_, _, err = p("kafka.labels", reflect.TypeOf([]string(nil)))
+	require.ErrorContains(t, err, `key "kafka.labels": holds map[string]interface {}, not an array`)
+}
+
+func TestFileLiteralProvider_YAML(t *testing.T) {
+	path := writeLiteralFile(t, "app.yaml", "workingDir: /srv/yaml\ndb:\n  host: db.yaml\n  port: 6543\ntimeout: 250ms\n")
+	p, err := FileLiteralProvider(path)
+	require.NoError(t, err)
+
+	c := New()
+	require.NoError(t, c.Register("dsn", reflect.TypeOf((*fileDSN)(nil))))
+	c.AddLiteralProvider(p)
+	dsn, err := ResolveAs[*fileDSN](c, "dsn")
+	require.NoError(t, err)
+	require.Equal(t, "db.yaml", dsn.Host)
+
+	v, _, err := p("db.port", reflect.TypeOf(uint16(0)))
+	require.NoError(t, err)
+	require.Equal(t, uint16(6543), v)
+	v, _, err = p("WORKINGDIR", reflect.TypeOf(""))
+	require.NoError(t, err)
+	require.Equal(t, "/srv/yaml", v)
+	v, _, err = p("timeout", reflect.TypeOf(time.Duration(0)))
+	require.NoError(t, err)
+	require.Equal(t, 250*time.Millisecond, v)
+}
+
+func TestFileLiteralProvider_MissingKey(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(nil)
+	path := writeLiteralFile(t, "app.yml", "db:\n  port: 5432\n")
+	p, err := FileLiteralProvider(path)
+	require.NoError(t, err)
+
+	c := New()
+	require.NoError(t, c.Register("dsn", reflect.TypeOf((*fileDSN)(nil))))
+	c.AddLiteralProvider(p)
+	err = c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dependency bean 'db.host' for 'dsn' receiver bean not found")
+}
+
+func TestFileLiteralProvider_DecodeErrors(t *testing.T) {
+	_, err := FileLiteralProvider(writeLiteralFile(t, "bad.json", `{"db": `))
+	require.ErrorContains(t, err, "bad.json")
+
+	_, err = FileLiteralProvider(writeLiteralFile(t, "bad.yaml", "db: [unclosed\n"))
+	require.ErrorContains(t, err, "bad.yaml")
+
+	_, err = FileLiteralProvider(writeLiteralFile(t, "app.toml", "db = 1"))
+	require.ErrorContains(t, err, `unsupported extension ".toml"`)
+
+	_, err = FileLiteralProvider(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,28 @@
+package iocdi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Fingerprint returns a hex SHA-256 identifying the shape of the wiring, e.g. to key cached startup artifacts:
+// it covers the registered bean IDs, their Go types and scopes, and their dependency edges with the receiving
+// fields. Instance values and literal contents are left out, so changing configuration does not change it, and
+// so are the beans Build synthesizes from literal sources. Registration order does not matter. Edges from
+// autowired fields are only known after Build. It does not build the container.
+func (c *Container) Fingerprint() string {
+	wiring := c.wiring()
+	h := sha256.New()
+	for _, id := range sortedKeys(wiring) {
+		w := wiring[id]
+		if w.literal {
+			continue
+		}
+		fmt.Fprintf(h, "bean %q type %q scope %s\n", id, w.typ, w.scope)
+		for _, e := range w.edges {
+			fmt.Fprintf(h, "edge %q\n", e)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,60 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint_RegistrationOrder(t *testing.T) {
+	a := New()
+	require.NoError(t, a.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+	require.NoError(t, a.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	require.NoError(t, a.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+
+	b := New()
+	require.NoError(t, b.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, b.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	require.NoError(t, b.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+
+	require.Len(t, a.Fingerprint(), 64)
+	require.Equal(t, a.Fingerprint(), b.Fingerprint())
+}
+
+func TestFingerprint_IgnoresLiteralValues(t *testing.T) {
+	c := newServiceGraph(t)
+	before := c.Fingerprint()
+	require.NoError(t, c.Build())
+	require.Equal(t, before, c.Fingerprint(), "synthesized literal beans are left out")
+
+	other := New(WithLiterals(map[string]any{"workingdir": "/tmp"}))
+	require.NoError(t, other.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+	require.NoError(t, other.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	require.NoError(t, other.RegisterInstance("ServiceBeanLogger", &Logger{}))
+	require.NoError(t, other.Build())
+	require.Equal(t, before, other.Fingerprint())
+}
+
+func TestFingerprint_ChangesWithWiring(t *testing.T) {
+	newRouter := func(grouped bool, handlerType reflect.Type) *Container {
+		c := New()
+		require.NoError(t, c.Register("router", reflect.TypeOf((*router)(nil))))
+		var opts []RegisterOption
+		if grouped {
+			opts = append(opts, InGroups("http.handlers"))
+		}
+		require.NoError(t, c.Register("h1-users", handlerType, opts...))
+		return c
+	}
+	base := newRouter(true, reflect.TypeOf((*usersHandler)(nil))).Fingerprint()
+
+	require.Equal(t, base, newRouter(true, reflect.TypeOf((*usersHandler)(nil))).Fingerprint())
+	require.NotEqual(t, base, newRouter(false, reflect.TypeOf((*usersHandler)(nil))).Fingerprint(), "edge removed")
+	require.NotEqual(t, base, newRouter(true, reflect.TypeOf((*adminHandler)(nil))).Fingerprint(), "type changed")
+
+	lazy := New()
+	require.NoError(t, lazy.Register("router", reflect.TypeOf((*router)(nil)), Lazy()))
+	require.NoError(t, lazy.Register("h1-users", reflect.TypeOf((*usersHandler)(nil)), InGroups("http.handlers")))
+	require.NotEqual(t, base, lazy.Fingerprint(), "scope changed")
+}
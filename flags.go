@@ -0,0 +1,93 @@
+package iocdi
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FlagOption configures a FlagLiteralProvider.
+type FlagOption func(*flagConfig)
+
+type flagConfig struct {
+	includeDefaults bool
+}
+
+// FlagDefaults makes a FlagLiteralProvider also serve flags that were not set on the command line, using their
+// default values.
+func FlagDefaults() FlagOption {
+	return func(cfg *flagConfig) {
+		cfg.includeDefaults = true
+	}
+}
+
+// FlagLiteralProvider serves literals from the flags of fs, so command-line configuration flows into tagged
+// fields without glue code. A dependency ID matches the flag of the same name, ignoring case, dashes and
+// underscores, so "workingdir" matches a "working-dir" flag. Only flags explicitly set on the command line are
+// served unless FlagDefaults is given. The flag's value is converted to the target type: strings, bools,
+// numbers and time.Duration are supported. For slice and map targets the flag's raw value is returned, for
+// WithCommaSeparatedLiterals to split, unless the flag is a flag.Getter holding a value of the target's kind.
+// Unknown flags are reported as not found.
+//
+// The flags are read at each lookup, so fs must be parsed before the container is built.
+func FlagLiteralProvider(fs *flag.FlagSet, opts ...FlagOption) LiteralProvider {
+	var cfg flagConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return func(id string, targetType reflect.Type) (any, bool, error) {
+		f := lookupFlag(fs, id, cfg.includeDefaults)
+		if f == nil {
+			return nil, false, nil
+		}
+		if targetType == nil {
+			targetType = reflect.TypeOf(emptyString)
+		}
+		if isLiteralCollection(targetType) {
+			if g, ok := f.Value.(flag.Getter); ok {
+				if v := reflect.ValueOf(g.Get()); v.Kind() == targetType.Kind() {
+					return v.Interface(), true, nil
+				}
+			}
+			return f.Value.String(), true, nil
+		}
+		v, err := convertString(f.Value.String(), targetType)
+		if err != nil {
+			return nil, false, fmt.Errorf("flag -%s: cannot convert %q to %v: %w", f.Name, f.Value.String(), targetType, err)
+		}
+		return v.Interface(), true, nil
+	}
+}
+
+// lookupFlag returns the flag of fs matching the dependency ID, preferring an exact name match, or nil.
+// Unless includeDefaults is set, only flags set on the command line are considered.
+func lookupFlag(fs *flag.FlagSet, id string, includeDefaults bool) *flag.Flag {
+	visit := fs.Visit
+	if includeDefaults {
+		visit = fs.VisitAll
+	}
+	var exact, normalized *flag.Flag
+	visit(func(f *flag.Flag) {
+		switch {
+		case f.Name == id:
+			exact = f
+		case normalized == nil && normalizeFlagName(f.Name) == normalizeFlagName(id):
+			normalized = f
+		}
+	})
+	if exact != nil {
+		return exact
+	}
+	return normalized
+}
+
+// normalizeFlagName lower-cases the name and drops dashes and underscores.
+func normalizeFlagName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(name))
+}
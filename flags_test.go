@@ -0,0 +1,107 @@
+package iocdi
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFlags(t *testing.T, args ...string) *flag.FlagSet {
+	t.Helper()
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.String("working-dir", "/default", "working directory")
+	fs.Int("port", 8080, "listen port")
+	fs.Bool("debug", false, "debug mode")
+	fs.Duration("timeout", time.Second, "request timeout")
+	require.NoError(t, fs.Parse(args))
+	return fs
+}
+
+func TestFlagLiteralProvider_Build(t *testing.T) {
+	fs := newTestFlags(t, "-working-dir", "/srv/app")
+
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.AddLiteralProvider(FlagLiteralProvider(fs))
+
+	cfg, err := ResolveAs[*Config](c, "servicebeanconfig")
+	require.NoError(t, err)
+	require.Equal(t, "/srv/app", cfg.WorkingDir)
+}
+
+func TestFlagLiteralProvider_NotSet(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(nil)
+	fs := newTestFlags(t)
+
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.AddLiteralProvider(FlagLiteralProvider(fs))
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dependency bean 'workingdir' for 'servicebeanconfig' receiver bean not found")
+
+	// With defaults, the unset flag's default is served
+	c = New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.AddLiteralProvider(FlagLiteralProvider(fs, FlagDefaults()))
+	cfg, err := ResolveAs[*Config](c, "servicebeanconfig")
+	require.NoError(t, err)
+	require.Equal(t, "/default", cfg.WorkingDir)
+}
+
+func TestFlagLiteralProvider_Conversion(t *testing.T) {
+	fs := newTestFlags(t, "-port", "9090", "-debug", "-timeout", "250ms")
+	p := FlagLiteralProvider(fs)
+
+	v, found, err := p("port", reflect.TypeOf(0))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, 9090, v)
+
+	v, found, err = p("debug", reflect.TypeOf(false))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, true, v)
+
+	v, found, err = p("timeout", reflect.TypeOf(time.Duration(0)))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, 250*time.Millisecond, v)
+
+	v, found, err = p("port", reflect.TypeOf(""))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "9090", v)
+
+	_, found, err = p("unknown", reflect.TypeOf(""))
+	require.NoError(t, err)
+	require.False(t, found)
+
+	_, _, err = p("timeout", reflect.TypeOf(0))
+	require.ErrorContains(t, err, `flag -timeout: cannot convert "250ms" to int`)
+}
+
+type flagKafka struct {
+	Brokers []string `di.inject:"KafkaBrokers"`
+	Ports   []int    `di.inject:"KafkaPorts"`
+}
+
+func TestFlagLiteralProvider_Slices(t *testing.T) {
+	fs := newTestFlags(t)
+	fs.String("kafka-brokers", emptyString, "brokers")
+	fs.String("kafka-ports", emptyString, "ports")
+	require.NoError(t, fs.Parse([]string{"-kafka-brokers=kafka-1:9092,kafka-2:9092", "-kafka-ports=9092, 9093"}))
+
+	c := New(WithCommaSeparatedLiterals())
+	require.NoError(t, c.Register("kafka", reflect.TypeOf((*flagKafka)(nil))))
+	c.AddLiteralProvider(FlagLiteralProvider(fs))
+
+	k, err := ResolveAs[*flagKafka](c, "kafka")
+	require.NoError(t, err)
+	require.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, k.Brokers)
+	require.Equal(t, []int{9092, 9093}, k.Ports)
+}
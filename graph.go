@@ -0,0 +1,336 @@
+package iocdi
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GraphOption configures a single graph export.
+type GraphOption func(*graphConfig)
+
+type graphConfig struct {
+	root string
+}
+
+func newGraphConfig(opts []GraphOption) graphConfig {
+	var cfg graphConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// GraphRoot limits the export to the bean with the ID and everything it depends on, directly or transitively.
+func GraphRoot(beanID string) GraphOption {
+	return func(cfg *graphConfig) {
+		cfg.root = strings.ToLower(beanID)
+	}
+}
+
+// graphNodeKind tells how a node of the exported graph is, or will be, satisfied.
+type graphNodeKind int
+
+const (
+	graphBean    graphNodeKind = iota // a registered bean
+	graphLiteral                      // a value from a literal provider
+	graphMissing                      // a dependency nothing supplies
+)
+
+type graphNode struct {
+	id   string
+	typ  string // empty when the type is unknown
+	kind graphNodeKind
+}
+
+type graphEdge struct {
+	from, to string
+	field    string // name of the receiving field
+	// viaInterface marks edges whose field is an interface (or a slice of one), satisfied by any implementation.
+	viaInterface bool
+	// cyclic marks edges that lie on a dependency cycle.
+	cyclic bool
+}
+
+// graphModel is the snapshot of the beans and their wiring shared by the graph exporters.
+type graphModel struct {
+	nodes []graphNode // sorted by ID
+	edges []graphEdge // grouped by receiver in ID order, in field order within a receiver
+}
+
+// graph collects the graph model. Before Build it holds the declared edges; after Build it also holds the
+// autowired fields and the synthesized literal beans.
+func (c *Container) graph(cfg graphConfig) (graphModel, error) {
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	ids := sortedKeys(c.registeredBeans)
+	if cfg.root != emptyString {
+		if !c.isRegistered(cfg.root) {
+			return graphModel{}, &BeanError{ID: cfg.root, Err: ErrBeanNotFound}
+		}
+		ids = c.reachable(cfg.root)
+	}
+
+	var m graphModel
+	var missing []string
+	for _, id := range ids {
+		bn, ok := c.registeredBeans[id]
+		if !ok {
+			continue // added below as a literal or missing node
+		}
+		kind := graphBean
+		if bn.literal {
+			kind = graphLiteral
+		}
+		m.nodes = append(m.nodes, graphNode{id: id, typ: bn.beanType.String(), kind: kind})
+		for _, e := range c.graphEdges(bn) {
+			if !c.isRegistered(e.to) && !slices.Contains(missing, e.to) {
+				missing = append(missing, e.to)
+			}
+			m.edges = append(m.edges, e)
+		}
+	}
+
+	for _, id := range missing {
+		node := graphNode{id: id, kind: graphMissing}
+		if t, ok := c.requiredDependency[id]; ok && t != nil {
+			node.typ = t.String()
+		}
+		if c.literalSupplied(id) {
+			node.kind = graphLiteral
+		}
+		m.nodes = append(m.nodes, node)
+	}
+	slices.SortStableFunc(m.nodes, func(a, b graphNode) int { return strings.Compare(a.id, b.id) })
+	m.markCycles()
+	return m, nil
+}
+
+// markCycles flags every edge whose target leads back to its source.
+func (m *graphModel) markCycles() {
+	out := make(map[string][]string)
+	for _, e := range m.edges {
+		out[e.from] = append(out[e.from], e.to)
+	}
+	for i, e := range m.edges {
+		seen := map[string]bool{e.to: true}
+		stack := []string{e.to}
+		for len(stack) > 0 && !m.edges[i].cyclic {
+			id := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for _, next := range out[id] {
+				if next == e.from {
+					m.edges[i].cyclic = true
+					break
+				}
+				if !seen[next] {
+					seen[next] = true
+					stack = append(stack, next)
+				}
+			}
+		}
+		if e.from == e.to {
+			m.edges[i].cyclic = true
+		}
+	}
+}
+
+// graphEdges returns one edge per field of the receiver that is, or will be, injected.
+// Callers must hold regMu.
+func (c *Container) graphEdges(bn bean) []graphEdge {
+	var edges []graphEdge
+	for _, fd := range bn.fields {
+		edges = append(edges, graphEdge{from: bn.id, to: fd.id, field: fd.field, viaInterface: fd.typ.Kind() == reflect.Interface})
+	}
+	for _, af := range bn.autowired {
+		ft, _ := structFieldType(bn.beanType, af.field)
+		edges = append(edges, graphEdge{from: bn.id, to: af.id, field: af.field, viaInterface: ft != nil && ft.Kind() == reflect.Interface})
+	}
+	for _, gf := range bn.groupFields {
+		ft, _ := structFieldType(bn.beanType, gf.field)
+		viaInterface := ft != nil && ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Interface
+		for _, member := range c.groupMembers(gf.group) {
+			if member != bn.id {
+				edges = append(edges, graphEdge{from: bn.id, to: member, field: gf.field, viaInterface: viaInterface})
+			}
+		}
+	}
+	return edges
+}
+
+// reachable returns the sorted IDs of the root and of every dependency reachable from it, registered or not.
+// Callers must hold regMu.
+func (c *Container) reachable(root string) []string {
+	seen := map[string]bool{root: true}
+	queue := []string{root}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		bn, ok := c.registeredBeans[id]
+		if !ok {
+			continue
+		}
+		for _, dep := range c.edges(bn) {
+			if !seen[dep] {
+				seen[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return sortedKeys(seen)
+}
+
+// structFieldType returns the type of the named field of a struct or pointer-to-struct type.
+func structFieldType(t reflect.Type, name string) (reflect.Type, bool) {
+	if t == nil {
+		return nil, false
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	f, ok := t.FieldByName(name)
+	if !ok {
+		return nil, false
+	}
+	return f.Type, true
+}
+
+// ExportDOT writes the dependency graph as a Graphviz DOT digraph, e.g. for `dot -Tsvg`. Each node is labeled
+// with the bean ID and type, and each edge with the receiving field. Literal values are drawn dashed, missing
+// dependencies dotted in red, and edges into interface fields with an open arrowhead. Before Build the graph
+// holds the declared edges; after Build it also holds autowired fields and the synthesized literals.
+func (c *Container) ExportDOT(w io.Writer, opts ...GraphOption) error {
+	m, err := c.graph(newGraphConfig(opts))
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph iocdi {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, n := range m.nodes {
+		label := dotQuote(n.id)
+		if n.typ != emptyString {
+			label = dotQuote(n.id + "\n" + n.typ)
+		}
+		fmt.Fprintf(&sb, "  %s [label=%s", dotQuote(n.id), label)
+		switch n.kind {
+		case graphLiteral:
+			sb.WriteString(", style=dashed")
+		case graphMissing:
+			sb.WriteString(", style=dotted, color=red, fontcolor=red")
+		}
+		sb.WriteString("];\n")
+	}
+	for _, e := range m.edges {
+		fmt.Fprintf(&sb, "  %s -> %s [label=%s", dotQuote(e.from), dotQuote(e.to), dotQuote(e.field))
+		if e.viaInterface {
+			sb.WriteString(", arrowhead=empty")
+		}
+		sb.WriteString("];\n")
+	}
+	sb.WriteString("}\n")
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// dotEscaper escapes DOT quoted strings; line breaks become the \n label escape.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotQuote renders s as a DOT quoted string.
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
+
+// ExportMermaid writes the dependency graph as a Mermaid `graph TD` flowchart, for Markdown documentation. Node
+// IDs are reduced to the characters Mermaid accepts, while the labels keep the bean ID and type. Edges are
+// labeled with the receiving field; edges into interface fields are dotted, and edges on a dependency cycle are
+// thick and red. Literal and missing nodes get the `literal` and `missing` classes. It accepts the same options
+// as ExportDOT.
+func (c *Container) ExportMermaid(w io.Writer, opts ...GraphOption) error {
+	m, err := c.graph(newGraphConfig(opts))
+	if err != nil {
+		return err
+	}
+
+	ids := mermaidIDs(m.nodes)
+	var sb strings.Builder
+	sb.WriteString("graph TD\n")
+	for _, n := range m.nodes {
+		label := mermaidEscaper.Replace(n.id)
+		if n.typ != emptyString {
+			label += "<br/>" + mermaidEscaper.Replace(n.typ)
+		}
+		fmt.Fprintf(&sb, "  %s[\"%s\"]", ids[n.id], label)
+		switch n.kind {
+		case graphLiteral:
+			sb.WriteString(":::literal")
+		case graphMissing:
+			sb.WriteString(":::missing")
+		}
+		sb.WriteString("\n")
+	}
+	var cyclic []string
+	for i, e := range m.edges {
+		arrow := "-->"
+		switch {
+		case e.cyclic:
+			arrow = "==>"
+			cyclic = append(cyclic, strconv.Itoa(i))
+		case e.viaInterface:
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&sb, "  %s %s|\"%s\"| %s\n", ids[e.from], arrow, mermaidEscaper.Replace(e.field), ids[e.to])
+	}
+	sb.WriteString("  classDef literal stroke-dasharray: 5 5\n")
+	sb.WriteString("  classDef missing stroke:#d00,color:#d00,stroke-dasharray: 2 2\n")
+	if len(cyclic) > 0 {
+		fmt.Fprintf(&sb, "  linkStyle %s stroke:#d00,stroke-width:2px\n", strings.Join(cyclic, ","))
+	}
+
+	_, err = io.WriteString(w, sb.String())
+	return err
+}
+
+// mermaidEscaper escapes text inside quoted Mermaid labels with the entity codes Mermaid understands.
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "#", "#35;")
+
+// mermaidKeywords are the words Mermaid's flowchart parser reserves; bean IDs are lower case already.
+var mermaidKeywords = map[string]bool{
+	"end": true, "graph": true, "flowchart": true, "subgraph": true, "direction": true,
+	"style": true, "class": true, "classdef": true, "click": true, "linkstyle": true,
+}
+
+// mermaidIDs maps each node to a unique Mermaid node ID made of letters, digits and underscores. IDs Mermaid
+// would misread, such as "end" or a leading digit, are prefixed with "n_".
+func mermaidIDs(nodes []graphNode) map[string]string {
+	ids := make(map[string]string, len(nodes))
+	taken := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		base := strings.Map(func(r rune) rune {
+			if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+				return r
+			}
+			return '_'
+		}, n.id)
+		if mermaidKeywords[base] || base == emptyString || base[0] >= '0' && base[0] <= '9' {
+			base = "n_" + base
+		}
+		id := base
+		for i := 2; taken[id]; i++ {
+			id = base + "_" + strconv.Itoa(i)
+		}
+		taken[id] = true
+		ids[n.id] = id
+	}
+	return ids
+}
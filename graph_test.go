@@ -0,0 +1,144 @@
+package iocdi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func exportDOT(t *testing.T, c *Container, opts ...GraphOption) string {
+	t.Helper()
+	var sb strings.Builder
+	require.NoError(t, c.ExportDOT(&sb, opts...))
+	return sb.String()
+}
+
+func TestExportDOT_ServiceGraph(t *testing.T) {
+	c := newServiceGraph(t)
+
+	want := `digraph iocdi {
+  rankdir=LR;
+  node [shape=box];
+  "servicebean" [label="servicebean\n*iocdi.Service"];
+  "servicebeanconfig" [label="servicebeanconfig\n*iocdi.Config"];
+  "servicebeanlogger" [label="servicebeanlogger\n*iocdi.Logger"];
+  "workingdir" [label="workingdir\nstring", style=dashed];
+  "servicebean" -> "servicebeanconfig" [label="Config"];
+  "servicebean" -> "servicebeanlogger" [label="Logger"];
+  "servicebeanconfig" -> "workingdir" [label="WorkingDir"];
+}
+`
+	require.Equal(t, want, exportDOT(t, c))
+
+	// The literal bean synthesized by Build keeps its styling
+	require.NoError(t, c.Build())
+	require.Equal(t, want, exportDOT(t, c))
+
+	require.Equal(t, `digraph iocdi {
+  rankdir=LR;
+  node [shape=box];
+  "servicebeanconfig" [label="servicebeanconfig\n*iocdi.Config"];
+  "workingdir" [label="workingdir\nstring", style=dashed];
+  "servicebeanconfig" -> "workingdir" [label="WorkingDir"];
+}
+`, exportDOT(t, c, GraphRoot("ServiceBeanConfig")))
+
+	err := c.ExportDOT(&strings.Builder{}, GraphRoot("missing"))
+	require.ErrorIs(t, err, ErrBeanNotFound)
+}
+
+func TestExportDOT_MissingDependency(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*preloadRepo)(nil))))
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*receiver)(nil))))
+
+	require.Equal(t, `digraph iocdi {
+  rankdir=LR;
+  node [shape=box];
+  "dep" [label="dep\niocdi.testIface", style=dotted, color=red, fontcolor=red];
+  "dsn" [label="dsn\nstring", style=dotted, color=red, fontcolor=red];
+  "receiver" [label="receiver\n*iocdi.receiver"];
+  "repo" [label="repo\n*iocdi.preloadRepo"];
+  "receiver" -> "dep" [label="Dep", arrowhead=empty];
+  "repo" -> "dsn" [label="DSN"];
+}
+`, exportDOT(t, c))
+}
+
+func TestDOTQuote(t *testing.T) {
+	require.Equal(t, `"say \"hi\"\\"`, dotQuote(`say "hi"\`))
+}
+
+func exportMermaid(t *testing.T, c *Container, opts ...GraphOption) string {
+	t.Helper()
+	var sb strings.Builder
+	require.NoError(t, c.ExportMermaid(&sb, opts...))
+	return sb.String()
+}
+
+func TestExportMermaid_ServiceGraph(t *testing.T) {
+	c := newServiceGraph(t)
+
+	require.Equal(t, `graph TD
+  servicebean["servicebean<br/>*iocdi.Service"]
+  servicebeanconfig["servicebeanconfig<br/>*iocdi.Config"]
+  servicebeanlogger["servicebeanlogger<br/>*iocdi.Logger"]
+  workingdir["workingdir<br/>string"]:::literal
+  servicebean -->|"Config"| servicebeanconfig
+  servicebean -->|"Logger"| servicebeanlogger
+  servicebeanconfig -->|"WorkingDir"| workingdir
+  classDef literal stroke-dasharray: 5 5
+  classDef missing stroke:#d00,color:#d00,stroke-dasharray: 2 2
+`, exportMermaid(t, c))
+}
+
+func TestExportMermaid_Cycle(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("a", reflect.TypeOf((*cycleA)(nil))))
+	require.NoError(t, c.Register("b", reflect.TypeOf((*cycleB)(nil))))
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*receiver)(nil))))
+
+	require.Equal(t, `graph TD
+  a["a<br/>*iocdi.cycleA"]
+  b["b<br/>*iocdi.cycleB"]
+  dep["dep<br/>iocdi.testIface"]:::missing
+  receiver["receiver<br/>*iocdi.receiver"]
+  a ==>|"B"| b
+  b ==>|"A"| a
+  receiver -.->|"Dep"| dep
+  classDef literal stroke-dasharray: 5 5
+  classDef missing stroke:#d00,color:#d00,stroke-dasharray: 2 2
+  linkStyle 0,1 stroke:#d00,stroke-width:2px
+`, exportMermaid(t, c))
+}
+
+type mermaidNames struct {
+	Primary  *Logger `di.inject:"db.primary-conn"`
+	Fallback *Logger `di.inject:"db_primary_conn"`
+	End      *Logger `di.inject:"end"`
+	Quoted   *Logger `di.inject:"say\"hi"`
+}
+
+func TestExportMermaid_SanitizesIDs(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("2fa <svc>", reflect.TypeOf((*mermaidNames)(nil))))
+	for _, id := range []string{"db.primary-conn", "db_primary_conn", "end", `say"hi`} {
+		require.NoError(t, c.Register(id, reflect.TypeOf((*Logger)(nil))))
+	}
+
+	require.Equal(t, `graph TD
+  n_2fa__svc_["2fa #lt;svc#gt;<br/>*iocdi.mermaidNames"]
+  db_primary_conn["db.primary-conn<br/>*iocdi.Logger"]
+  db_primary_conn_2["db_primary_conn<br/>*iocdi.Logger"]
+  n_end["end<br/>*iocdi.Logger"]
+  say_hi["say#quot;hi<br/>*iocdi.Logger"]
+  n_2fa__svc_ -->|"Primary"| db_primary_conn
+  n_2fa__svc_ -->|"Fallback"| db_primary_conn_2
+  n_2fa__svc_ -->|"End"| n_end
+  n_2fa__svc_ -->|"Quoted"| say_hi
+  classDef literal stroke-dasharray: 5 5
+  classDef missing stroke:#d00,color:#d00,stroke-dasharray: 2 2
+`, exportMermaid(t, c))
+}
@@ -0,0 +1,139 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// groupPrefix marks a `di.inject` tag value that collects every member of a group into a slice field,
+// e.g. `di.inject:"group:http.handlers"`.
+const groupPrefix = "group:"
+
+// groupField records a slice field that collects the members of a group.
+type groupField struct {
+	field string
+	group string
+}
+
+// InGroups adds the bean to the named groups. Group names are case-insensitive.
+func InGroups(groups ...string) RegisterOption {
+	return func(b *bean) {
+		for _, g := range groups {
+			if g = strings.ToLower(strings.TrimSpace(g)); g != emptyString {
+				b.groups = appendUnique(b.groups, g)
+			}
+		}
+	}
+}
+
+// WithStrictGroups makes Build fail when a receiver collects a group that has no members.
+// By default such a field receives an empty slice.
+func WithStrictGroups() Option {
+	return func(c *Container) {
+		c.strictGroups = true
+	}
+}
+
+// GroupMembers returns the IDs of the beans in the named group, sorted. It does not trigger Build.
+func (c *Container) GroupMembers(group string) []string {
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+	return c.groupMembers(strings.ToLower(group))
+}
+
+// groupMembers returns the sorted member IDs of a group. Callers must hold regMu.
+func (c *Container) groupMembers(group string) []string {
+	members := make([]string, 0)
+	for id, bn := range c.registeredBeans {
+		for _, g := range bn.groups {
+			if g == group {
+				members = append(members, id)
+				break
+			}
+		}
+	}
+	sort.Strings(members)
+	return members
+}
+
+// discoverGroups reads the group memberships declared with `di.group` tags on the bean's own struct fields
+// (conventionally a blank `_ struct{}` field) and the slice fields that collect groups through
+// `di.inject:"group:<name>"`.
+func discoverGroups(beanType reflect.Type) (memberOf []string, collectors []groupField) {
+	if beanType.Kind() == reflect.Ptr {
+		beanType = beanType.Elem()
+	}
+	if beanType.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	for i := 0; i < beanType.NumField(); i++ {
+		sf := beanType.Field(i)
+		if raw, ok := sf.Tag.Lookup(string(group)); ok {
+			for _, g := range strings.Split(raw, ",") {
+				if g = strings.ToLower(strings.TrimSpace(g)); g != emptyString {
+					memberOf = appendUnique(memberOf, g)
+				}
+			}
+		}
+
+		tagVal, _, _ := injectTag(sf)
+		if sf.IsExported() && sf.Type.Kind() == reflect.Slice && strings.HasPrefix(tagVal, groupPrefix) {
+			collectors = append(collectors, groupField{field: sf.Name, group: strings.TrimPrefix(tagVal, groupPrefix)})
+		}
+	}
+	return memberOf, collectors
+}
+
+// injectGroups fills each group-collecting slice field of the receiver with the group's members in bean-ID order.
+// Callers must hold regMu.
+func (c *Container) injectGroups(receiverBean bean) error {
+	if len(receiverBean.groupFields) == 0 {
+		return nil
+	}
+	rv := reflect.ValueOf(receiverBean.instance)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	rv = rv.Elem()
+
+	for _, gf := range receiverBean.groupFields {
+		fv := rv.FieldByName(gf.field)
+		if !fv.CanSet() {
+			continue
+		}
+		members := c.groupMembers(gf.group)
+		if len(members) == 0 && c.strictGroups {
+			return fmt.Errorf("group '%s' collected by field '%s' of bean '%s' has no members", gf.group, gf.field, receiverBean.id)
+		}
+
+		elemType := fv.Type().Elem()
+		slice := reflect.MakeSlice(fv.Type(), 0, len(members))
+		for _, id := range members {
+			member := c.registeredBeans[id]
+			if member.instance == nil {
+				return fmt.Errorf("group '%s' member '%s' for bean '%s' not instantiated", gf.group, id, receiverBean.id)
+			}
+			mv := reflect.ValueOf(member.instance)
+			if !mv.Type().AssignableTo(elemType) {
+				return fmt.Errorf("group '%s' member '%s' (%v) is not assignable to field '%s' of bean '%s' (%v)",
+					gf.group, id, mv.Type(), gf.field, receiverBean.id, fv.Type())
+			}
+			slice = reflect.Append(slice, mv)
+		}
+		fv.Set(slice)
+	}
+	return nil
+}
+
+// appendUnique appends s to list unless it is already present.
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
@@ -0,0 +1,87 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type handler interface{ Route() string }
+
+type statusHandler struct {
+	_ struct{} `di.group:"http.handlers"`
+}
+
+func (h *statusHandler) Route() string { return "/status" }
+
+type usersHandler struct{ _ byte }
+
+func (h *usersHandler) Route() string { return "/users" }
+
+type adminHandler struct {
+	_ struct{} `di.group:"http.handlers, admin"`
+}
+
+func (h *adminHandler) Route() string { return "/admin" }
+
+type router struct {
+	Handlers []handler `di.inject:"group:http.handlers"`
+}
+
+type adminRouter struct {
+	Handlers []handler `di.inject:"group:admin"`
+}
+
+type metricsRouter struct {
+	Handlers []handler `di.inject:"group:metrics"`
+}
+
+func TestGroups_CollectedInBeanIDOrder(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("router", reflect.TypeOf((*router)(nil))))
+	require.NoError(t, c.Register("h2-status", reflect.TypeOf((*statusHandler)(nil))))
+	require.NoError(t, c.Register("h1-users", reflect.TypeOf((*usersHandler)(nil)), InGroups("http.handlers")))
+	require.NoError(t, c.RegisterInstance("h3-admin", &adminHandler{}))
+
+	r, err := ResolveAs[*router](c, "router")
+	require.NoError(t, err)
+	routes := make([]string, 0, len(r.Handlers))
+	for _, h := range r.Handlers {
+		routes = append(routes, h.Route())
+	}
+	require.Equal(t, []string{"/users", "/status", "/admin"}, routes)
+
+	require.Equal(t, []string{"h1-users", "h2-status", "h3-admin"}, c.GroupMembers("http.handlers"))
+	require.Equal(t, []string{"h3-admin"}, c.GroupMembers("Admin"))
+}
+
+func TestGroups_EmptyGroupYieldsEmptySlice(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("router", reflect.TypeOf((*metricsRouter)(nil))))
+
+	r, err := ResolveAs[*metricsRouter](c, "router")
+	require.NoError(t, err)
+	require.NotNil(t, r.Handlers)
+	require.Empty(t, r.Handlers)
+}
+
+func TestGroups_StrictEmptyGroupFailsBuild(t *testing.T) {
+	c := New(WithStrictGroups())
+	require.NoError(t, c.Register("router", reflect.TypeOf((*metricsRouter)(nil))))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "group 'metrics' collected by field 'Handlers' of bean 'router' has no members")
+}
+
+func TestGroups_StructTagMembershipOnly(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("router", reflect.TypeOf((*adminRouter)(nil))))
+	require.NoError(t, c.Register("admin", reflect.TypeOf((*adminHandler)(nil))))
+
+	r, err := ResolveAs[*adminRouter](c, "router")
+	require.NoError(t, err)
+	require.Len(t, r.Handlers, 1)
+	require.Equal(t, "/admin", r.Handlers[0].Route())
+}
@@ -0,0 +1,115 @@
+package iocdi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultHealthTimeout bounds each health check unless WithHealthTimeout says otherwise.
+	defaultHealthTimeout = 5 * time.Second
+	// defaultHealthConcurrency is the number of health checks run at once unless WithHealthConcurrency says otherwise.
+	defaultHealthConcurrency = 8
+)
+
+// HealthChecker is an optional interface for beans that can report their own health, e.g. by pinging a
+// database or checking a queue depth. See Container.Health.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// WithHealthTimeout bounds each HealthCheck run by Health; a check still running when d elapses is reported
+// as timed out and abandoned. The default is 5 seconds.
+func WithHealthTimeout(d time.Duration) Option {
+	return func(c *Container) {
+		c.healthTimeout = d
+	}
+}
+
+// WithHealthConcurrency limits how many health checks Health runs at once. The default is 8.
+func WithHealthConcurrency(n int) Option {
+	return func(c *Container) {
+		c.healthConcurrency = n
+	}
+}
+
+// Health runs HealthCheck on every built bean implementing HealthChecker, concurrently and each bounded by the
+// health timeout, and returns the result per bean ID; a nil error means healthy. An unbuilt container has no
+// beans to check and returns an empty map.
+func (c *Container) Health(ctx context.Context) map[string]error {
+	results := make(map[string]error)
+	if !c.built.Load() {
+		return results
+	}
+
+	checkers := make(map[string]HealthChecker)
+	c.regMu.RLock()
+	for id, bn := range c.registeredBeans {
+		if hc, ok := bn.instance.(HealthChecker); ok {
+			checkers[id] = hc
+		}
+	}
+	c.regMu.RUnlock()
+
+	timeout := c.healthTimeout
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+	limit := c.healthConcurrency
+	if limit <= 0 {
+		limit = defaultHealthConcurrency
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, limit)
+	)
+	for _, id := range sortedKeys(checkers) {
+		hc := checkers[id]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			err := runHealthCheck(ctx, id, hc, timeout)
+			mu.Lock()
+			results[id] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// Healthy reports whether every HealthChecker bean passed its health check.
+func (c *Container) Healthy(ctx context.Context) bool {
+	for _, err := range c.Health(ctx) {
+		if err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// runHealthCheck runs a single health check, cutting it off when the timeout elapses even if the checker
+// ignores its context. Panics are reported as a PanicError.
+func runHealthCheck(ctx context.Context, beanID string, hc HealthChecker, timeout time.Duration) error {
+	cctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1) // buffered so an abandoned check can still finish
+	go func() {
+		done <- callRecovered(beanID, func() error { return hc.HealthCheck(cctx) })
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-cctx.Done():
+		return fmt.Errorf("health check timed out after %v: %w", timeout, cctx.Err())
+	}
+}
@@ -0,0 +1,59 @@
+package iocdi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type healthyDB struct{}
+
+func (h *healthyDB) HealthCheck(ctx context.Context) error { return nil }
+
+type failingQueue struct{}
+
+func (f *failingQueue) HealthCheck(ctx context.Context) error { return errors.New("queue depth 10000") }
+
+// stuckCache ignores its context, so only the container's timeout can cut it off
+type stuckCache struct{}
+
+func (s *stuckCache) HealthCheck(ctx context.Context) error {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func TestHealth_AggregatesChecks(t *testing.T) {
+	c := New(WithHealthTimeout(50 * time.Millisecond))
+	require.NoError(t, c.RegisterInstance("db", &healthyDB{}))
+	require.NoError(t, c.RegisterInstance("queue", &failingQueue{}))
+	require.NoError(t, c.RegisterInstance("cache", &stuckCache{}))
+	require.NoError(t, c.RegisterInstance("name", "not a checker"))
+	require.NoError(t, c.Build())
+
+	start := time.Now()
+	results := c.Health(context.Background())
+	require.Less(t, time.Since(start), 500*time.Millisecond)
+
+	require.Len(t, results, 3)
+	require.NoError(t, results["db"])
+	require.EqualError(t, results["queue"], "queue depth 10000")
+	require.ErrorIs(t, results["cache"], context.DeadlineExceeded)
+	require.False(t, c.Healthy(context.Background()))
+}
+
+func TestHealthy_AllPassing(t *testing.T) {
+	c := New(WithHealthConcurrency(1))
+	require.NoError(t, c.RegisterInstance("db", &healthyDB{}))
+	require.NoError(t, c.RegisterInstance("replica", &healthyDB{}))
+	require.NoError(t, c.Build())
+	require.True(t, c.Healthy(context.Background()))
+}
+
+func TestHealth_UnbuiltContainer(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("queue", &failingQueue{}))
+	require.Empty(t, c.Health(context.Background()))
+}
@@ -1,12 +1,15 @@
 package iocdi
 
 import (
+	"context"
 	"fmt"
 	"reflect"
-	"strings"
 )
 
-func createInstance(beanType reflect.Type) (any, error) {
+// createInstance allocates a new instance of the bean's type. A panic raised by reflection is returned as a
+// PanicError attributed to the bean.
+func createInstance(beanID string, beanType reflect.Type) (instance any, err error) {
+	defer recoverPanic(beanID, &err)
 	if beanType.Kind() == reflect.Ptr {
 		return reflect.New(beanType.Elem()).Interface(), nil
 	}
@@ -18,12 +21,23 @@ func createInstance(beanType reflect.Type) (any, error) {
 	return nil, fmt.Errorf("beanType is not supported: %v", beanType.Kind())
 }
 
-func injectIntoStruct(receiverBean bean, depBean bean, chain []string) error {
+// injectIntoStruct sets the receiver's fields that take depBean. A panic raised while reflecting over the
+// receiver is returned as a PanicError attributed to the receiver bean and, once a field is being set, to that
+// field and depBean.
+func (c *Container) injectIntoStruct(receiverBean bean, depBean bean, chain []string) error {
+	return c.injectIntoField(receiverBean, depBean, chain, emptyString)
+}
+
+// injectIntoField is injectIntoStruct limited to the named field; an empty name sets every field taking depBean.
+func (c *Container) injectIntoField(receiverBean bean, depBean bean, chain []string, only string) (err error) {
+	var field *reflect.StructField // the field being set, if any, to attribute a panic to
+	defer recoverInjectionPanic(receiverBean.id, depBean.id, &field, &err)
+
 	// Fail fast if a direct/self cycle is observed based on the current chain context.
 	// This complements the DFS detection in injectDependencies with a local guard.
 	for _, id := range chain {
 		if id == depBean.id {
-			return fmt.Errorf("dependency cycle detected: %s -> %s", strings.Join(chain, pathSep), depBean.id)
+			return c.cycleError(chain, depBean.id)
 		}
 	}
 
@@ -35,7 +49,7 @@ func injectIntoStruct(receiverBean bean, depBean bean, chain []string) error {
 		return fmt.Errorf("injectIntoStruct: receiver bean '%s' is not a struct", receiverBean.id)
 	}
 
-	depVal := reflect.ValueOf(depBean.instance)
+	sharedVal := reflect.ValueOf(depBean.instance)
 	depType := depBean.beanType
 
 	// Iterate exported fields and inject only when the tag matches the dependency id.
@@ -43,21 +57,47 @@ func injectIntoStruct(receiverBean bean, depBean bean, chain []string) error {
 		sf := rv.Type().Field(i)
 		// Honor tag usage: only consider fields with di.inject tag matching the dep bean id.
 		// Normalize tag to lowercase to align with the container's lowercase bean ID policy.
-		tagVal := sf.Tag.Get(string(inject))
-		if tagVal == emptyString {
+		// Untagged fields are only considered when autowiring chose this dependency for them.
+		tagVal, opts, _ := injectTag(sf)
+		if tagVal == excluded || (only != emptyString && sf.Name != only) {
 			continue
 		}
-		tagVal = strings.ToLower(tagVal)
-		if tagVal != depBean.id {
+		tagVal = dependencyID(receiverBean.id, tagVal, opts)
+		if tagVal == emptyString {
+			if id, ok := receiverBean.autowiredID(sf.Name); !ok || id != depBean.id {
+				continue
+			}
+		} else if tagVal != depBean.id {
 			continue
 		}
+		field = &sf
 
 		fv := rv.Field(i)
 		if !fv.CanSet() {
+			if l := c.log(); l != nil {
+				l.Warn("iocdi: tagged field is not settable; left unset", "bean", receiverBean.id, "field", sf.Name, "dependency", depBean.id)
+			}
+			c.recordSkip(receiverBean.id, sf.Name, depBean.id, SkipNotSettable)
+			c.warnNotSettable(receiverBean.id, sf, depBean.id)
 			continue
 		}
 
+		// Prototype fields receive a fresh, fully wired instance instead of the shared singleton
+		depVal := sharedVal
+		if opts.has(injectPrototype) || opts.has(injectCopy) || depBean.prototype {
+			mode := depBean.copyMode
+			if opts.has(injectCopy) && mode == copyNone {
+				mode = copyShallowPointers
+			}
+			proto, err := c.newPrototype(depBean, mode, append(append([]string{}, chain...), receiverBean.id))
+			if err != nil {
+				return fmt.Errorf("injectIntoStruct: prototype '%s' for field '%s' of receiver bean '%s': %w", depBean.id, sf.Name, receiverBean.id, err)
+			}
+			depVal = reflect.ValueOf(proto)
+		}
+
 		fieldType := fv.Type()
+		injected := Event{Kind: EventInjected, BeanID: receiverBean.id, DependencyID: depBean.id, Field: sf.Name}
 
 		// Exact type match, including basic types like string and exact pointer types
 		if fieldType == depType {
@@ -68,6 +108,7 @@ func injectIntoStruct(receiverBean bean, depBean bean, chain []string) error {
 			} else {
 				fv.Set(depVal)
 			}
+			c.recordInjection(injected, depBean)
 			continue
 		}
 
@@ -76,6 +117,9 @@ func injectIntoStruct(receiverBean bean, depBean bean, chain []string) error {
 			// Use depVal.Type() instead of depType in case instance is a more specific concrete type
 			if depVal.Type().Implements(fieldType) {
 				fv.Set(depVal)
+				c.recordInjection(injected, depBean)
+			} else {
+				c.logIncompatible(receiverBean.id, sf.Name, depBean.id, fieldType, depVal.Type())
 			}
 			continue
 		}
@@ -86,23 +130,131 @@ func injectIntoStruct(receiverBean bean, depBean bean, chain []string) error {
 			ptr := reflect.New(depType)
 			ptr.Elem().Set(depVal)
 			fv.Set(ptr)
+			c.recordInjection(injected, depBean)
 			continue
 		}
 
 		// field: T, dep: *T
 		if fieldType.Kind() == reflect.Struct && depType.Kind() == reflect.Ptr && depType.Elem() == fieldType {
 			fv.Set(depVal.Elem())
+			c.recordInjection(injected, depBean)
 			continue
 		}
 
 		// field: *T, dep: *T with same element types
 		if fieldType.Kind() == reflect.Ptr && depType.Kind() == reflect.Ptr && fieldType.Elem() == depType.Elem() {
 			fv.Set(depVal)
+			c.recordInjection(injected, depBean)
+			continue
+		}
+
+		// Consult the converter registry before giving up
+		if converted, ok, err := c.convert(depVal, fieldType); ok {
+			if err != nil {
+				return fmt.Errorf("injectIntoStruct: converting '%s' for field '%s' of receiver bean '%s': %w", depBean.id, sf.Name, receiverBean.id, err)
+			}
+			fv.Set(converted)
+			c.recordInjection(injected, depBean)
+			continue
+		}
+
+		// Named basic types: e.g. an int into `type Port int`, a time.Duration into `type MyDuration time.Duration`
+		if namedConvertible(depVal.Type(), fieldType) {
+			fv.Set(depVal.Convert(fieldType))
+			c.recordInjection(injected, depBean)
 			continue
 		}
 
 		// If we reach here, types are incompatible; leave field untouched (explicit tag ensures we don't match by type alone).
+		c.logIncompatible(receiverBean.id, sf.Name, depBean.id, fieldType, depVal.Type())
+	}
+
+	return nil
+}
+
+// prototypeInstance creates a fresh instance of a bean registered with Prototype for a single resolution.
+// The instance is wired under the read lock, which is released before its initializer runs so the initializer
+// may resolve beans, including deferred ones. A failure is attributed to the bean.
+func (c *Container) prototypeInstance(bn bean) (any, error) {
+	c.regMu.RLock()
+	instance, fresh, err := c.wirePrototype(bn, bn.copyMode, nil)
+	c.regMu.RUnlock()
+	if err == nil && fresh {
+		err = c.initPrototype(bn, instance)
+	}
+	if err != nil {
+		return nil, &BeanError{ID: bn.id, Err: err}
+	}
+	return instance, nil
+}
+
+// newPrototype creates a fresh instance of a struct bean, injects its own dependencies from the built graph
+// (recursively honoring prototype fields), applies its values and runs its Initializer. The instance starts
+// from a zero value, or from a clone of the registered instance when a copy mode is given. The shared
+// singleton is left untouched. Non-struct beans (e.g., literals) are returned as-is since they are copied by value.
+// Callers must hold regMu.
+func (c *Container) newPrototype(template bean, mode copyMode, chain []string) (any, error) {
+	instance, fresh, err := c.wirePrototype(template, mode, chain)
+	if err != nil || !fresh {
+		return instance, err
+	}
+	if err := c.initPrototype(template, instance); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// wirePrototype is the part of newPrototype before the initializer runs. fresh is false for a non-struct bean,
+// whose registered instance is returned as-is.
+// Callers must hold regMu.
+func (c *Container) wirePrototype(template bean, mode copyMode, chain []string) (instance any, fresh bool, err error) {
+	if template.beanType == nil || template.beanType.Kind() != reflect.Ptr || template.beanType.Elem().Kind() != reflect.Struct {
+		return template.instance, false, nil
 	}
 
+	if mode != copyNone && template.instance != nil {
+		instance = cloneInstance(template.instance, mode)
+	} else if instance, err = createInstance(template.id, template.beanType); err != nil {
+		return nil, false, err
+	}
+	receiver := template
+	receiver.instance = instance
+
+	for _, depID := range c.edges(receiver) {
+		depBean, ok := c.registeredBeans[depID]
+		if !ok || (depBean.instance == nil && !depBean.prototype) {
+			return nil, false, fmt.Errorf("dependency bean '%s' for prototype '%s' not instantiated", depID, template.id)
+		}
+		if err := c.injectIntoStruct(receiver, depBean, chain); err != nil {
+			return nil, false, err
+		}
+	}
+	if err := c.injectGroups(receiver); err != nil {
+		return nil, false, err
+	}
+	if err := c.injectValues(receiver); err != nil {
+		return nil, false, err
+	}
+	return instance, true, nil
+}
+
+// initPrototype runs the initializer of a prototype wired by wirePrototype and tracks it if the bean asks for it.
+func (c *Container) initPrototype(template bean, instance any) error {
+	if err := initializeWithin(context.Background(), template.id, instance, c.initTimeoutFor(template)); err != nil {
+		return fmt.Errorf("initializer for prototype '%s' failed: %w", template.id, err)
+	}
+	if template.trackPrototypes {
+		c.prototypes.track(template.id, instance)
+	}
 	return nil
 }
+
+// namedConvertible reports whether a value of type from can be converted to type to without changing its
+// meaning: both must be basic kinds of the same kind (e.g., int and a named int), so numeric-to-string
+// conversions, which Go defines as rune conversion, are never applied. Structs remain strict.
+func namedConvertible(from, to reflect.Type) bool {
+	if from.Kind() != to.Kind() || !isBasicKind(from.Kind()) {
+		return false
+	}
+	return from.AssignableTo(to) || from.ConvertibleTo(to)
+}
@@ -1,7 +1,10 @@
 package iocdi
 
 import (
+	"fmt"
 	"reflect"
+	"slices"
+	"strings"
 	"sync/atomic"
 )
 
@@ -14,6 +17,13 @@ import (
 // - err: any error occurred while sourcing the value (e.g., parsing, I/O)
 type LiteralProvider func(id string, targetType reflect.Type) (value any, found bool, err error)
 
+// EphemeralLiteral wraps a LiteralProvider value, such as a secret, that is injected into the requesting field
+// but not registered as a bean: it cannot be resolved by ID or seen through introspection, and every field
+// naming the ID asks the provider again. Return it with found set to true.
+type EphemeralLiteral struct {
+	Value any
+}
+
 // literalProvider holds the global hook. It is guarded with atomic.Value to allow
 // lock-free, race-free reads during injection while supporting concurrent updates.
 var literalProvider atomic.Value // stores LiteralProvider
@@ -25,6 +35,7 @@ func init() {
 
 // SetLiteralProvider installs a global literal provider hook.
 // A typical implementation might read env vars, files, flags, or other configuration sources.
+// A container captures the hook when its Build starts; see Container.SetLiteralProvider.
 func SetLiteralProvider(p LiteralProvider) {
 	literalProvider.Store(p)
 }
@@ -36,3 +47,242 @@ func loadLiteralProvider() LiteralProvider {
 	}
 	return nil
 }
+
+// LiteralRequest describes a missing dependency for a LiteralProviderV2, including who is asking.
+type LiteralRequest struct {
+	// ID is the lower-cased value of the `di.inject` tag.
+	ID string
+	// TargetType is the type expected for the dependency.
+	TargetType reflect.Type
+	// ReceiverBeanID is the ID of the bean whose field needs the value, or the type name of a target passed
+	// to Inject.
+	ReceiverBeanID string
+	// ReceiverField is the name of that field.
+	ReceiverField string
+}
+
+// LiteralProviderV2 is a LiteralProvider that also receives the receiver bean and field, so two beans
+// declaring the same dependency ID can be given different values. Because its answer may depend on the
+// receiver, a value it returns is injected into the requesting field only and never stored as a bean, as with
+// EphemeralLiteral: every field naming the ID asks again.
+type LiteralProviderV2 func(req LiteralRequest) (value any, found bool, err error)
+
+// V2 adapts the provider to a LiteralProviderV2 that ignores the receiver.
+func (p LiteralProvider) V2() LiteralProviderV2 {
+	return func(req LiteralRequest) (any, bool, error) {
+		return p(req.ID, req.TargetType)
+	}
+}
+
+// TypedLiteralProvider adapts a strongly typed function into a LiteralProvider. It only claims dependencies whose
+// type is T, or a named type of the same basic kind (e.g. `type Port int` for int), converting the value to it;
+// for any other type it reports not found without calling fn, so a chain moves on to the next provider. Chained,
+// such providers can serve strings, durations and ints separately:
+//
+//	c.AddLiteralProvider(iocdi.TypedLiteralProvider(func(id string) (string, bool, error) { ... }))
+//	c.AddLiteralProvider(iocdi.TypedLiteralProvider(func(id string) (time.Duration, bool, error) { ... }))
+func TypedLiteralProvider[T any](fn func(id string) (T, bool, error)) LiteralProvider {
+	t := reflect.TypeFor[T]()
+	return func(id string, targetType reflect.Type) (any, bool, error) {
+		if targetType != t && !namedConvertible(t, targetType) {
+			return nil, false, nil
+		}
+		v, found, err := fn(id)
+		if err != nil || !found {
+			return nil, found, err
+		}
+		if targetType == t {
+			return v, true, nil
+		}
+		return reflect.ValueOf(v).Convert(targetType).Interface(), true, nil
+	}
+}
+
+// literalSource is an entry in a container's provider chain. Values from receiver-aware providers are not
+// stored as beans.
+type literalSource struct {
+	provide     LiteralProviderV2
+	perReceiver bool
+}
+
+// LiteralValidator checks a literal value before it is injected, e.g. rejecting an empty directory or an
+// out-of-range port. id is the lower-cased dependency ID.
+type LiteralValidator func(id string, value any) error
+
+// SetLiteralValidator installs a validator that every value a literal provider reports found passes before it is
+// injected or stored as a bean. An error fails Build naming the dependency ID. With WithValidatedStringBeans the
+// validator also checks registered string beans at Build. nil removes the validator.
+func (c *Container) SetLiteralValidator(v LiteralValidator) {
+	if v == nil {
+		c.literalValidator.Store(nil)
+		return
+	}
+	c.literalValidator.Store(&v)
+}
+
+// validateLiteral runs the literal validator, if any, on the value for the ID.
+func (c *Container) validateLiteral(id string, value any) error {
+	v := c.literalValidator.Load()
+	if v == nil {
+		return nil
+	}
+	if err := (*v)(id, value); err != nil {
+		return fmt.Errorf("literal '%s' failed validation: %w", id, err)
+	}
+	return nil
+}
+
+// validateStringBeans runs the literal validator over the registered string beans when WithValidatedStringBeans
+// is set, in bean-ID order.
+// Callers must hold regMu.
+func (c *Container) validateStringBeans() error {
+	if !c.validateStrings {
+		return nil
+	}
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		if bn.literal || bn.instance == nil || bn.beanType.Kind() != reflect.String {
+			continue
+		}
+		if err := c.validateLiteral(id, bn.instance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetLiteralProvider installs a literal provider for this container only, replacing any providers added with
+// AddLiteralProvider; nil removes them all. Container providers are consulted before the global provider set
+// with the package-level SetLiteralProvider, which is still asked when none of them reports found.
+//
+// Build captures the container's providers and the global one when it starts, and both its precheck and its
+// injection use that capture. A successful Build keeps it: providers set or cleared afterwards have no effect on
+// the container, including lazy resolution and RefreshLiterals, until Reset. After a failed Build the next one
+// captures the providers anew, so installing a provider after an implicit Build failed is enough.
+func (c *Container) SetLiteralProvider(p LiteralProvider) {
+	var chain []literalSource
+	if p != nil {
+		chain = []literalSource{{provide: p.V2()}}
+	}
+	c.literalProviders.Store(&chain)
+}
+
+// AddLiteralProvider appends a provider to the container's chain, e.g. one for environment variables, then
+// one for flags, then one for a configuration file. At injection the providers are asked in the order they were
+// added and the first reporting found wins; an error from any of them aborts the lookup.
+func (c *Container) AddLiteralProvider(p LiteralProvider) {
+	if p == nil {
+		return
+	}
+	c.addLiteralSource(literalSource{provide: p.V2()})
+}
+
+// AddLiteralProviderV2 appends a receiver-aware provider to the container's chain. See AddLiteralProvider for
+// the order the chain is asked in and LiteralProviderV2 for how its values are injected.
+func (c *Container) AddLiteralProviderV2(p LiteralProviderV2) {
+	if p == nil {
+		return
+	}
+	c.addLiteralSource(literalSource{provide: p, perReceiver: true})
+}
+
+// addLiteralSource appends an entry to the provider chain, copying it on write.
+func (c *Container) addLiteralSource(src literalSource) {
+	for {
+		old := c.literalProviders.Load()
+		var chain []literalSource
+		if old != nil {
+			chain = slices.Clone(*old)
+		}
+		chain = append(chain, src)
+		if c.literalProviders.CompareAndSwap(old, &chain) {
+			return
+		}
+	}
+}
+
+// ClearLiteralProviders removes the container's literal providers, leaving only the global one.
+func (c *Container) ClearLiteralProviders() {
+	c.literalProviders.Store(nil)
+}
+
+// containerLiteralProviders returns the container's provider chain.
+func (c *Container) containerLiteralProviders() []literalSource {
+	if chain := c.literalProviders.Load(); chain != nil {
+		return *chain
+	}
+	return nil
+}
+
+// literalSnapshot is the provider state a Build captured. See SetLiteralProvider.
+type literalSnapshot struct {
+	chain  []literalSource
+	global LiteralProvider
+}
+
+// captureLiteralProviders records the current provider state for Build unless one is already in effect, and
+// reports whether it did.
+// Callers must hold regMu.
+func (c *Container) captureLiteralProviders() bool {
+	if c.capturedLiterals != nil {
+		return false
+	}
+	c.capturedLiterals = &literalSnapshot{chain: c.containerLiteralProviders(), global: loadLiteralProvider()}
+	return true
+}
+
+// literalSources returns the provider chain and global provider in effect: the ones captured by Build, or the
+// current ones before any Build.
+// Callers must hold regMu.
+func (c *Container) literalSources() ([]literalSource, LiteralProvider) {
+	if s := c.capturedLiterals; s != nil {
+		return s.chain, s.global
+	}
+	return c.containerLiteralProviders(), loadLiteralProvider()
+}
+
+// WithLiterals supplies literal values from a map, keyed by dependency ID (case-insensitive), e.g. for
+// WorkingDir. The map is copied, so changing it after New has no effect. It is consulted before any literal
+// provider; values may be strings or other basic kinds, as for a LiteralProvider. Several calls merge.
+func WithLiterals(values map[string]any) Option {
+	return func(c *Container) {
+		if c.staticLiterals == nil {
+			c.staticLiterals = make(map[string]any, len(values))
+		}
+		for id, v := range values {
+			c.staticLiterals[strings.ToLower(id)] = v
+		}
+	}
+}
+
+// hasLiteralProvider reports whether static literals or a container or global literal provider are installed.
+// Callers must hold regMu.
+func (c *Container) hasLiteralProvider() bool {
+	chain, global := c.literalSources()
+	return len(c.staticLiterals) > 0 || len(chain) > 0 || global != nil
+}
+
+// lookupLiteral consults the static literals, then asks the container's literal providers in order, then the
+// global one, for the dependency's value. perReceiver reports whether the value came from a receiver-aware
+// provider.
+// Callers must hold regMu.
+func (c *Container) lookupLiteral(req LiteralRequest) (val any, found, perReceiver bool, err error) {
+	if v, ok := c.staticLiterals[req.ID]; ok {
+		return v, true, false, nil
+	}
+	chain, global := c.literalSources()
+	for i, src := range chain {
+		val, found, err := src.provide(req)
+		if err != nil {
+			return nil, false, false, fmt.Errorf("literal provider %d: %w", i, err)
+		}
+		if found {
+			return val, true, src.perReceiver, nil
+		}
+	}
+	if global != nil {
+		val, found, err = global(req.ID, req.TargetType)
+		return val, found, false, err
+	}
+	return nil, false, false, nil
+}
@@ -1,5 +1,11 @@
 package iocdi
 
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
 // Initializer is an optional interface that a bean may implement to perform
 // additional initialization after all of its dependencies have been injected.
 //
@@ -9,8 +15,77 @@ package iocdi
 // error.
 //
 // Note: This interface is intentionally defined in the root iocdi package with
-// no imports and no references to internal container types to avoid introducing
+// only standard library imports and no references to internal container types to avoid introducing
 // cyclic dependencies when implemented by beans in other modules/packages.
 type Initializer interface {
 	Initialize() error
 }
+
+// ContextInitializer is the context-aware variant of Initializer for initialization that may block, e.g. on
+// the network. When a bean implements both, the container calls InitializeCtx instead of Initialize, passing
+// the context given to BuildContext.
+type ContextInitializer interface {
+	InitializeCtx(ctx context.Context) error
+}
+
+// isInitializer reports whether instance implements Initializer or ContextInitializer.
+func isInitializer(instance any) bool {
+	_, ok := instance.(Initializer)
+	_, okCtx := instance.(ContextInitializer)
+	return ok || okCtx
+}
+
+// initialize runs the initializer of instance, preferring ContextInitializer over Initializer.
+// Instances implementing neither are left untouched.
+func initialize(ctx context.Context, instance any) error {
+	if initr, ok := instance.(ContextInitializer); ok {
+		return initr.InitializeCtx(ctx)
+	}
+	if initr, ok := instance.(Initializer); ok {
+		return initr.Initialize()
+	}
+	return nil
+}
+
+// initializeWithin runs the initializer of the bean's instance, failing with ErrInitTimeout once timeout elapses.
+// A ContextInitializer receives the deadline through its context and is run synchronously; a plain
+// Initializer runs in a goroutine that is abandoned on timeout. A non-positive timeout disables the limit.
+// Panics are returned as a PanicError attributed to beanID.
+func initializeWithin(ctx context.Context, beanID string, instance any, timeout time.Duration) error {
+	run := func(ctx context.Context) error {
+		return callRecovered(beanID, func() error { return initialize(ctx, instance) })
+	}
+	if timeout <= 0 {
+		return run(ctx)
+	}
+	if _, ok := instance.(ContextInitializer); ok {
+		tctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return run(tctx)
+	}
+
+	start := time.Now()
+	done := make(chan error, 1) // buffered so an abandoned initializer can still finish
+	go func() {
+		done <- run(ctx)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("%w after %v", ErrInitTimeout, time.Since(start).Round(time.Millisecond))
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// initTimeoutFor returns the initializer timeout of the bean, falling back to the container default.
+func (c *Container) initTimeoutFor(b bean) time.Duration {
+	if b.initTimeout > 0 {
+		return b.initTimeout
+	}
+	return c.initTimeout
+}
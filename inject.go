@@ -0,0 +1,124 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// InjectOption configures a single Inject or InjectStruct call.
+type InjectOption func(*injectConfig)
+
+type injectConfig struct {
+	requireTags bool
+}
+
+// RequireTags makes Inject and InjectStruct fail with ErrNoInjectionTags when the target's struct type has
+// no `di.inject` tags at all, which usually means the wrong object was passed.
+func RequireTags() InjectOption {
+	return func(cfg *injectConfig) {
+		cfg.requireTags = true
+	}
+}
+
+// InjectStruct is the type-safe form of Container.Inject: the compiler guarantees a pointer is passed and
+// the caller keeps its concrete type, so no assertion is needed afterwards.
+func InjectStruct[T any](c *Container, target *T, opts ...InjectOption) error {
+	if target == nil {
+		return ErrBeanParamIsNil
+	}
+	return c.Inject(target, opts...)
+}
+
+// Inject wires the tagged fields of an object the container did not create, such as an HTTP handler
+// instantiated by a router or a CLI command struct. The target must be a pointer to a struct.
+//
+// Each field tagged with `di.inject` is resolved from the built container (building it first if needed),
+// including the LiteralProvider fallback for strings, and set exactly as it would be for a registered bean.
+// `di.value`, `di.env` and group fields are applied as well. The target itself is not stored in the container.
+func (c *Container) Inject(target any, opts ...InjectOption) error {
+	if target == nil {
+		return ErrBeanParamIsNil
+	}
+	targetType := reflect.TypeOf(target)
+	if targetType.Kind() != reflect.Ptr || targetType.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: got %v", ErrInjectTargetNotStructPtr, targetType)
+	}
+
+	var cfg injectConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if err := c.checkTags(targetType.String(), targetType); err != nil {
+		return err
+	}
+	if cfg.requireTags && !hasInjectTags(targetType.Elem()) {
+		return fmt.Errorf("%w: %v", ErrNoInjectionTags, targetType)
+	}
+
+	// Ensure the container is built before resolving.
+	if err := c.ensureBuilt(); err != nil {
+		return err
+	}
+
+	// A write lock is needed because the LiteralProvider fallback stores synthetic beans.
+	c.regMu.Lock()
+	defer c.regMu.Unlock()
+
+	_, collectors := discoverGroups(targetType)
+	receiver := bean{
+		id:          targetType.String(),
+		beanType:    targetType,
+		instance:    target,
+		groupFields: collectors,
+	}
+
+	for _, fd := range dependencyFields(receiver.id, targetType) {
+		depBean, ok := c.registeredBeans[fd.id]
+		if !ok {
+			var err error
+			if depBean, ok, err = c.literalBean(LiteralRequest{ID: fd.id, TargetType: fd.typ, ReceiverBeanID: receiver.id, ReceiverField: fd.field}); err != nil {
+				return fmt.Errorf("inject: %w", err)
+			}
+			if !ok {
+				return fmt.Errorf("inject: dependency bean '%s' for field '%s' of %v not found", fd.id, fd.field, targetType)
+			}
+		}
+		if depBean.deferred && !depBean.initialized {
+			err := c.materializeLocked(fd.id, nil)
+			// Deferred beans built along the way are served without locking from now on, even on failure
+			c.republishResolved()
+			if err != nil {
+				return fmt.Errorf("inject: %w", err)
+			}
+			depBean = c.registeredBeans[fd.id]
+		}
+		if depBean.instance == nil && !depBean.prototype {
+			return fmt.Errorf("inject: dependency bean '%s' for field '%s' of %v not instantiated", fd.id, fd.field, targetType)
+		}
+		only := emptyString
+		if depBean.ephemeral {
+			only = fd.field
+		}
+		if err := c.injectIntoField(receiver, depBean, nil, only); err != nil {
+			return fmt.Errorf("inject: %w", err)
+		}
+	}
+
+	if err := c.injectGroups(receiver); err != nil {
+		return fmt.Errorf("inject: %w", err)
+	}
+	if err := c.injectValues(receiver); err != nil {
+		return fmt.Errorf("inject: %w", err)
+	}
+	return nil
+}
+
+// hasInjectTags reports whether any field of the struct type carries a `di.inject` tag other than "-".
+func hasInjectTags(structType reflect.Type) bool {
+	for i := 0; i < structType.NumField(); i++ {
+		if tagVal, _, ok := injectTag(structType.Field(i)); ok && tagVal != excluded {
+			return true
+		}
+	}
+	return false
+}
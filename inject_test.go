@@ -0,0 +1,109 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type externalHandler struct {
+	Service *Service `di.inject:"ServiceBean"`
+	Logger  *Logger  `di.inject:"ServiceBeanLogger"`
+	Dir     string   `di.inject:"WorkingDir"`
+	Name    string   `di.value:"handler"`
+}
+
+func newServiceContainer(t *testing.T) *Container {
+	t.Helper()
+	c := New()
+	require.NoError(t, c.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	require.NoError(t, c.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, c.RegisterInstance("WorkingDir", "/srv"))
+	return c
+}
+
+func TestInject_SharesSingletonsWithRegisteredBeans(t *testing.T) {
+	c := newServiceContainer(t)
+
+	h := &externalHandler{}
+	require.NoError(t, c.Inject(h))
+
+	svc, err := ResolveAs[*Service](c, "ServiceBean")
+	require.NoError(t, err)
+	require.Same(t, svc, h.Service)
+	require.Same(t, svc.Logger, h.Logger)
+	require.Equal(t, "/srv", h.Dir)
+	require.Equal(t, "handler", h.Name)
+
+	// The target is not stored in the container.
+	require.NotContains(t, c.registeredBeans, reflect.TypeOf(h).String())
+}
+
+func TestInject_LiteralProviderFallback(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(func(id string, typ reflect.Type) (any, bool, error) {
+		if id == "workingdir" {
+			return "/literal", true, nil
+		}
+		return nil, false, nil
+	})
+
+	c := New()
+	cfg := &Config{}
+	require.NoError(t, c.Inject(cfg))
+	require.Equal(t, "/literal", cfg.WorkingDir)
+}
+
+func TestInject_MissingDependency(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("ServiceBeanLogger", &Logger{}))
+
+	err := c.Inject(&externalHandler{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dependency bean 'servicebean' for field 'Service'")
+}
+
+func TestInject_RejectsNonStructPointer(t *testing.T) {
+	c := New()
+	require.ErrorIs(t, c.Inject(nil), ErrBeanParamIsNil)
+	require.ErrorIs(t, c.Inject(externalHandler{}), ErrInjectTargetNotStructPtr)
+	s := "x"
+	require.ErrorIs(t, c.Inject(&s), ErrInjectTargetNotStructPtr)
+}
+
+func TestInjectStruct_Success(t *testing.T) {
+	c := newServiceContainer(t)
+
+	h := &externalHandler{}
+	require.NoError(t, InjectStruct(c, h))
+	require.NotNil(t, h.Service)
+	require.Equal(t, "/srv", h.Dir)
+}
+
+func TestInjectStruct_MissingDependency(t *testing.T) {
+	c := New()
+
+	err := InjectStruct(c, &Config{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dependency bean 'workingdir' for field 'WorkingDir'")
+}
+
+type untaggedFixture struct {
+	Logger *Logger
+}
+
+func TestInjectStruct_NoTags(t *testing.T) {
+	c := newServiceContainer(t)
+
+	// Without the option an untagged target is simply left alone.
+	f := &untaggedFixture{}
+	require.NoError(t, InjectStruct(c, f))
+	require.Nil(t, f.Logger)
+
+	err := InjectStruct(c, f, RequireTags())
+	require.ErrorIs(t, err, ErrNoInjectionTags)
+
+	require.ErrorIs(t, InjectStruct[untaggedFixture](c, nil), ErrBeanParamIsNil)
+}
@@ -1,163 +1,356 @@
 package iocdi
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 )
 
-// checkForDependency analyzes the provided beanType for any tagged dependencies and registers as a required dependency.
-// It processes exported fields ONLY with the `di.inject` tag, identifying dependencies to be resolved later.
-// Handles pointer-to-struct fields and string fields, storing them in the requiredDependency map.
-// Non-struct types or unexported fields are ignored during this process.
-// Returns true if any dependencies were found, false otherwise.
-func (c *Container) checkForDependency(beanType reflect.Type) (bool, []string) {
+// fieldDependency describes a tagged field of a receiver and the dependency it requires.
+type fieldDependency struct {
+	field     string       // name of the receiving struct field
+	id        string       // lower-cased dependency bean ID taken from the `di.inject` tag
+	typ       reflect.Type // required type; the struct type for pointer-to-struct fields, the field type otherwise
+	prototype bool         // the field receives a fresh instance rather than the shared singleton
+}
+
+// dependencyFields analyzes the provided beanType for tagged dependencies without recording them.
+// It processes exported fields ONLY with the `di.inject` tag, returning one entry per injectable field in field order.
+// Non-struct types, unexported fields and unsupported field kinds are ignored. Tags with `scope=bean` are qualified
+// with receiverID.
+func dependencyFields(receiverID string, beanType reflect.Type) []fieldDependency {
 	// Check if the bean is a pointer to a struct or a struct
 	if beanType.Kind() == reflect.Ptr {
 		if beanType.Elem().Kind() != reflect.Struct {
-			return false, nil
+			return nil
 		}
+		beanType = beanType.Elem()
 	} else if beanType.Kind() != reflect.Struct {
-		return false, nil
+		return nil
 	}
 
-	dependencyIDs := make([]string, 0)
-	hasDependencies := false
-	beanTypeElement := beanType.Elem()
+	fields := make([]fieldDependency, 0)
 	// Iterate through the fields of the struct and check for the `di.inject` tag
-	// if seen, add it to the required list
-	for i := 0; i < beanTypeElement.NumField(); i++ {
-		field := beanTypeElement.Field(i)
-		tagName, exists := field.Tag.Lookup(string(inject))
-		tagName = strings.ToLower(tagName) // Enfore lower-case tag names
+	for i := 0; i < beanType.NumField(); i++ {
+		field := beanType.Field(i)
+		tagName, opts, exists := injectTag(field) // Enforces lower-case tag names
 		if !exists {
 			continue
 		}
 
 		// We only support exported fields, otherwise it requires the use of unsafe pointers.
-		if field.IsExported() {
-			// Only handle pointer-to-struct fields for injection demo
-			if field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
-				c.requiredDependency[tagName] = field.Type.Elem()
-				hasDependencies = true
-				dependencyIDs = append(dependencyIDs, tagName)
-			}
+		// A tag of "-" explicitly excludes the field.
+		if !field.IsExported() || tagName == excluded {
+			continue
+		}
+		tagName = dependencyID(receiverID, tagName, opts)
 
-			// string-typed fields
-			if field.Type.Kind() == reflect.String {
-				c.requiredDependency[tagName] = field.Type
-				hasDependencies = true
-				dependencyIDs = append(dependencyIDs, tagName)
-			}
+		switch {
+		case field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct:
+			// pointer-to-struct fields are recorded by their struct type
+			fields = append(fields, fieldDependency{field: field.Name, id: tagName, typ: field.Type.Elem(), prototype: opts.has(injectPrototype) || opts.has(injectCopy)})
+		case isBasicKind(field.Type.Kind()), field.Type.Kind() == reflect.Interface:
+			// string and other basic scalar fields (bool, numbers) and interface-typed fields
+			fields = append(fields, fieldDependency{field: field.Name, id: tagName, typ: field.Type, prototype: opts.has(injectPrototype) || opts.has(injectCopy)})
+		case isLiteralCollection(field.Type) && !strings.HasPrefix(tagName, groupPrefix):
+			// slices of basic kinds and map[string]string fields, typically supplied by a LiteralProvider;
+			// group collectors are handled by discoverGroups
+			fields = append(fields, fieldDependency{field: field.Name, id: tagName, typ: field.Type})
+		}
+	}
+
+	return fields
+}
 
-			// interface-typed fields
-			if field.Type.Kind() == reflect.Interface {
-				c.requiredDependency[tagName] = field.Type
-				hasDependencies = true
-				dependencyIDs = append(dependencyIDs, tagName)
+// checkForDependency analyzes the provided beanType for any tagged dependencies and registers as a required dependency.
+// Handles pointer-to-struct, interface, string and other basic scalar fields, storing them in the requiredDependency map.
+// Returns true if any dependencies were found, false otherwise, together with the dependency IDs in field order.
+func (c *Container) checkForDependency(beanType reflect.Type) (bool, []string) {
+	return c.requireDependencies(dependencyFields(emptyString, beanType))
+}
+
+// requireDependencies records the required type of every field dependency in the requiredDependency map.
+// Returns true if there are any dependencies, together with the dependency IDs in field order.
+func (c *Container) requireDependencies(fields []fieldDependency) (bool, []string) {
+	dependencyIDs := make([]string, 0, len(fields))
+	for _, fd := range fields {
+		c.requiredDependency[fd.id] = fd.typ
+		dependencyIDs = append(dependencyIDs, fd.id)
+	}
+	return len(dependencyIDs) > 0, dependencyIDs
+}
+
+// derivedRequirements returns the required type of every dependency of the registered beans, derived afresh
+// from their fields rather than from what earlier registrations recorded. When receivers require a dependency
+// both as an interface and as a concrete type, the concrete type is kept, since a bean satisfying it also
+// satisfies the interface.
+// Callers must hold regMu.
+func (c *Container) derivedRequirements() map[string]reflect.Type {
+	required := make(map[string]reflect.Type, len(c.requiredDependency))
+	for _, id := range sortedKeys(c.registeredBeans) {
+		for _, fd := range c.registeredBeans[id].fields {
+			if t, ok := required[fd.id]; !ok || (t.Kind() == reflect.Interface && fd.typ.Kind() != reflect.Interface) {
+				required[fd.id] = fd.typ
 			}
 		}
 	}
+	return required
+}
 
-	return hasDependencies, dependencyIDs
+// dependencyIDs returns the distinct dependency IDs of the fields, in field order. Several fields may share
+// an ID; the edge is listed once since injecting a dependency sets every field that names it.
+func dependencyIDs(fields []fieldDependency) []string {
+	ids := make([]string, 0, len(fields))
+	for _, fd := range fields {
+		ids = appendUnique(ids, fd.id)
+	}
+	return ids
 }
 
-func (c *Container) injectDependencies() error {
-	//	fmt.Println("Injecting dependencies...")
+// cycleThrough reports a dependency cycle that passes through the given bean, following only the
+// tagged dependency edges between beans registered so far. Dependencies that are not (yet) registered
+// are ignored because the graph is incomplete during registration.
+// Callers must hold regMu.
+func (c *Container) cycleThrough(start string) error {
+	visited := make(map[string]bool)
+	path := make([]string, 0, 8)
 
-	// DFS-based cycle detection and ordered injection
-	visited := make(map[string]bool) // fully processed
-	onPath := make(map[string]bool)  // nodes in the current recursion stack
-	path := make([]string, 0, 16)    // ordered path for clear errors
+	var visit func(id string) error
+	visit = func(id string) error {
+		bn, ok := c.registeredBeans[id]
+		if !ok || visited[id] {
+			return nil
+		}
+		visited[id] = true
+		path = append(path, id)
+		for _, dep := range bn.dependencies {
+			if dep == start {
+				return c.cycleError(path, start)
+			}
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		return nil
+	}
+	return visit(start)
+}
 
-	var joinPath = func(p []string, last string) string {
-		s := emptyString
-		for i, v := range p {
-			if i > 0 {
-				s += pathSep
+// literalBean asks the LiteralProvider for a missing dependency of a basic kind (string, bool, number or
+// time.Duration) and, when found, stores the value as a synthetic bean. A value wrapped in EphemeralLiteral or
+// returned by a LiteralProviderV2 yields an ephemeral bean that is not stored. The boolean result reports
+// whether a bean was synthesized.
+// Callers must hold regMu.
+func (c *Container) literalBean(req LiteralRequest) (bean, bool, error) {
+	depBeanID, expectedType := req.ID, req.TargetType
+	if expectedType == nil || !isLiteralKind(expectedType) {
+		return bean{}, false, nil
+	}
+	val, found, ephemeral, err := c.lookupLiteral(req)
+	if l := c.log(); l != nil {
+		l.Debug("iocdi: literal lookup", "dependency", depBeanID, "type", expectedType, "receiver", req.ReceiverBeanID,
+			"field", req.ReceiverField, "found", found)
+	}
+	if err != nil {
+		return bean{}, false, fmt.Errorf("literal provider error for '%s': %w", depBeanID, err)
+	}
+	if !found {
+		return bean{}, false, nil
+	}
+	if eph, ok := val.(EphemeralLiteral); ok {
+		val, ephemeral = eph.Value, true
+	}
+	if val == nil {
+		return bean{}, false, fmt.Errorf("literal provider returned nil for '%s'", depBeanID)
+	}
+	if isLiteralCollection(expectedType) {
+		if val, err = c.collectionLiteral(depBeanID, val, expectedType); err != nil {
+			return bean{}, false, fmt.Errorf("literal provider value for '%s': %w", depBeanID, err)
+		}
+	}
+	if val, err = c.expandLiteral(depBeanID, val); err != nil {
+		return bean{}, false, err
+	}
+	// Strings keep accepting any value, left to a Converter; other kinds must fit the field
+	valType := reflect.TypeOf(val)
+	if expectedType.Kind() != reflect.String && valType != expectedType && !namedConvertible(valType, expectedType) && !c.hasConverter(valType, expectedType) {
+		return bean{}, false, fmt.Errorf("literal provider returned %v for '%s', expected %v", valType, depBeanID, expectedType)
+	}
+	if err := c.validateLiteral(depBeanID, val); err != nil {
+		return bean{}, false, err
+	}
+
+	// Synthesize a bean from the literal so downstream code can proceed uniformly.
+	// The value's own type is kept so a registered Converter can bridge any gap to the field type.
+	depBean := bean{
+		id:        depBeanID,
+		instance:  val,
+		beanType:  reflect.TypeOf(val),
+		literal:   true,
+		ephemeral: ephemeral,
+		request:   req,
+		// keep other fields default (no dependencies, etc.)
+	}
+	if !ephemeral {
+		c.registeredBeans[depBeanID] = depBean
+	}
+	return depBean, true, nil
+}
+
+// literalRequest describes the dependency for a LiteralProvider lookup on behalf of the receiver bean, naming
+// the receiver's first field that takes it.
+// Callers must hold regMu.
+func (c *Container) literalRequest(receiverID, depID string) LiteralRequest {
+	return LiteralRequest{
+		ID:             depID,
+		TargetType:     c.requiredDependency[depID],
+		ReceiverBeanID: receiverID,
+		ReceiverField:  c.edgeField(receiverID, depID),
+	}
+}
+
+// injectEphemeral injects an ephemeral literal into each of the receiver's fields naming it, asking the
+// LiteralProvider again for every field after the first, which receives first.
+// Callers must hold regMu.
+func (c *Container) injectEphemeral(receiver bean, first bean, chain []string) error {
+	n := 0
+	for _, fd := range receiver.fields {
+		if fd.id != first.id {
+			continue
+		}
+		dep := first
+		if n > 0 {
+			var ok bool
+			var err error
+			if dep, ok, err = c.literalBean(LiteralRequest{ID: fd.id, TargetType: fd.typ, ReceiverBeanID: receiver.id, ReceiverField: fd.field}); err != nil {
+				return err
+			}
+			if !ok {
+				return &MissingDependencyError{Receiver: receiver.id, Dependency: fd.id, Field: fd.field}
 			}
-			s += v
 		}
-		if last != emptyString {
-			if len(s) > 0 {
-				s += pathSep // " -> "
+		if err := c.injectIntoField(receiver, dep, chain, fd.field); err != nil {
+			return err
+		}
+		n++
+	}
+	return nil
+}
+
+// collectionLiteral adapts a provider value for a slice or map field: a value convertible to the field type is
+// converted to it, and with WithCommaSeparatedLiterals a string is split on commas into a slice whose elements
+// are converted to the element type. Any other value is returned unchanged for literalBean to reject.
+// Callers must hold regMu.
+func (c *Container) collectionLiteral(id string, val any, expectedType reflect.Type) (any, error) {
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == expectedType.Kind() && rv.Type().ConvertibleTo(expectedType) {
+		return rv.Convert(expectedType).Interface(), nil
+	}
+	s, ok := val.(string)
+	if !ok || !c.splitLiterals || expectedType.Kind() != reflect.Slice {
+		return val, nil
+	}
+
+	parts := strings.Split(s, ",")
+	if strings.TrimSpace(s) == emptyString {
+		parts = nil
+	}
+	out := reflect.MakeSlice(expectedType, 0, len(parts))
+	for _, part := range parts {
+		elem, err := convertString(strings.TrimSpace(part), expectedType.Elem())
+		if err != nil {
+			if c.secrets[id] {
+				// The parse error quotes the input, so it is dropped for secrets
+				return nil, fmt.Errorf("cannot convert %s to %v", redacted, expectedType.Elem())
 			}
-			s += last
+			return nil, fmt.Errorf("cannot convert %q to %v: %w", part, expectedType.Elem(), err)
 		}
-		return s
+		out = reflect.Append(out, elem)
 	}
+	return out.Interface(), nil
+}
+
+func (c *Container) injectDependencies() error {
+	// DFS-based cycle detection and ordered injection
+	visited := make(map[string]bool) // fully processed
+	onPath := make(map[string]bool)  // nodes in the current recursion stack
+	path := make([]string, 0, 16)    // ordered path for clear errors
 
 	var visit func(id string) error
 	visit = func(id string) error {
 		// Unknown bean (should not happen here; callers ensure registration)
 		bn, ok := c.registeredBeans[id]
 		if !ok {
-			return fmt.Errorf("injectDependencies: receiver bean '%s' not found", id)
+			return fmt.Errorf("receiver bean '%s' not found", id)
 		}
 
 		// Cycle checks
 		if onPath[id] {
 			// Produce a cycle path ending back at id
-			return fmt.Errorf("dependency cycle detected: %s", joinPath(path, id))
+			return c.cycleError(path, id)
 		}
 		if visited[id] {
 			return nil
 		}
+		// Beans initialized by an earlier build are already wired; deferred lazy beans are wired on first resolution;
+		// prototype beans are wired per instance
+		if bn.initialized || bn.deferred || bn.prototype {
+			visited[id] = true
+			return nil
+		}
 
 		// Enter node
 		onPath[id] = true
 		path = append(path, id)
 
-		if bn.hasDependencies {
-			//			fmt.Println("Injecting dependencies for bean:", bn.id, " hasDependencies:", bn.hasDependencies, "list:", bn.dependencies)
+		var deps []bean
+		if bn.hasDependencies || len(bn.autowired) > 0 || len(bn.groupFields) > 0 {
+			if l := c.log(); l != nil {
+				l.Debug("iocdi: wiring bean", "bean", bn.id, "dependencies", c.edges(bn))
+			}
 
 			if bn.instance == nil {
-				return fmt.Errorf("injectDependencies: receiver bean '%s' is nil", bn.id)
+				return fmt.Errorf("receiver bean '%s' is nil", bn.id)
 			}
 
-			for _, depBeanID := range bn.dependencies {
+			for _, depBeanID := range c.edges(bn) {
 				depBean, ok := c.registeredBeans[depBeanID]
 				if !ok {
 					// Attempt to resolve via literalProvider if the expected type is known and is string
-					if expectedType, okType := c.requiredDependency[depBeanID]; okType && expectedType.Kind() == reflect.String {
-						if lp := loadLiteralProvider(); lp != nil {
-							if val, found, err := lp(depBeanID, expectedType); err != nil {
-								return fmt.Errorf("injectDependencies: literal provider error for '%s': %w", depBeanID, err)
-							} else if found {
-								// Synthesize a bean from the literal so downstream code can proceed uniformly
-								depBean = bean{
-									id:       depBeanID,
-									instance: val,
-									beanType: expectedType,
-									// keep other fields default (no dependencies, etc.)
-								}
-								c.registeredBeans[depBeanID] = depBean
-								ok = true
-							}
-						}
+					var err error
+					if depBean, ok, err = c.literalBean(c.literalRequest(bn.id, depBeanID)); err != nil {
+						return err
 					}
 					if !ok {
-						return fmt.Errorf("injectDependencies: dependency bean '%s' for '%s' receiver bean not found", depBeanID, bn.id)
+						return &MissingDependencyError{Receiver: bn.id, Dependency: depBeanID, Field: c.edgeField(bn.id, depBeanID)}
 					}
 				}
 
-				// Recurse into dependency first to detect indirect cycles and ensure its deps are injected
-				if err := visit(depBeanID); err != nil {
-					return err
+				// Recurse into dependency first to detect indirect cycles and ensure its deps are injected;
+				// ephemeral literals are not stored and have no dependencies
+				if !depBean.ephemeral {
+					if err := visit(depBeanID); err != nil {
+						return err
+					}
 				}
 
 				// Ensure the instance exists before injection
-				if depBean.instance == nil {
-					return fmt.Errorf("injectDependencies: dependency bean '%s' for '%s' receiver bean not instantiated", depBeanID, bn.id)
-				}
-
-				// Inject depBean into receiver bn; pass current path for direct/self-cycle guard and clarity
-				if err := injectIntoStruct(bn, depBean, append([]string{}, path...)); err != nil {
-					return fmt.Errorf("injectDependencies: %w", err)
+				if depBean.instance == nil && !depBean.prototype {
+					return fmt.Errorf("dependency bean '%s' for '%s' receiver bean not instantiated", depBeanID, bn.id)
 				}
+				deps = append(deps, depBean)
+			}
+		}
 
-				// Reload potentially updated receiver from map (in case injectIntoStruct updated anything)
-				bn = c.registeredBeans[id]
+		// The bean's own fields are set once all its dependencies are wired, so its span and timing exclude theirs
+		if bn.instance != nil && bn.beanType.Kind() == reflect.Ptr && bn.beanType.Elem().Kind() == reflect.Struct {
+			if err := c.wireBean(id, deps, path); err != nil {
+				return err
 			}
 		}
 
@@ -168,12 +361,157 @@ func (c *Container) injectDependencies() error {
 		return nil
 	}
 
-	// Visit all registered beans
-	for id := range c.registeredBeans {
+	// Visit all registered beans in sorted order so failures are reproducible. The path still holds the chain of
+	// receivers that led to a failure; a cycle error already names its own path.
+	for _, id := range sortedKeys(c.registeredBeans) {
 		if err := visit(id); err != nil {
+			var cycle *CycleError
+			if errors.As(err, &cycle) || len(path) == 0 {
+				return err
+			}
+			return &WiringError{Chain: slices.Clone(path), Err: err}
+		}
+	}
+
+	return nil
+}
+
+// wireBean injects the dependencies into the receiver bean with the ID, then applies its group collections,
+// inline constants and environment variables.
+// Callers must hold regMu.
+func (c *Container) wireBean(id string, deps []bean, path []string) (err error) {
+	bn := c.registeredBeans[id]
+	end := c.startSpan(spanInject, bn.id, bn.beanType)
+	start := time.Now()
+	defer func() {
+		c.recorder.injected(id, start)
+		end(err)
+	}()
+
+	for _, depBean := range deps {
+		// Inject depBean into receiver bn; pass current path for direct/self-cycle guard and clarity
+		inject := c.injectIntoStruct
+		if depBean.ephemeral {
+			inject = c.injectEphemeral
+		}
+		if err := inject(bn, depBean, append([]string{}, path...)); err != nil {
+			c.emit(Event{Kind: EventInjected, BeanID: bn.id, DependencyID: depBean.id, Err: err})
 			return err
 		}
+
+		// Reload potentially updated receiver from map (in case injectIntoStruct updated anything)
+		bn = c.registeredBeans[id]
 	}
 
+	// Group collections, inline constants and environment variables are applied after the tagged dependencies
+	if err := c.injectGroups(bn); err != nil {
+		return err
+	}
+	if err := c.injectValues(bn); err != nil {
+		return err
+	}
 	return nil
 }
+
+// isBasicKind reports whether k is a string, bool or numeric kind.
+func isBasicKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// isLiteralCollection reports whether t is a slice of a basic kind or a map from string to string, the
+// collection types a LiteralProvider may supply.
+func isLiteralCollection(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice:
+		return isBasicKind(t.Elem().Kind())
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && t.Elem().Kind() == reflect.String
+	}
+	return false
+}
+
+// isLiteralKind reports whether a dependency of type t may be supplied by a LiteralProvider.
+func isLiteralKind(t reflect.Type) bool {
+	return isBasicKind(t.Kind()) || isLiteralCollection(t)
+}
+
+// edgeField returns the name of the receiver's field that creates the edge to depID, or an empty string
+// if the edge cannot be attributed to a field (e.g., beans constructed without field metadata).
+func (c *Container) edgeField(receiverID, depID string) string {
+	bn, ok := c.registeredBeans[receiverID]
+	if !ok {
+		return emptyString
+	}
+	for _, fd := range bn.fields {
+		if fd.id == depID {
+			return fd.field
+		}
+	}
+	if field, ok := bn.autowiredField(depID); ok {
+		return field
+	}
+	for _, gf := range bn.groupFields {
+		for _, member := range c.groupMembers(gf.group) {
+			if member == depID {
+				return gf.field
+			}
+		}
+	}
+	return emptyString
+}
+
+// cycleError builds the error for a DFS path that reached last while last was still on the path.
+// Only the cycle itself is reported, rotated so the lexicographically smallest bean ID comes first,
+// which makes the message independent of where the traversal entered the cycle.
+func (c *Container) cycleError(path []string, last string) error {
+	cycle := canonicalCycle(path, last)
+	cycle = append(cycle, cycle[0])
+	fields := make([]string, len(cycle)-1)
+	for i := range fields {
+		fields[i] = c.edgeField(cycle[i], cycle[i+1])
+	}
+	return &CycleError{Path: cycle, Fields: fields}
+}
+
+// canonicalCycle extracts the cycle ending at last from the DFS path and rotates it to start at its smallest ID.
+func canonicalCycle(path []string, last string) []string {
+	start := 0
+	for i, id := range path {
+		if id == last {
+			start = i
+			break
+		}
+	}
+	cycle := path[start:]
+	if len(cycle) == 0 {
+		return []string{last}
+	}
+
+	smallest := 0
+	for i, id := range cycle {
+		if id < cycle[smallest] {
+			smallest = i
+		}
+	}
+	rotated := make([]string, 0, len(cycle))
+	rotated = append(rotated, cycle[smallest:]...)
+	rotated = append(rotated, cycle[:smallest]...)
+	return rotated
+}
+
+// sortedKeys returns the keys of a string-keyed map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
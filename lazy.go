@@ -0,0 +1,181 @@
+package iocdi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Lazy defers creating, injecting and initializing the bean until it is first resolved, instead of during Build.
+// Build still checks the bean's dependencies. A lazy bean that an eager bean depends on, directly or
+// transitively, is needed to complete Build and is therefore built eagerly.
+func Lazy() RegisterOption {
+	return func(b *bean) {
+		b.lazy = true
+	}
+}
+
+// markDeferred decides which beans Build leaves for their first resolution: lazy beans no eager bean depends on
+// and, when roots are given, every bean outside the roots' transitive dependency closure.
+// Callers must hold regMu.
+func (c *Container) markDeferred(roots []string) {
+	needed := make(map[string]bool)
+	var visit func(id string)
+	visit = func(id string) {
+		if needed[id] {
+			return
+		}
+		needed[id] = true
+		if bn, ok := c.registeredBeans[id]; ok {
+			for _, dep := range c.edges(bn) {
+				visit(dep)
+			}
+		}
+	}
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		if bn.initialized || (roots == nil && !bn.lazy) {
+			visit(id)
+		}
+	}
+	for _, id := range roots {
+		visit(id)
+	}
+
+	for id, bn := range c.registeredBeans {
+		bn.deferred = !needed[id] && (bn.lazy || roots != nil)
+		c.registeredBeans[id] = bn
+	}
+}
+
+// requiredByBuilt returns the sorted IDs required by the beans a build will wire, i.e. those not deferred.
+// Callers must hold regMu.
+func (c *Container) requiredByBuilt() []string {
+	ids := make([]string, 0)
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		if bn.deferred {
+			continue
+		}
+		for _, fd := range bn.fields {
+			ids = appendUnique(ids, fd.id)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// materialize creates, injects, validates and initializes a deferred bean on first resolution. Deferred
+// dependencies are materialized first, so the bean's dependencies are always initialized before it. Concurrent
+// first resolutions are serialized by the write lock, so Initialize runs exactly once; a failed attempt leaves
+// the bean unbuilt and is retried by the next resolution.
+func (c *Container) materialize(id string) error {
+	c.regMu.Lock()
+	defer c.regMu.Unlock()
+	// Materializing also builds deferred dependencies, so the whole map is republished even on failure
+	defer c.republishResolved()
+	return c.materializeLocked(id, nil)
+}
+
+// materializeLocked is materialize for callers holding regMu. The path lists the deferred beans being
+// materialized, for cycle detection.
+func (c *Container) materializeLocked(id string, path []string) error {
+	bn, ok := c.registeredBeans[id]
+	if !ok || !bn.deferred || bn.initialized || bn.prototype {
+		return nil
+	}
+	if slices.Contains(path, id) {
+		return c.cycleError(path, id)
+	}
+	path = append(path, id)
+
+	label := "bean"
+	if bn.lazy {
+		label = "lazy bean"
+	}
+	if err := c.supplyMissing(dependencyIDs(bn.fields), true); err != nil {
+		return fmt.Errorf("%s '%s': %w", label, id, err)
+	}
+
+	for _, depID := range c.edges(bn) {
+		if err := c.materializeLocked(depID, path); err != nil {
+			return err
+		}
+	}
+
+	if err := c.checkRequired(dependencyIDs(bn.fields)); err != nil {
+		return fmt.Errorf("%s '%s': %w", label, id, err)
+	}
+	if bn.instance == nil {
+		instance, err := createInstance(id, bn.beanType)
+		if err != nil {
+			return fmt.Errorf("%s '%s': %w", label, id, err)
+		}
+		bn.instance = instance
+		bn.singleton = true
+		c.emit(Event{Kind: EventInstantiated, BeanID: id})
+	}
+	// The bean is only stored once fully built, so a failed attempt leaves no partial instance behind
+	if err := c.wireLazy(bn); err != nil {
+		return fmt.Errorf("%s '%s': %w", label, id, err)
+	}
+
+	bn.initialized = true
+	c.registeredBeans[id] = bn
+	c.initOrder = append(c.initOrder, id)
+	return nil
+}
+
+// wireLazy injects, validates and initializes a deferred bean whose dependencies are all built.
+// Callers must hold regMu.
+func (c *Container) wireLazy(bn bean) error {
+	for _, depID := range c.edges(bn) {
+		depBean, ok := c.registeredBeans[depID]
+		if !ok {
+			var err error
+			if depBean, ok, err = c.literalBean(c.literalRequest(bn.id, depID)); err != nil {
+				return err
+			}
+			if !ok {
+				return &MissingDependencyError{Receiver: bn.id, Dependency: depID, Field: c.edgeField(bn.id, depID)}
+			}
+		}
+		if depBean.instance == nil && !depBean.prototype {
+			return fmt.Errorf("dependency bean '%s' not instantiated", depID)
+		}
+		if depBean.ephemeral {
+			if err := c.injectEphemeral(bn, depBean, nil); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.injectIntoStruct(bn, depBean, nil); err != nil {
+			return err
+		}
+	}
+	if err := c.injectGroups(bn); err != nil {
+		return err
+	}
+	if err := c.injectValues(bn); err != nil {
+		return err
+	}
+
+	if v, ok := bn.instance.(Validator); ok {
+		if err := v.ValidateWiring(); err != nil {
+			return fmt.Errorf("validation failed: %w", err)
+		}
+	}
+	if isInitializer(bn.instance) {
+		start := time.Now()
+		endInit := c.startSpan(spanInit, bn.id, bn.beanType)
+		err := initializeWithin(context.Background(), bn.id, bn.instance, c.initTimeoutFor(bn))
+		endInit(err)
+		c.observeInit(bn.id, start)
+		c.emit(Event{Kind: EventInitialized, BeanID: bn.id, Err: err})
+		if err != nil {
+			return &InitError{BeanID: bn.id, Err: err}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	lazyMu  sync.Mutex
+	lazyLog []string
+)
+
+func recordLazy(name string) {
+	lazyMu.Lock()
+	defer lazyMu.Unlock()
+	lazyLog = append(lazyLog, name)
+}
+
+func lazyEntries() []string {
+	lazyMu.Lock()
+	defer lazyMu.Unlock()
+	return append([]string(nil), lazyLog...)
+}
+
+type lazyPool struct{}
+
+func (l *lazyPool) Initialize() error {
+	recordLazy("pool")
+	return nil
+}
+
+type lazyReport struct {
+	Pool *lazyPool `di.inject:"pool"`
+}
+
+func (l *lazyReport) Initialize() error {
+	recordLazy("report")
+	return nil
+}
+
+type eagerClock struct{}
+
+func (e *eagerClock) Initialize() error {
+	recordLazy("clock")
+	return nil
+}
+
+type eagerUsesPool struct {
+	Pool *lazyPool `di.inject:"pool"`
+}
+
+type lazyBroken struct{}
+
+func (l *lazyBroken) Initialize() error { return errors.New("no credentials") }
+
+func TestLazy_InitializesOnFirstResolution(t *testing.T) {
+	lazyLog = nil
+	c := New()
+	require.NoError(t, c.Register("report", reflect.TypeOf((*lazyReport)(nil)), Lazy()))
+	require.NoError(t, c.Register("pool", reflect.TypeOf((*lazyPool)(nil)), Lazy()))
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*eagerClock)(nil))))
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"clock"}, lazyEntries())
+	require.Nil(t, c.registeredBeans["report"].instance)
+
+	var wg sync.WaitGroup
+	results := make([]*lazyReport, 16)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, err := ResolveAs[*lazyReport](c, "report")
+			require.NoError(t, err)
+			results[i] = r
+		}()
+	}
+	wg.Wait()
+
+	// Exactly one Initialize each, dependencies first
+	require.Equal(t, []string{"clock", "pool", "report"}, lazyEntries())
+	for _, r := range results {
+		require.Same(t, results[0], r)
+	}
+	require.NotNil(t, results[0].Pool)
+
+	// Lazily built beans join the initialization order, so Close destroys them too
+	require.Equal(t, []string{"clock", "pool", "report"}, c.initOrder)
+}
+
+func TestLazy_NeededByEagerBeanIsBuiltEagerly(t *testing.T) {
+	lazyLog = nil
+	c := New()
+	require.NoError(t, c.Register("pool", reflect.TypeOf((*lazyPool)(nil)), Lazy()))
+	require.NoError(t, c.Register("user", reflect.TypeOf((*eagerUsesPool)(nil))))
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"pool"}, lazyEntries())
+}
+
+func TestLazy_InitializerErrorSurfacesFromResolve(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("broken", reflect.TypeOf((*lazyBroken)(nil)), Lazy()))
+	require.NoError(t, c.Build())
+
+	_, err := c.ResolveSafe("broken")
+	require.EqualError(t, err, "lazy bean 'broken': initializer for bean 'broken' failed: no credentials")
+	require.Nil(t, c.registeredBeans["broken"].instance)
+}
@@ -0,0 +1,167 @@
+package iocdi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Start builds the container if needed and then calls Start on every bean implementing Startable, in dependency
+// order. Beans already started by a previous call are skipped. The context is checked before each bean, so
+// cancellation aborts the remaining starts. If a Start fails or the context is cancelled, the beans started so
+// far are stopped in reverse order and the stop errors are joined to the returned error.
+func (c *Container) Start(ctx context.Context) error {
+	if !c.built.Load() {
+		if err := c.Build(); err != nil {
+			return err
+		}
+	}
+
+	c.buildLock.Lock()
+	defer c.buildLock.Unlock()
+
+	if c.closed.Load() {
+		return ErrContainerClosed
+	}
+
+	for _, id := range c.initOrder {
+		if slices.Contains(c.started, id) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return errors.Join(fmt.Errorf("start of bean '%s' aborted: %w", id, err), c.stopStarted(context.WithoutCancel(ctx)))
+		}
+		if s, ok := c.instanceOf(id).(Startable); ok {
+			if err := s.Start(ctx); err != nil {
+				return errors.Join(fmt.Errorf("start for bean '%s' failed: %w", id, err), c.stopStarted(context.WithoutCancel(ctx)))
+			}
+		}
+		c.started = append(c.started, id)
+	}
+	return nil
+}
+
+// Stop calls Stop on every started bean implementing Stoppable, in reverse dependency order. Errors from all
+// beans are joined. The context is checked before each bean; once it is done the remaining beans are left
+// running and reported in the error, so a later Stop can finish the job.
+func (c *Container) Stop(ctx context.Context) error {
+	c.buildLock.Lock()
+	defer c.buildLock.Unlock()
+
+	return c.stopStarted(ctx)
+}
+
+// stopStarted stops the started beans in reverse order, removing each from the started list once stopped.
+// Callers must hold buildLock.
+func (c *Container) stopStarted(ctx context.Context) error {
+	var errs []error
+	for len(c.started) > 0 {
+		id := c.started[len(c.started)-1]
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, fmt.Errorf("stop of bean '%s' aborted: %w", id, err))
+			break
+		}
+		c.started = c.started[:len(c.started)-1]
+		if s, ok := c.instanceOf(id).(Stoppable); ok {
+			if err := s.Stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("stop for bean '%s' failed: %w", id, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// instanceOf returns the instance of the bean under a read lock, or nil if it is not registered.
+// The lock is released before returning so lifecycle callbacks may resolve other beans.
+func (c *Container) instanceOf(id string) any {
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+	return c.registeredBeans[id].instance
+}
+
+// Close destroys the container's beans in reverse initialization order (dependents before dependencies),
+// calling Destroy on every bean implementing Destroyer. Prototypes tracked with TrackPrototypes are destroyed
+// after the singletons, most recent first. All Destroy errors are joined into the returned error.
+// Afterward the container is closed: Build, registration and resolution fail with ErrContainerClosed.
+//
+// Close is idempotent; calls after the first return nil.
+func (c *Container) Close() error {
+	return c.CloseContext(context.Background())
+}
+
+// CloseContext is like Close but bounded by ctx. Beans implementing ContextDestroyer receive the context; a plain
+// Destroy still running when the context is done is abandoned. Once the context is done the remaining beans are
+// skipped, and the returned error lists them alongside any Destroy failures. The reverse dependency order is
+// preserved for the beans that are destroyed.
+func (c *Container) CloseContext(ctx context.Context) error {
+	c.buildLock.Lock()
+	defer c.buildLock.Unlock()
+
+	// Resolution stops serving published instances before any of them is destroyed
+	c.unpublishResolved()
+	if c.closed.Swap(true) {
+		return nil
+	}
+
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	// Singletons go first, dependents before dependencies, then tracked prototypes, most recent first
+	targets := make([]trackedPrototype, 0, len(c.initOrder))
+	for i := len(c.initOrder) - 1; i >= 0; i-- {
+		id := c.initOrder[i]
+		targets = append(targets, trackedPrototype{id: id, instance: c.registeredBeans[id].instance})
+	}
+	prototypes := c.prototypes.drain()
+	slices.Reverse(prototypes)
+	targets = append(targets, prototypes...)
+
+	var errs []error
+	var skipped []string
+	for _, target := range targets {
+		if !isDestroyer(target.instance) {
+			continue
+		}
+		if ctx.Err() != nil {
+			skipped = append(skipped, target.id)
+			continue
+		}
+		err := destroyWithin(ctx, target.id, target.instance)
+		c.emit(Event{Kind: EventDestroyed, BeanID: target.id, Err: err})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("destroyer for bean '%s' failed: %w", target.id, err))
+		}
+	}
+	if len(skipped) > 0 {
+		errs = append(errs, fmt.Errorf("close aborted, beans not destroyed: %s: %w", strings.Join(skipped, ", "), ctx.Err()))
+	}
+	return errors.Join(errs...)
+}
+
+// rollbackInitialized undoes a failed Build by stopping and destroying the given initialized beans in reverse
+// order. Every bean is attempted; the errors are joined. The context's cancellation is ignored so a cancelled
+// Build still releases what it acquired.
+// Callers must hold regMu.
+func (c *Container) rollbackInitialized(ctx context.Context, ids []string) error {
+	ctx = context.WithoutCancel(ctx)
+	var errs []error
+	for i := len(ids) - 1; i >= 0; i-- {
+		id := ids[i]
+		instance := c.registeredBeans[id].instance
+		if s, ok := instance.(Stoppable); ok {
+			if err := callRecovered(id, func() error { return s.Stop(ctx) }); err != nil {
+				errs = append(errs, fmt.Errorf("rollback: stop for bean '%s' failed: %w", id, err))
+			}
+		}
+		if isDestroyer(instance) {
+			err := destroyWithin(ctx, id, instance)
+			c.emit(Event{Kind: EventDestroyed, BeanID: id, Err: err})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("rollback: destroyer for bean '%s' failed: %w", id, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
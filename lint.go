@@ -0,0 +1,77 @@
+package iocdi
+
+import (
+	"slices"
+	"strings"
+)
+
+// UnusedOption configures a single UnusedBeans call.
+type UnusedOption func(*unusedConfig)
+
+type unusedConfig struct {
+	roots []string
+}
+
+// WithRoots names the entry points the application resolves directly, such as "app" or "server". The roots and
+// every bean they depend on, directly or transitively, count as used. IDs that are not registered are ignored.
+func WithRoots(ids ...string) UnusedOption {
+	return func(cfg *unusedConfig) {
+		for _, id := range ids {
+			cfg.roots = append(cfg.roots, strings.ToLower(id))
+		}
+	}
+}
+
+// UnusedBeans returns the sorted IDs of the beans no other bean depends on, to find dead wiring during a
+// refactor. Without WithRoots every entry point is reported too, since nothing in the container depends on it.
+// Edges from autowired fields are only known after Build. The result is advisory; it changes nothing.
+func (c *Container) UnusedBeans(opts ...UnusedOption) []string {
+	var cfg unusedConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	used := make(map[string]bool)
+	for _, root := range cfg.roots {
+		if c.isRegistered(root) {
+			for _, id := range c.reachable(root) {
+				used[id] = true
+			}
+		}
+	}
+
+	for id, dependents := range c.dependentsIndex() {
+		// A bean depending on itself does not make itself used
+		if len(dependents) > 0 && !slices.Equal(dependents, []string{id}) {
+			used[id] = true
+		}
+	}
+	unused := make([]string, 0)
+	for _, id := range sortedKeys(c.registeredBeans) {
+		if !used[id] {
+			unused = append(unused, id)
+		}
+	}
+	return unused
+}
+
+// DanglingRequirements returns the sorted IDs that earlier registrations recorded as required but no registered
+// bean requires anymore, e.g. after a bean was registered again with a type that dropped the field. Build
+// derives the requirements afresh and no longer demands them; the list helps tooling spot leftovers of replaced
+// registrations. It does not build the container.
+func (c *Container) DanglingRequirements() []string {
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	derived := c.derivedRequirements()
+	dangling := make([]string, 0)
+	for _, id := range sortedKeys(c.requiredDependency) {
+		if _, ok := derived[id]; !ok {
+			dangling = append(dangling, id)
+		}
+	}
+	return dangling
+}
@@ -0,0 +1,49 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnusedBeans(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Register("legacyCache", reflect.TypeOf((*Logger)(nil))))
+
+	// Nothing depends on the entry point either
+	require.Equal(t, []string{"legacycache", "servicebean"}, c.UnusedBeans())
+	require.Equal(t, []string{"legacycache"}, c.UnusedBeans(WithRoots("ServiceBean")))
+	require.Empty(t, c.UnusedBeans(WithRoots("ServiceBean", "LegacyCache", "unknown")))
+
+	// The literal synthesized by Build is used by its receiver
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"legacycache"}, c.UnusedBeans(WithRoots("servicebean")))
+}
+
+func TestUnusedBeans_SelfDependency(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("aself", reflect.TypeOf((*selfCycleA)(nil))))
+	require.Equal(t, []string{"aself"}, c.UnusedBeans())
+}
+
+func TestDanglingRequirements_OverwrittenRegistration(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+	require.NoError(t, c.Register("ServiceBean", reflect.TypeOf((*Logger)(nil))))
+
+	require.Equal(t, []string{"servicebeanconfig", "servicebeanlogger"}, c.DanglingRequirements())
+	require.NoError(t, c.Validate())
+	require.NoError(t, c.Build(), "the replaced registration's dependencies are no longer demanded")
+	require.Empty(t, c.DanglingRequirements())
+}
+
+func TestDanglingRequirements_StillRequiredElsewhere(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*sleepyInit)(nil))))
+	require.NoError(t, c.Register("ServiceBean", reflect.TypeOf((*Logger)(nil))))
+
+	require.Equal(t, []string{"servicebeanconfig"}, c.DanglingRequirements())
+	require.EqualError(t, c.Build(), "bean `servicebeanlogger` is required but not registered")
+}
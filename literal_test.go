@@ -0,0 +1,650 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerLiteralProvider_PerContainer(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "/global", true, nil
+	})
+
+	newContainer := func(dir string) *Container {
+		c := New()
+		require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+		c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+			if id == "workingdir" {
+				return dir, true, nil
+			}
+			return nil, false, nil
+		})
+		return c
+	}
+
+	dirs := []string{"/srv/a", "/srv/b"}
+	configs := make([]*Config, len(dirs))
+	var wg sync.WaitGroup
+	for i, dir := range dirs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := newContainer(dir)
+			cfg, err := ResolveAs[*Config](c, "servicebeanconfig")
+			require.NoError(t, err)
+			configs[i] = cfg
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, "/srv/a", configs[0].WorkingDir)
+	require.Equal(t, "/srv/b", configs[1].WorkingDir)
+}
+
+func TestContainerLiteralProvider_GlobalFallback(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "/global", true, nil
+	})
+
+	// No container provider: the global one is used
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	cfg, err := ResolveAs[*Config](c, "servicebeanconfig")
+	require.NoError(t, err)
+	require.Equal(t, "/global", cfg.WorkingDir)
+
+	// The container provider reports not found: the global one is used
+	c = New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return nil, false, nil
+	})
+	cfg, err = ResolveAs[*Config](c, "servicebeanconfig")
+	require.NoError(t, err)
+	require.Equal(t, "/global", cfg.WorkingDir)
+}
+
+func TestContainerLiteralProvider_WithoutGlobal(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(nil)
+
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "/local", true, nil
+	})
+	cfg, err := ResolveAs[*Config](c, "servicebeanconfig")
+	require.NoError(t, err)
+	require.Equal(t, "/local", cfg.WorkingDir)
+
+	// Another container without a provider still fails the precheck
+	other := New()
+	require.NoError(t, other.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	require.EqualError(t, other.Build(), "bean `workingdir` is required but not registered")
+}
+
+// fixedLiterals returns a provider serving the given values and recording its calls in log.
+func fixedLiterals(name string, values map[string]string, log *[]string) LiteralProvider {
+	return func(id string, targetType reflect.Type) (any, bool, error) {
+		*log = append(*log, name+":"+id)
+		v, ok := values[id]
+		if !ok {
+			return nil, false, nil
+		}
+		return v, true, nil
+	}
+}
+
+func TestAddLiteralProvider_FirstMatchWins(t *testing.T) {
+	var calls []string
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.AddLiteralProvider(fixedLiterals("env", map[string]string{}, &calls))
+	c.AddLiteralProvider(fixedLiterals("flags", map[string]string{"workingdir": "/flags"}, &calls))
+	c.AddLiteralProvider(fixedLiterals("file", map[string]string{"workingdir": "/file"}, &calls))
+
+	cfg, err := ResolveAs[*Config](c, "servicebeanconfig")
+	require.NoError(t, err)
+	require.Equal(t, "/flags", cfg.WorkingDir)
+	require.Equal(t, []string{"env:workingdir", "flags:workingdir"}, calls)
+}
+
+func TestAddLiteralProvider_ErrorShortCircuits(t *testing.T) {
+	var calls []string
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.AddLiteralProvider(fixedLiterals("env", map[string]string{}, &calls))
+	c.AddLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return nil, false, errors.New("flags not parsed")
+	})
+	c.AddLiteralProvider(fixedLiterals("file", map[string]string{"workingdir": "/file"}, &calls))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "literal provider error for 'workingdir': literal provider 1: flags not parsed")
+	require.Equal(t, []string{"env:workingdir"}, calls)
+}
+
+func TestAddLiteralProvider_FallThroughToNotFound(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(nil)
+
+	var calls []string
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.AddLiteralProvider(fixedLiterals("env", map[string]string{}, &calls))
+	c.AddLiteralProvider(fixedLiterals("file", map[string]string{}, &calls))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dependency bean 'workingdir' for 'servicebeanconfig' receiver bean not found")
+	require.Equal(t, []string{"env:workingdir", "file:workingdir"}, calls)
+
+	// Once cleared, the precheck no longer defers to the providers
+	c.ClearLiteralProviders()
+	require.EqualError(t, c.Build(), "bean `workingdir` is required but not registered")
+}
+
+type literalServer struct {
+	Port    int           `di.inject:"port"`
+	Debug   bool          `di.inject:"debug"`
+	Timeout time.Duration `di.inject:"timeout"`
+	Dir     string        `di.inject:"workingDir"`
+}
+
+func TestLiteralProvider_BasicKinds(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("server", reflect.TypeOf((*literalServer)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		switch id {
+		case "port":
+			return 8080, true, nil
+		case "debug":
+			return true, true, nil
+		case "timeout":
+			return 3 * time.Second, true, nil
+		case "workingdir":
+			return "/srv", true, nil
+		}
+		return nil, false, nil
+	})
+
+	srv, err := ResolveAs[*literalServer](c, "server")
+	require.NoError(t, err)
+	require.Equal(t, 8080, srv.Port)
+	require.True(t, srv.Debug)
+	require.Equal(t, 3*time.Second, srv.Timeout)
+	require.Equal(t, "/srv", srv.Dir)
+}
+
+func TestLiteralProvider_WrongType(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("server", reflect.TypeOf((*literalServer)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		if id == "port" {
+			return "8080", true, nil
+		}
+		return nil, false, nil
+	})
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "literal provider returned string for 'port', expected int")
+}
+
+type literalKafka struct {
+	Brokers []string          `di.inject:"KafkaBrokers"`
+	Ports   []int             `di.inject:"KafkaPorts"`
+	Labels  map[string]string `di.inject:"KafkaLabels"`
+}
+
+func TestLiteralProvider_Collections(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("kafka", reflect.TypeOf((*literalKafka)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		switch id {
+		case "kafkabrokers":
+			return []string{"kafka-1:9092", "kafka-2:9092"}, true, nil
+		case "kafkaports":
+			return []int{9092, 9093}, true, nil
+		case "kafkalabels":
+			return map[string]string{"env": "prod"}, true, nil
+		}
+		return nil, false, nil
+	})
+
+	k, err := ResolveAs[*literalKafka](c, "kafka")
+	require.NoError(t, err)
+	require.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, k.Brokers)
+	require.Equal(t, []int{9092, 9093}, k.Ports)
+	require.Equal(t, map[string]string{"env": "prod"}, k.Labels)
+
+	// Synthesized collection beans are stored like string literals
+	v, err := c.ResolveSafe("kafkabrokers")
+	require.NoError(t, err)
+	require.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, v)
+}
+
+func TestLiteralProvider_CommaSeparated(t *testing.T) {
+	literals := func(id string, targetType reflect.Type) (any, bool, error) {
+		switch id {
+		case "kafkabrokers":
+			return "kafka-1:9092, kafka-2:9092", true, nil
+		case "kafkaports":
+			return "9092,9093", true, nil
+		case "kafkalabels":
+			return map[string]string{}, true, nil
+		}
+		return nil, false, nil
+	}
+
+	c := New(WithCommaSeparatedLiterals())
+	require.NoError(t, c.Register("kafka", reflect.TypeOf((*literalKafka)(nil))))
+	c.SetLiteralProvider(literals)
+
+	k, err := ResolveAs[*literalKafka](c, "kafka")
+	require.NoError(t, err)
+	require.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, k.Brokers)
+	require.Equal(t, []int{9092, 9093}, k.Ports)
+
+	// Without the option a string does not fit a slice field
+	c = New()
+	require.NoError(t, c.Register("kafka", reflect.TypeOf((*literalKafka)(nil))))
+	c.SetLiteralProvider(literals)
+	err = c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "literal provider returned string for 'kafkabrokers', expected []string")
+}
+
+func TestLiteralProvider_CommaSeparatedBadElement(t *testing.T) {
+	c := New(WithCommaSeparatedLiterals())
+	require.NoError(t, c.Register("kafka", reflect.TypeOf((*literalKafka)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		switch id {
+		case "kafkaports":
+			return "9092,x", true, nil
+		case "kafkabrokers":
+			return []string{}, true, nil
+		case "kafkalabels":
+			return map[string]string{}, true, nil
+		}
+		return nil, false, nil
+	})
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `literal provider value for 'kafkaports': cannot convert "x" to int`)
+}
+
+type literalDatabase struct {
+	Password string `di.inject:"dbPassword"`
+	Replica  string `di.inject:"dbPassword"`
+}
+
+type literalMigrator struct {
+	Password string `di.inject:"dbPassword"`
+}
+
+func TestLiteralProvider_Ephemeral(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("database", reflect.TypeOf((*literalDatabase)(nil))))
+	require.NoError(t, c.Register("migrator", reflect.TypeOf((*literalMigrator)(nil))))
+	calls := 0
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		if id == "dbpassword" {
+			calls++
+			return EphemeralLiteral{Value: "s3cret"}, true, nil
+		}
+		return nil, false, nil
+	})
+
+	db, err := ResolveAs[*literalDatabase](c, "database")
+	require.NoError(t, err)
+	require.Equal(t, "s3cret", db.Password)
+	require.Equal(t, "s3cret", db.Replica)
+	m, err := ResolveAs[*literalMigrator](c, "migrator")
+	require.NoError(t, err)
+	require.Equal(t, "s3cret", m.Password)
+
+	// One provider call per consuming field, and the value is not registered
+	require.Equal(t, 3, calls)
+	_, err = c.ResolveSafe("dbPassword")
+	require.ErrorIs(t, err, ErrBeanNotFound)
+	require.NotContains(t, c.BeanIDs(), "dbpassword")
+
+	// Inject asks again for each field as well
+	var target literalDatabase
+	require.NoError(t, c.Inject(&target))
+	require.Equal(t, "s3cret", target.Replica)
+	require.Equal(t, 5, calls)
+
+	// Rewire fetches ephemeral values again rather than failing on the unregistered ID
+	require.NoError(t, c.Rewire())
+	require.Equal(t, 8, calls)
+}
+
+type literalHTTPClient struct {
+	Timeout string `di.inject:"timeout"`
+}
+
+type literalDBClient struct {
+	Timeout string `di.inject:"timeout"`
+}
+
+func TestLiteralProviderV2_PerReceiver(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("http", reflect.TypeOf((*literalHTTPClient)(nil))))
+	require.NoError(t, c.Register("db", reflect.TypeOf((*literalDBClient)(nil))))
+	var requests []LiteralRequest
+	c.AddLiteralProviderV2(func(req LiteralRequest) (any, bool, error) {
+		requests = append(requests, req)
+		switch req.ReceiverBeanID {
+		case "http":
+			return "5s", true, nil
+		case "db":
+			return "30s", true, nil
+		}
+		return nil, false, nil
+	})
+
+	httpClient, err := ResolveAs[*literalHTTPClient](c, "http")
+	require.NoError(t, err)
+	dbClient, err := ResolveAs[*literalDBClient](c, "db")
+	require.NoError(t, err)
+	require.Equal(t, "5s", httpClient.Timeout)
+	require.Equal(t, "30s", dbClient.Timeout)
+
+	stringType := reflect.TypeOf("")
+	require.ElementsMatch(t, []LiteralRequest{
+		{ID: "timeout", TargetType: stringType, ReceiverBeanID: "http", ReceiverField: "Timeout"},
+		{ID: "timeout", TargetType: stringType, ReceiverBeanID: "db", ReceiverField: "Timeout"},
+	}, requests)
+}
+
+func TestLiteralProvider_V2Adapter(t *testing.T) {
+	p := LiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return id + ":" + targetType.String(), true, nil
+	})
+	v, found, err := p.V2()(LiteralRequest{ID: "workingdir", TargetType: reflect.TypeOf(""), ReceiverBeanID: "config"})
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "workingdir:string", v)
+}
+
+func nonEmptyValidator(id string, value any) error {
+	if s, ok := value.(string); ok && s == "" {
+		return errors.New("must not be empty")
+	}
+	return nil
+}
+
+func TestLiteralValidator(t *testing.T) {
+	newContainer := func(dir string) *Container {
+		c := New()
+		require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+		c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+			return dir, true, nil
+		})
+		c.SetLiteralValidator(nonEmptyValidator)
+		return c
+	}
+
+	c := newContainer("")
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "literal 'workingdir' failed validation: must not be empty")
+	require.False(t, c.Contains("workingdir"))
+
+	cfg, err := ResolveAs[*Config](newContainer("/srv"), "servicebeanconfig")
+	require.NoError(t, err)
+	require.Equal(t, "/srv", cfg.WorkingDir)
+}
+
+func TestLiteralValidator_StringBeans(t *testing.T) {
+	newContainer := func(opts ...Option) *Container {
+		c := New(opts...)
+		require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+		require.NoError(t, c.RegisterInstance("WorkingDir", ""))
+		c.SetLiteralValidator(nonEmptyValidator)
+		return c
+	}
+
+	// Registered string beans are only validated with the option
+	require.NoError(t, newContainer().Build())
+	err := newContainer(WithValidatedStringBeans()).Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "literal 'workingdir' failed validation: must not be empty")
+}
+
+func TestWithLiterals(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "/global", true, nil
+	})
+
+	values := map[string]any{"WorkingDir": "/srv/static"}
+	c := New(WithLiterals(values))
+	// The map was copied at construction
+	values["WorkingDir"] = "/srv/changed"
+	require.NoError(t, c.Register("ServiceBean", reflect.TypeOf((*Service)(nil))))
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	require.NoError(t, c.Register("ServiceBeanLogger", reflect.TypeOf((*Logger)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "/container", true, nil
+	})
+
+	svc, err := ResolveAs[*Service](c, "servicebean")
+	require.NoError(t, err)
+	require.Equal(t, "/srv/static", svc.Config.WorkingDir)
+}
+
+func TestWithLiterals_BasicKinds(t *testing.T) {
+	c := New(WithLiterals(map[string]any{
+		"port":       8080,
+		"debug":      true,
+		"timeout":    3 * time.Second,
+		"workingDir": "/srv",
+	}))
+	require.NoError(t, c.Register("server", reflect.TypeOf((*literalServer)(nil))))
+
+	srv, err := ResolveAs[*literalServer](c, "server")
+	require.NoError(t, err)
+	require.Equal(t, 8080, srv.Port)
+	require.True(t, srv.Debug)
+	require.Equal(t, 3*time.Second, srv.Timeout)
+	require.Equal(t, "/srv", srv.Dir)
+}
+
+type typedLiterals struct {
+	Dir     string        `di.inject:"workingDir"`
+	Timeout time.Duration `di.inject:"timeout"`
+	Port    Port          `di.inject:"port"`
+}
+
+func TestTypedLiteralProvider(t *testing.T) {
+	var asked []string
+	c := New()
+	require.NoError(t, c.Register("typed", reflect.TypeOf((*typedLiterals)(nil))))
+	c.AddLiteralProvider(TypedLiteralProvider(func(id string) (string, bool, error) {
+		asked = append(asked, "string:"+id)
+		return "/srv/" + id, true, nil
+	}))
+	c.AddLiteralProvider(TypedLiteralProvider(func(id string) (time.Duration, bool, error) {
+		asked = append(asked, "duration:"+id)
+		return 5 * time.Second, true, nil
+	}))
+	c.AddLiteralProvider(TypedLiteralProvider(func(id string) (int, bool, error) {
+		asked = append(asked, "int:"+id)
+		return 8080, true, nil
+	}))
+
+	typed, err := ResolveAs[*typedLiterals](c, "typed")
+	require.NoError(t, err)
+	require.Equal(t, "/srv/workingdir", typed.Dir)
+	require.Equal(t, 5*time.Second, typed.Timeout)
+	require.Equal(t, Port(8080), typed.Port)
+	// Each provider was only called for its own type
+	require.ElementsMatch(t, []string{"string:workingdir", "duration:timeout", "int:port"}, asked)
+}
+
+type eagerInitBean struct {
+	initialized *bool
+}
+
+func (b *eagerInitBean) Initialize() error {
+	*b.initialized = true
+	return nil
+}
+
+func TestEagerLiteralCheck(t *testing.T) {
+	calls := make(map[string]int)
+	c := New(WithEagerLiteralCheck())
+	require.NoError(t, c.Register("server", reflect.TypeOf((*literalServer)(nil))))
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		calls[id]++
+		switch id {
+		case "port":
+			return 8080, true, nil
+		case "debug":
+			return true, true, nil
+		case "timeout":
+			return time.Second, true, nil
+		case "workingdir":
+			return "/srv", true, nil
+		}
+		return nil, false, nil
+	})
+
+	require.NoError(t, c.Build())
+	require.Equal(t, map[string]int{"port": 1, "debug": 1, "timeout": 1, "workingdir": 1}, calls)
+}
+
+func TestEagerLiteralCheck_FailsBeforeInstantiation(t *testing.T) {
+	initialized := false
+	c := New(WithEagerLiteralCheck())
+	require.NoError(t, c.RegisterInstance("early", &eagerInitBean{initialized: &initialized}))
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return nil, false, nil
+	})
+	var instantiated []string
+	c.Subscribe(func(e Event) {
+		if e.Kind == EventInstantiated {
+			instantiated = append(instantiated, e.BeanID)
+		}
+	})
+
+	require.EqualError(t, c.Build(), "bean `workingdir` is required but no literal provider supplies it")
+	require.Empty(t, instantiated)
+	require.False(t, initialized)
+}
+
+func TestLiteralProvider_InstalledAfterFailedBuild(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+
+	// The implicit Build captured no provider, so the precheck fails
+	_, err := c.ResolveSafe("servicebeanconfig")
+	require.EqualError(t, err, "bean `workingdir` is required but not registered")
+
+	// The next Build captures the provider installed meanwhile
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "/srv", true, nil
+	})
+	cfg, err := ResolveAs[*Config](c, "servicebeanconfig")
+	require.NoError(t, err)
+	require.Equal(t, "/srv", cfg.WorkingDir)
+}
+
+func TestLiteralProvider_RemovedDuringBuild(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "/srv", true, nil
+	})
+	// Removing the provider between the precheck and injection does not affect this Build
+	c.OnPhase(PhasePreInject, func(c *Container) error {
+		c.SetLiteralProvider(nil)
+		return nil
+	})
+
+	cfg, err := ResolveAs[*Config](c, "servicebeanconfig")
+	require.NoError(t, err)
+	require.Equal(t, "/srv", cfg.WorkingDir)
+}
+
+func TestLiteralProvider_FrozenAfterBuild(t *testing.T) {
+	src := &reloadableConfig{values: map[string]string{"workingdir": "/srv/a"}}
+	c := New()
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Config)(nil))))
+	c.SetLiteralProvider(src.provide)
+	require.NoError(t, c.Build())
+
+	// A provider installed after Build is ignored until Reset
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "/srv/late", true, nil
+	})
+	require.NoError(t, c.RefreshLiterals())
+	v, err := c.ResolveSafe("workingdir")
+	require.NoError(t, err)
+	require.Equal(t, "/srv/a", v)
+
+	require.NoError(t, c.Reset())
+	cfg, err := ResolveAs[*Config](c, "servicebeanconfig")
+	require.NoError(t, err)
+	require.Equal(t, "/srv/late", cfg.WorkingDir)
+}
+
+type scopedHTTPClient struct {
+	Timeout string `di.inject:"timeout,scope=bean"`
+}
+
+type scopedDBClient struct {
+	Timeout time.Duration `di.inject:"timeout,scope=bean"`
+	Dir     string        `di.inject:"workingDir"`
+}
+
+func TestLiteralProvider_ScopedIDs(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("http", reflect.TypeOf((*scopedHTTPClient)(nil))))
+	require.NoError(t, c.Register("db", reflect.TypeOf((*scopedDBClient)(nil))))
+	var requested []string
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		requested = append(requested, id)
+		switch id {
+		case "http.timeout":
+			return "5s", true, nil
+		case "db.timeout":
+			return 30 * time.Second, true, nil
+		case "workingdir":
+			return "/srv", true, nil
+		}
+		return nil, false, nil
+	})
+
+	httpClient, err := ResolveAs[*scopedHTTPClient](c, "http")
+	require.NoError(t, err)
+	dbClient, err := ResolveAs[*scopedDBClient](c, "db")
+	require.NoError(t, err)
+	require.Equal(t, "5s", httpClient.Timeout)
+	require.Equal(t, 30*time.Second, dbClient.Timeout)
+	require.Equal(t, "/srv", dbClient.Dir)
+	require.ElementsMatch(t, []string{"http.timeout", "db.timeout", "workingdir"}, requested)
+}
+
+func TestLiteralProvider_ScopedIDsBadValue(t *testing.T) {
+	type badScope struct {
+		Timeout string `di.inject:"timeout,scope=global"`
+	}
+	err := New().Register("bad", reflect.TypeOf((*badScope)(nil)))
+	require.ErrorIs(t, err, ErrMalformedTag)
+	require.ErrorContains(t, err, "option 'scope=global' is not supported; use 'scope=bean'")
+}
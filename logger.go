@@ -0,0 +1,54 @@
+package iocdi
+
+import (
+	"log/slog"
+	"reflect"
+)
+
+// SetLogger installs a logger for the container's internals, to debug wiring without a debugger. At debug
+// level it reports registrations, the steps of Build, each bean being wired, literal provider lookups (by ID,
+// never the value), injections and initializers, with the bean IDs and field names involved. A tagged field
+// left unset because its type does not fit the dependency is reported at warn level. nil removes the logger;
+// without one, logging costs a single nil check.
+func (c *Container) SetLogger(l *slog.Logger) {
+	c.logger.Store(l)
+}
+
+// log returns the installed logger, or nil. Call sites check for nil before building any attributes.
+func (c *Container) log() *slog.Logger {
+	return c.logger.Load()
+}
+
+// logEvent reports a lifecycle event at debug level.
+func logEvent(l *slog.Logger, e Event) {
+	args := make([]any, 0, 8)
+	args = append(args, "bean", e.BeanID)
+	if e.DependencyID != emptyString {
+		args = append(args, "dependency", e.DependencyID)
+	}
+	if e.Field != emptyString {
+		args = append(args, "field", e.Field)
+	}
+	if e.Err != nil {
+		args = append(args, "error", e.Err)
+	}
+	l.Debug("iocdi: "+e.Kind.String(), args...)
+}
+
+// logStep reports the Build step about to run.
+func (c *Container) logStep(step string) {
+	if l := c.log(); l != nil {
+		l.Debug("iocdi: build step", "step", step)
+	}
+}
+
+// logIncompatible warns that a tagged field was left unset because the dependency does not fit its type, and
+// records the skip and the warning.
+func (c *Container) logIncompatible(receiverID, field, depID string, fieldType, depType reflect.Type) {
+	c.recordSkip(receiverID, field, depID, SkipIncompatible)
+	c.warnIncompatible(receiverID, field, depID, fieldType, depType)
+	if l := c.log(); l != nil {
+		l.Warn("iocdi: dependency type is incompatible with tagged field; left unset", "bean", receiverID, "field", field,
+			"dependency", depID, "fieldType", fieldType, "dependencyType", depType)
+	}
+}
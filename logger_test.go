@@ -0,0 +1,77 @@
+package iocdi
+
+import (
+	"bytes"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// captureLogger returns a debug-level logger writing to the buffer without timestamps.
+func captureLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+type initializedLogger struct {
+	Config *Config `di.inject:"ServiceBeanConfig"`
+}
+
+func (l *initializedLogger) Initialize() error { return nil }
+
+func TestSetLogger_Build(t *testing.T) {
+	c := newServiceGraph(t)
+	var buf bytes.Buffer
+	c.SetLogger(captureLogger(&buf))
+	require.NoError(t, c.Register("audit", reflect.TypeOf((*initializedLogger)(nil))))
+	require.NoError(t, c.Build())
+
+	out := buf.String()
+	for _, line := range []string{
+		`level=DEBUG msg="iocdi: Registered" bean=audit`,
+		`level=DEBUG msg="iocdi: build step" step=precheck`,
+		`level=DEBUG msg="iocdi: literal lookup" dependency=workingdir type=string receiver=servicebeanconfig field=WorkingDir found=true`,
+		`level=DEBUG msg="iocdi: Instantiated" bean=servicebean`,
+		`level=DEBUG msg="iocdi: wiring bean" bean=servicebean dependencies="[servicebeanconfig servicebeanlogger]"`,
+		`level=DEBUG msg="iocdi: Injected" bean=servicebean dependency=servicebeanconfig field=Config`,
+		`level=DEBUG msg="iocdi: running initializer" bean=audit`,
+		`level=DEBUG msg="iocdi: Initialized" bean=audit`,
+		`level=DEBUG msg="iocdi: build completed"`,
+	} {
+		require.Contains(t, out, line+"\n")
+	}
+	require.NotContains(t, out, "/srv/app", "literal values are not logged")
+	require.NotContains(t, out, "level=WARN")
+}
+
+type mistypedTarget struct {
+	Config *Logger `di.inject:"ServiceBeanConfig"`
+}
+
+func TestSetLogger_WarnsIncompatibleField(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Build())
+	var buf bytes.Buffer
+	c.SetLogger(captureLogger(&buf))
+
+	var target mistypedTarget
+	require.NoError(t, c.Inject(&target))
+	require.Nil(t, target.Config)
+	require.Contains(t, buf.String(), `level=WARN msg="iocdi: dependency type is incompatible with tagged field; left unset" `+
+		`bean=*iocdi.mistypedTarget field=Config dependency=servicebeanconfig fieldType=*iocdi.Logger dependencyType=*iocdi.Config`)
+
+	// Removing the logger silences the container
+	buf.Reset()
+	c.SetLogger(nil)
+	require.NoError(t, c.Inject(&target))
+	require.Empty(t, buf.String())
+}
@@ -0,0 +1,118 @@
+package iocdi
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// MetricsSink receives the container's counters and durations, to forward them to the application's metrics
+// system (Prometheus, OpenTelemetry, expvar). Methods are called synchronously, often with the container's
+// locks held, so they must be quick and must not call back into the container.
+type MetricsSink interface {
+	// ObserveBuild reports the duration of every Build or Preload that ran, successful or not.
+	ObserveBuild(d time.Duration)
+	// ObserveInit reports the duration of a bean's initializer, run by Build or on a lazy bean's first resolution.
+	ObserveInit(beanID string, d time.Duration)
+	// IncResolve counts a ResolveSafe or TryResolve call; hit reports whether it returned the bean.
+	IncResolve(beanID string, hit bool)
+}
+
+// SetMetricsSink installs the sink the container reports to; nil removes it. Without a sink no metrics are
+// collected.
+func (c *Container) SetMetricsSink(s MetricsSink) {
+	if s == nil {
+		c.metrics.Store(nil)
+		return
+	}
+	c.metrics.Store(&s)
+}
+
+// metricsSink returns the installed sink, or nil.
+func (c *Container) metricsSink() MetricsSink {
+	if s := c.metrics.Load(); s != nil {
+		return *s
+	}
+	return nil
+}
+
+// observeInit reports an initializer that started at start.
+func (c *Container) observeInit(id string, start time.Time) {
+	if s := c.metricsSink(); s != nil {
+		s.ObserveInit(id, time.Since(start))
+	}
+}
+
+// MemoryMetrics is a MetricsSink keeping everything in memory, for tests and as an example. It is safe for
+// concurrent use; the zero value is ready to use.
+type MemoryMetrics struct {
+	mu       sync.Mutex
+	builds   []time.Duration
+	inits    map[string][]time.Duration
+	hits     map[string]int
+	misses   map[string]int
+	resolves int
+}
+
+var _ MetricsSink = (*MemoryMetrics)(nil)
+
+// ObserveBuild records a Build duration.
+func (m *MemoryMetrics) ObserveBuild(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.builds = append(m.builds, d)
+}
+
+// ObserveInit records an initializer duration.
+func (m *MemoryMetrics) ObserveInit(beanID string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.inits == nil {
+		m.inits = make(map[string][]time.Duration)
+	}
+	m.inits[beanID] = append(m.inits[beanID], d)
+}
+
+// IncResolve counts a resolution.
+func (m *MemoryMetrics) IncResolve(beanID string, hit bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.hits == nil {
+		m.hits = make(map[string]int)
+		m.misses = make(map[string]int)
+	}
+	if hit {
+		m.hits[beanID]++
+	} else {
+		m.misses[beanID]++
+	}
+	m.resolves++
+}
+
+// Builds returns the recorded Build durations, oldest first.
+func (m *MemoryMetrics) Builds() []time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.builds)
+}
+
+// Inits returns the recorded initializer durations of the bean, oldest first.
+func (m *MemoryMetrics) Inits(beanID string) []time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.inits[beanID])
+}
+
+// Resolves returns the number of resolutions of the bean that returned it and that failed.
+func (m *MemoryMetrics) Resolves(beanID string) (hits, misses int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.hits[beanID], m.misses[beanID]
+}
+
+// TotalResolves returns the number of resolutions of any bean.
+func (m *MemoryMetrics) TotalResolves() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.resolves
+}
@@ -0,0 +1,70 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsSink(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*sleepyInit)(nil))))
+	m := &MemoryMetrics{}
+	c.SetMetricsSink(m)
+
+	_, err := c.ResolveSafe("ServiceBean")
+	require.NoError(t, err)
+	_, err = c.ResolveSafe("servicebean")
+	require.NoError(t, err)
+	_, err = c.ResolveSafe("missing")
+	require.ErrorIs(t, err, ErrBeanNotFound)
+	_, ok := c.TryResolve("missing")
+	require.False(t, ok)
+	_, ok = c.TryResolve("ServiceBeanLogger")
+	require.True(t, ok)
+
+	require.Len(t, m.Builds(), 1)
+	require.Positive(t, m.Builds()[0])
+
+	inits := m.Inits("slow")
+	require.Len(t, inits, 1)
+	require.GreaterOrEqual(t, inits[0], sleepyDelay)
+	require.Empty(t, m.Inits("servicebean"), "beans without an initializer are not observed")
+
+	hits, misses := m.Resolves("servicebean")
+	require.Equal(t, 2, hits)
+	require.Zero(t, misses)
+	hits, misses = m.Resolves("missing")
+	require.Zero(t, hits)
+	require.Equal(t, 2, misses)
+	hits, _ = m.Resolves("servicebeanlogger")
+	require.Equal(t, 1, hits)
+	require.Equal(t, 5, m.TotalResolves())
+
+	// Removing the sink stops the reporting
+	c.SetMetricsSink(nil)
+	_, err = c.ResolveSafe("servicebean")
+	require.NoError(t, err)
+	require.Equal(t, 5, m.TotalResolves())
+}
+
+type lazyInitialized struct{ inits int }
+
+func (l *lazyInitialized) Initialize() error {
+	l.inits++
+	return nil
+}
+
+func TestMetricsSink_LazyInit(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("lazy", reflect.TypeOf((*lazyInitialized)(nil)), Lazy()))
+	m := &MemoryMetrics{}
+	c.SetMetricsSink(m)
+
+	require.NoError(t, c.Build())
+	require.Empty(t, m.Inits("lazy"))
+	_, err := c.ResolveSafe("lazy")
+	require.NoError(t, err)
+	require.Len(t, m.Inits("lazy"), 1)
+}
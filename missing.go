@@ -0,0 +1,77 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// MissingBeanProvider is a hook invoked when a dependency of any type is not registered, e.g. to generate a
+// no-op implementation of a metrics or tracing interface.
+// - id: the value of the `di.inject` tag for the missing dependency
+// - targetType: the type expected for that dependency (the struct type for pointer-to-struct fields)
+// Returns:
+// - value: the instance to register under the ID
+// - found: whether an instance is available
+// - err: any error occurred while constructing the instance
+type MissingBeanProvider func(id string, targetType reflect.Type) (value any, found bool, err error)
+
+// SetMissingBeanProvider installs a hook that Build asks for each required dependency that is not registered.
+// Values it returns are registered as synthetic beans: they are wired, validated and initialized like beans
+// given to RegisterInstance and must be compatible with the fields requiring them. For basic kinds and
+// collections a LiteralProvider keeps precedence; the hook is only asked when none is installed. nil removes
+// the hook. Synthetic beans are discarded by a failed Build and by Reset.
+func (c *Container) SetMissingBeanProvider(p MissingBeanProvider) {
+	if p == nil {
+		c.missingBeanProvider.Store(nil)
+		return
+	}
+	c.missingBeanProvider.Store(&p)
+}
+
+// supplyMissing asks the MissingBeanProvider for each of the required dependencies that is not registered and
+// registers the instances it returns, then does the same for their own dependencies. Beans synthesized for a
+// lazy resolution are deferred so they are built with the bean needing them.
+// Callers must hold regMu.
+func (c *Container) supplyMissing(ids []string, deferred bool) error {
+	p := c.missingBeanProvider.Load()
+	if p == nil {
+		return nil
+	}
+
+	queue := slices.Clone(ids)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		requiredType, ok := c.requiredDependency[id]
+		if !ok || c.isRegistered(id) {
+			continue
+		}
+		if isLiteralKind(requiredType) && c.hasLiteralProvider() {
+			continue
+		}
+		val, found, err := (*p)(id, requiredType)
+		if err != nil {
+			return fmt.Errorf("missing bean provider error for '%s': %w", id, err)
+		}
+		if !found {
+			continue
+		}
+		if val == nil {
+			return fmt.Errorf("missing bean provider returned nil for '%s'", id)
+		}
+
+		b, err := c.instanceBean(id, val, nil)
+		if err != nil {
+			return fmt.Errorf("missing bean provider value for '%s': %w", id, err)
+		}
+		b.supplied = false
+		b.synthetic = true
+		b.deferred = deferred
+		c.requireDependencies(b.fields)
+		c.registeredBeans[id] = b
+		queue = append(queue, b.dependencies...)
+	}
+	return nil
+}
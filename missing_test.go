@@ -0,0 +1,117 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tracer interface {
+	Trace(span string)
+}
+
+type noopTracer struct {
+	initialized bool
+}
+
+func (t *noopTracer) Trace(string) {}
+
+func (t *noopTracer) Initialize() error {
+	t.initialized = true
+	return nil
+}
+
+type tracedService struct {
+	Tracer tracer `di.inject:"tracer"`
+	Name   string `di.inject:"serviceName"`
+}
+
+func tracerProvider(calls *int) MissingBeanProvider {
+	return func(id string, targetType reflect.Type) (any, bool, error) {
+		*calls++
+		if id == "tracer" && targetType == reflect.TypeOf((*tracer)(nil)).Elem() {
+			return &noopTracer{}, true, nil
+		}
+		return nil, false, nil
+	}
+}
+
+func TestMissingBeanProvider_SynthesizesInterface(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("service", reflect.TypeOf((*tracedService)(nil))))
+	require.NoError(t, c.RegisterInstance("serviceName", "orders"))
+	var calls int
+	c.SetMissingBeanProvider(tracerProvider(&calls))
+
+	svc, err := ResolveAs[*tracedService](c, "service")
+	require.NoError(t, err)
+	tr, ok := svc.Tracer.(*noopTracer)
+	require.True(t, ok)
+	require.True(t, tr.initialized)
+	require.Equal(t, 1, calls)
+
+	// The synthetic bean is registered and shared
+	v, err := c.ResolveSafe("tracer")
+	require.NoError(t, err)
+	require.Same(t, tr, v)
+	d, err := c.DescribeBean("tracer")
+	require.NoError(t, err)
+	require.True(t, d.Synthetic)
+	require.False(t, d.Supplied)
+}
+
+func TestMissingBeanProvider_LiteralPrecedence(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("service", reflect.TypeOf((*tracedService)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		if id == "servicename" {
+			return "billing", true, nil
+		}
+		return nil, false, nil
+	})
+	var asked []string
+	c.SetMissingBeanProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		asked = append(asked, id)
+		if id == "tracer" {
+			return &noopTracer{}, true, nil
+		}
+		return "unused", true, nil
+	})
+
+	svc, err := ResolveAs[*tracedService](c, "service")
+	require.NoError(t, err)
+	require.Equal(t, "billing", svc.Name)
+	require.Equal(t, []string{"tracer"}, asked)
+}
+
+func TestMissingBeanProvider_TypeMismatch(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("service", reflect.TypeOf((*tracedService)(nil))))
+	require.NoError(t, c.RegisterInstance("serviceName", "orders"))
+	c.SetMissingBeanProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return &Logger{}, true, nil
+	})
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bean 'tracer' type mismatch: required iocdi.tracer, registered *iocdi.Logger")
+
+	// The failed Build discarded the synthetic bean
+	require.False(t, c.Contains("tracer"))
+}
+
+func TestMissingBeanProvider_Error(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("service", reflect.TypeOf((*tracedService)(nil))))
+	require.NoError(t, c.RegisterInstance("serviceName", "orders"))
+	boom := errors.New("boom")
+	c.SetMissingBeanProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return nil, false, boom
+	})
+
+	err := c.Build()
+	require.ErrorIs(t, err, boom)
+	require.Contains(t, err.Error(), "missing bean provider error for 'tracer'")
+}
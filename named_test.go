@@ -0,0 +1,47 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Port int
+type Hostname string
+type Backoff time.Duration
+
+type namedReceiver struct {
+	Port    Port     `di.inject:"Port"`
+	Host    Hostname `di.inject:"Host"`
+	Backoff Backoff  `di.inject:"Backoff"`
+}
+
+func TestNamedTypes_ConvertedFromUnderlying(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("Receiver", reflect.TypeOf((*namedReceiver)(nil))))
+	require.NoError(t, c.RegisterInstance("Port", 8080))
+	require.NoError(t, c.RegisterInstance("Host", "localhost"))
+	require.NoError(t, c.RegisterInstance("Backoff", 2*time.Second))
+
+	r, err := ResolveAs[*namedReceiver](c, "Receiver")
+	require.NoError(t, err)
+	require.Equal(t, Port(8080), r.Port)
+	require.Equal(t, Hostname("localhost"), r.Host)
+	require.Equal(t, Backoff(2*time.Second), r.Backoff)
+}
+
+type hostReceiver struct {
+	Host Hostname `di.inject:"Host"`
+}
+
+func TestNamedTypes_NonConvertibleMismatch(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("Receiver", reflect.TypeOf((*hostReceiver)(nil))))
+	// int is convertible to a string type in Go (as a rune), but that must not be applied.
+	require.NoError(t, c.RegisterInstance("Host", 65))
+
+	err := c.Build()
+	require.EqualError(t, err, "bean 'host' type mismatch: required iocdi.Hostname, registered int")
+}
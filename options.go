@@ -0,0 +1,171 @@
+package iocdi
+
+import (
+	"os"
+	"time"
+)
+
+// Option configures a Container at construction time. See New.
+type Option func(*Container)
+
+// Lookuper looks up environment variables for fields tagged with `di.env`.
+// It mirrors the signature of os.LookupEnv so tests can supply a map-backed implementation
+// instead of mutating the process environment.
+type Lookuper interface {
+	LookupEnv(key string) (string, bool)
+}
+
+// LookuperFunc adapts an ordinary function to the Lookuper interface.
+type LookuperFunc func(key string) (string, bool)
+
+// LookupEnv calls f(key).
+func (f LookuperFunc) LookupEnv(key string) (string, bool) {
+	return f(key)
+}
+
+// WithEnvLookuper replaces the default os.LookupEnv based lookup used for `di.env` fields.
+func WithEnvLookuper(l Lookuper) Option {
+	return func(c *Container) {
+		if l != nil {
+			c.envLookuper = l
+		}
+	}
+}
+
+// defaultLookuper is used when no Lookuper is configured.
+var defaultLookuper Lookuper = LookuperFunc(os.LookupEnv)
+
+// WithAutowire enables by-type wiring of exported, untagged fields of interface or pointer-to-struct type.
+// Fields of an empty interface type such as any are left alone.
+// Such a field is wired to the single registered bean assignable to it; when several beans qualify the one
+// registered with Primary wins. Fields tagged with `di.inject` are always wired by ID.
+func WithAutowire() Option {
+	return func(c *Container) {
+		c.autowire = true
+	}
+}
+
+// WithStrictAutowire makes Build fail, before anything is instantiated, when an autowired interface field has
+// no candidate (it would be left nil) or several candidates without a single Primary, listing every such field
+// with its candidates. It implies WithAutowire.
+func WithStrictAutowire() Option {
+	return func(c *Container) {
+		c.autowire = true
+		c.strictAutowire = true
+	}
+}
+
+// WithStrictWarnings makes Build fail after injection, listing every such warning, when it raised a Warning
+// with one of the codes, or any Warning when no code is given. Other warnings are still only collected.
+func WithStrictWarnings(codes ...WarningCode) Option {
+	return func(c *Container) {
+		if len(codes) == 0 {
+			codes = []WarningCode{WarningIncompatibleType, WarningUnexportedField, WarningUnsettableField, WarningUnknownTagOption}
+		}
+		if c.strictWarnings == nil {
+			c.strictWarnings = make(map[WarningCode]bool, len(codes))
+		}
+		for _, code := range codes {
+			c.strictWarnings[code] = true
+		}
+	}
+}
+
+// WithEagerCycleCheck makes Register and RegisterInstance reject a registration that closes a dependency cycle
+// among the beans registered so far, returning the cycle path instead of deferring the failure to Build.
+// Missing dependencies are not errors at this stage.
+func WithEagerCycleCheck() Option {
+	return func(c *Container) {
+		c.eagerCycleCheck = true
+	}
+}
+
+// WithLenientTags makes registration ignore unknown `di.inject` and `di.env` tag options instead of failing,
+// for structs from third-party packages you don't control. Each ignored option is logged at warn level and
+// collected as a WarningUnknownTagOption; see Warnings. Malformed options are still reported.
+func WithLenientTags() Option {
+	return func(c *Container) {
+		c.lenientTags = true
+	}
+}
+
+// WithNoImplicitBuild makes resolution and Inject on a container that has not been built fail with
+// ErrContainerNotBuilt instead of building it, so resolving before the composition root has finished
+// registering is caught as a bug. Once Build succeeds, resolution behaves normally.
+func WithNoImplicitBuild() Option {
+	return func(c *Container) {
+		c.noImplicitBuild = true
+	}
+}
+
+// WithCommaSeparatedLiterals lets a LiteralProvider supply a slice field as a single comma-separated string,
+// e.g. "kafka-1:9092, kafka-2:9092" for a []string. Elements are trimmed and converted to the element type.
+// Without it, a slice field requires a value of the slice type.
+func WithCommaSeparatedLiterals() Option {
+	return func(c *Container) {
+		c.splitLiterals = true
+	}
+}
+
+// WithEagerLiteralCheck makes Build's precheck ask the literal providers for every missing basic-kind or
+// collection dependency before any bean is instantiated, failing at once when a value is not supplied or a
+// provider errs. The values are stored, so injection does not ask again. By default the precheck only checks
+// that a provider is installed and leaves the lookup to injection.
+func WithEagerLiteralCheck() Option {
+	return func(c *Container) {
+		c.eagerLiterals = true
+	}
+}
+
+// WithValidatedStringBeans makes Build pass registered string beans, not only literal provider values, through
+// the validator set with SetLiteralValidator.
+func WithValidatedStringBeans() Option {
+	return func(c *Container) {
+		c.validateStrings = true
+	}
+}
+
+// WithInitTimeout bounds each bean's initializer during Build. An Initialize that runs longer fails Build with
+// ErrInitTimeout; its goroutine is abandoned. Beans implementing ContextInitializer receive the deadline through
+// their context instead. Zero (the default) disables the timeout. See InitTimeout for a per-bean override.
+func WithInitTimeout(d time.Duration) Option {
+	return func(c *Container) {
+		c.initTimeout = d
+	}
+}
+
+// RegisterOption configures a single bean at registration time. See Register and RegisterInstance.
+type RegisterOption func(*bean)
+
+// Primary marks the bean as the preferred candidate when several registered beans satisfy the same
+// autowired field. It has no effect on fields that name their dependency with a `di.inject` tag.
+func Primary() RegisterOption {
+	return func(b *bean) {
+		b.primary = true
+	}
+}
+
+// Prototype gives the bean prototype scope: Build creates no shared instance, and every resolution and every
+// receiving field gets a fresh instance, wired from the built graph and initialized, as with the `prototype`
+// tag option. Fresh instances are not retained unless the bean is also registered with TrackPrototypes.
+func Prototype() RegisterOption {
+	return func(b *bean) {
+		b.prototype = true
+	}
+}
+
+// InitTimeout overrides the container's WithInitTimeout for this bean's initializer.
+func InitTimeout(d time.Duration) RegisterOption {
+	return func(b *bean) {
+		b.initTimeout = d
+	}
+}
+
+// InitPriority orders the bean's initializer among beans that are ready at the same time; lower values run
+// earlier, and beans of equal priority run in bean-ID order. Dependencies always come first: a bean never
+// initializes before its dependencies, whatever its priority.
+func InitPriority(n int) RegisterOption {
+	return func(b *bean) {
+		b.initPriority = n
+	}
+}
@@ -0,0 +1,95 @@
+package iocdi
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// initializationOrder returns the bean IDs in the order their initializers run. It is a topological order of
+// the dependency graph (dependencies first) computed with Kahn's algorithm; among the beans ready at each step,
+// the one with the lowest InitPriority runs first, then the smallest bean ID. Deferred beans are left out.
+// Callers must hold regMu.
+func (c *Container) initializationOrder() ([]string, error) {
+	pending := make(map[string]int, len(c.registeredBeans))         // unsatisfied dependency count per bean
+	dependents := make(map[string][]string, len(c.registeredBeans)) // reverse edges
+	built := make([]string, 0, len(c.registeredBeans))
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		if bn.deferred {
+			continue
+		}
+		built = append(built, id)
+		seen := make(map[string]bool)
+		for _, dep := range c.edges(bn) {
+			if _, ok := c.registeredBeans[dep]; !ok {
+				if t, ok := c.requiredDependency[dep]; ok && isLiteralKind(t) {
+					continue // an ephemeral literal, which has nothing to initialize
+				}
+				return nil, fmt.Errorf("initializer order: dependency '%s' required by '%s' not registered", dep, id)
+			}
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			pending[id]++
+			dependents[dep] = append(dependents[dep], id)
+		}
+	}
+
+	ready := &readyQueue{c: c}
+	for _, id := range built {
+		if pending[id] == 0 {
+			heap.Push(ready, id)
+		}
+	}
+
+	order := make([]string, 0, len(built))
+	for ready.Len() > 0 {
+		id := heap.Pop(ready).(string)
+		order = append(order, id)
+		for _, dependent := range dependents[id] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				heap.Push(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) < len(built) {
+		if cycles := c.declaredCycles(); len(cycles) > 0 {
+			return nil, fmt.Errorf("initializer order: %w", cycles[0])
+		}
+		for _, id := range built {
+			if pending[id] > 0 {
+				return nil, fmt.Errorf("initializer order: dependency cycle detected at '%s'", id)
+			}
+		}
+	}
+	return order, nil
+}
+
+// readyQueue is a min-heap of bean IDs ordered by (InitPriority, ID).
+type readyQueue struct {
+	c   *Container
+	ids []string
+}
+
+func (q *readyQueue) Len() int { return len(q.ids) }
+
+func (q *readyQueue) Less(i, j int) bool {
+	pi, pj := q.c.registeredBeans[q.ids[i]].initPriority, q.c.registeredBeans[q.ids[j]].initPriority
+	if pi != pj {
+		return pi < pj
+	}
+	return q.ids[i] < q.ids[j]
+}
+
+func (q *readyQueue) Swap(i, j int) { q.ids[i], q.ids[j] = q.ids[j], q.ids[i] }
+
+func (q *readyQueue) Push(x any) { q.ids = append(q.ids, x.(string)) }
+
+func (q *readyQueue) Pop() any {
+	last := q.ids[len(q.ids)-1]
+	q.ids = q.ids[:len(q.ids)-1]
+	return last
+}
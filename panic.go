@@ -0,0 +1,57 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"runtime/debug"
+)
+
+// PanicError reports a panic recovered from a bean's Initialize or from injecting into a bean during Build.
+// Build returns it (possibly wrapped) instead of unwinding with the container's locks held; use errors.As to
+// inspect it.
+type PanicError struct {
+	// BeanID is the bean whose initializer or injection panicked.
+	BeanID string
+	// Field, FieldType and DependencyID identify the field being set and the bean being injected into it when
+	// the panic was raised during injection; they are empty otherwise.
+	Field        string
+	FieldType    reflect.Type
+	DependencyID string
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the goroutine stack captured when the panic was recovered.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	if e.Field != emptyString {
+		return fmt.Sprintf("bean '%s' panicked injecting '%s' into field '%s' (%v): %v", e.BeanID, e.DependencyID, e.Field, e.FieldType, e.Value)
+	}
+	return fmt.Sprintf("bean '%s' panicked: %v", e.BeanID, e.Value)
+}
+
+// recoverPanic converts a panic in progress into a PanicError stored in *err.
+// It must be called directly by a deferred statement.
+func recoverPanic(beanID string, err *error) {
+	if r := recover(); r != nil {
+		*err = &PanicError{BeanID: beanID, Value: r, Stack: debug.Stack()}
+	}
+}
+
+// recoverInjectionPanic is recoverPanic for injecting depID into the receiver, adding the field being set, if
+// any, to the PanicError. It must be called directly by a deferred statement.
+func recoverInjectionPanic(receiverID, depID string, field **reflect.StructField, err *error) {
+	if r := recover(); r != nil {
+		pe := &PanicError{BeanID: receiverID, Value: r, Stack: debug.Stack()}
+		if sf := *field; sf != nil {
+			pe.Field, pe.FieldType, pe.DependencyID = sf.Name, sf.Type, depID
+		}
+		*err = pe
+	}
+}
+
+// callRecovered runs fn, converting a panic into a PanicError attributed to beanID.
+func callRecovered(beanID string, fn func() error) (err error) {
+	defer recoverPanic(beanID, &err)
+	return fn()
+}
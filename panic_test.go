@@ -0,0 +1,98 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type panickingInit struct{}
+
+func (p *panickingInit) Initialize() error {
+	panic("database handle missing")
+}
+
+type panicPortReceiver struct {
+	Port int `di.inject:"port"`
+}
+
+func TestPanic_InitializerReturnsPanicError(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("broken", reflect.TypeOf((*panickingInit)(nil))))
+
+	err := c.Build()
+	var pe *PanicError
+	require.True(t, errors.As(err, &pe))
+	require.Equal(t, "broken", pe.BeanID)
+	require.Equal(t, "database handle missing", pe.Value)
+	require.NotEmpty(t, pe.Stack)
+	require.Contains(t, err.Error(), "bean 'broken' panicked: database handle missing")
+	require.False(t, c.built.Load())
+
+	// Locks were released: a second attempt fails the same way instead of deadlocking
+	require.ErrorAs(t, c.Build(), &pe)
+}
+
+func TestPanic_InitializerWithTimeout(t *testing.T) {
+	c := New(WithInitTimeout(time.Second))
+	require.NoError(t, c.Register("broken", reflect.TypeOf((*panickingInit)(nil))))
+
+	var pe *PanicError
+	require.ErrorAs(t, c.Build(), &pe)
+	require.Equal(t, "broken", pe.BeanID)
+}
+
+func TestPanic_InjectionReturnsPanicError(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterConverter(reflect.TypeOf(""), reflect.TypeOf(0), func(v any) (any, error) {
+		panic("bad port")
+	}))
+	require.NoError(t, c.RegisterInstance("port", "8080"))
+	require.NoError(t, c.Register("server", reflect.TypeOf((*panicPortReceiver)(nil))))
+
+	err := c.Build()
+	var pe *PanicError
+	require.ErrorAs(t, err, &pe)
+	require.Equal(t, "server", pe.BeanID)
+	require.Equal(t, "bad port", pe.Value)
+	require.Equal(t, "Port", pe.Field)
+	require.Equal(t, reflect.TypeOf(0), pe.FieldType)
+	require.Equal(t, "port", pe.DependencyID)
+	require.EqualError(t, pe, "bean 'server' panicked injecting 'port' into field 'Port' (int): bad port")
+	require.False(t, c.built.Load())
+}
+
+type panicSettings struct {
+	Name string
+}
+
+// panicSettingsReceiver takes the settings both by pointer and by value; copying a nil *panicSettings into
+// Value makes reflection panic.
+type panicSettingsReceiver struct {
+	Ptr   *panicSettings `di.inject:"settings"`
+	Value panicSettings  `di.inject:"settings"`
+}
+
+func TestPanic_InjectionCarriesFieldContext(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("settings", (*panicSettings)(nil)))
+	require.NoError(t, c.Register("app", reflect.TypeOf((*panicSettingsReceiver)(nil))))
+
+	err := c.Build()
+	var pe *PanicError
+	require.ErrorAs(t, err, &pe)
+	require.Equal(t, "app", pe.BeanID)
+	require.Equal(t, "Value", pe.Field)
+	require.Equal(t, reflect.TypeOf(panicSettings{}), pe.FieldType)
+	require.Equal(t, "settings", pe.DependencyID)
+	require.Contains(t, err.Error(), "bean 'app' panicked injecting 'settings' into field 'Value' (iocdi.panicSettings): ")
+	require.False(t, c.built.Load())
+	require.Equal(t, StateBuildFailed, c.State())
+
+	// The failed attempt left nothing half-built behind: the same panic is reported again
+	require.ErrorAs(t, c.Build(), &pe)
+	require.Equal(t, "Value", pe.Field)
+}
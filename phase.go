@@ -0,0 +1,86 @@
+package iocdi
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Phase identifies a point inside Build at which hooks registered with OnPhase run.
+// Phases are bit flags and may be combined, e.g. PhasePreInject|PhasePostInject.
+type Phase uint8
+
+const (
+	// PhasePreInject runs after instances are created and before any dependency is injected.
+	PhasePreInject Phase = 1 << iota
+	// PhasePostInject runs after injection and before any Validator or Initializer.
+	PhasePostInject
+	// PhasePreInit runs after validation and before the first Initializer.
+	PhasePreInit
+	// PhasePostInit runs after every Initializer succeeded.
+	PhasePostInit
+)
+
+// phases lists the individual phases in the order Build runs them.
+var phases = []Phase{PhasePreInject, PhasePostInject, PhasePreInit, PhasePostInit}
+
+func (p Phase) String() string {
+	switch p {
+	case PhasePreInject:
+		return "pre-inject"
+	case PhasePostInject:
+		return "post-inject"
+	case PhasePreInit:
+		return "pre-init"
+	case PhasePostInit:
+		return "post-init"
+	}
+	return fmt.Sprintf("phase(%d)", uint8(p))
+}
+
+// phaseHooks holds the hooks registered with OnPhase.
+type phaseHooks struct {
+	mu  sync.Mutex
+	fns map[Phase][]func(c *Container) error
+}
+
+// OnPhase registers fn to run at each of the given Build phases, in registration order. Hooks may inspect the
+// container (e.g. DescribeBean) to add cross-cutting checks, such as reporting nil tagged fields after
+// injection. A hook returning an error aborts Build with an error naming the phase. Hooks must not build or
+// resolve from the container, since Build is still in progress.
+func (c *Container) OnPhase(phase Phase, fn func(c *Container) error) {
+	if fn == nil {
+		return
+	}
+	c.hooks.mu.Lock()
+	defer c.hooks.mu.Unlock()
+	if c.hooks.fns == nil {
+		c.hooks.fns = make(map[Phase][]func(c *Container) error)
+	}
+	for _, p := range phases {
+		if phase&p != 0 {
+			c.hooks.fns[p] = append(c.hooks.fns[p], fn)
+		}
+	}
+}
+
+// runPhase runs the hooks of a single phase, stopping at the first error. The registry lock is released while
+// hooks run so they can inspect the container; registration stays closed because Build is in progress.
+// Callers must hold regMu.
+func (c *Container) runPhase(p Phase) error {
+	c.hooks.mu.Lock()
+	fns := slices.Clone(c.hooks.fns[p])
+	c.hooks.mu.Unlock()
+	if len(fns) == 0 {
+		return nil
+	}
+
+	c.regMu.Unlock()
+	defer c.regMu.Lock()
+	for _, fn := range fns {
+		if err := fn(c); err != nil {
+			return fmt.Errorf("%s hook failed: %w", p, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var phaseLog []string
+
+type phaseRepo struct{}
+
+func (p *phaseRepo) Initialize() error {
+	phaseLog = append(phaseLog, "init:repo")
+	return nil
+}
+
+type phaseService struct {
+	Repo *phaseRepo `di.inject:"repo"`
+}
+
+func (p *phaseService) Initialize() error {
+	phaseLog = append(phaseLog, "init:service")
+	return nil
+}
+
+func TestOnPhase_Ordering(t *testing.T) {
+	phaseLog = nil
+	c := New()
+	require.NoError(t, c.Register("service", reflect.TypeOf((*phaseService)(nil))))
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*phaseRepo)(nil))))
+
+	injected := func(c *Container) bool {
+		return c.registeredBeans["service"].instance.(*phaseService).Repo != nil
+	}
+	c.OnPhase(PhasePreInject|PhasePostInject, func(c *Container) error {
+		phaseLog = append(phaseLog, "inject-hook")
+		return nil
+	})
+	c.OnPhase(PhasePreInject, func(c *Container) error {
+		require.False(t, injected(c))
+		return nil
+	})
+	c.OnPhase(PhasePostInject, func(c *Container) error {
+		require.True(t, injected(c))
+		// Inspection is available while Build is in progress
+		d, err := c.DescribeBean("service")
+		require.NoError(t, err)
+		require.False(t, d.Initialized)
+		return nil
+	})
+	c.OnPhase(PhasePreInit, func(c *Container) error {
+		phaseLog = append(phaseLog, "pre-init")
+		return nil
+	})
+	c.OnPhase(PhasePostInit, func(c *Container) error {
+		phaseLog = append(phaseLog, "post-init")
+		return nil
+	})
+
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"inject-hook", "inject-hook", "pre-init", "init:repo", "init:service", "post-init"}, phaseLog)
+}
+
+func TestOnPhase_ErrorAbortsBuild(t *testing.T) {
+	phaseLog = nil
+	c := New()
+	require.NoError(t, c.Register("service", reflect.TypeOf((*phaseService)(nil))))
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*phaseRepo)(nil))))
+	c.OnPhase(PhasePostInject, func(c *Container) error {
+		return errors.New("service has nil field Repo")
+	})
+
+	err := c.Build()
+	require.EqualError(t, err, "post-inject hook failed: service has nil field Repo")
+	require.Empty(t, phaseLog)
+	require.False(t, c.built.Load())
+}
+
+func TestOnPhase_RegistrationClosedDuringBuild(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*phaseRepo)(nil))))
+	c.OnPhase(PhasePreInit, func(c *Container) error {
+		return c.RegisterInstance("late", "value")
+	})
+	require.ErrorIs(t, c.Build(), ErrRegistrationClosed)
+}
+
+func TestPhase_String(t *testing.T) {
+	require.Equal(t, "pre-init", PhasePreInit.String())
+	require.Equal(t, "phase(3)", (PhasePreInject | PhasePostInject).String())
+}
@@ -0,0 +1,46 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type brokenReceiver struct {
+	Config *Config  `di.inject:"ServiceBeanConfig"`
+	Repo   *Service `di.inject:"repo"`
+	Cache  *Service `di.inject:"cache"`
+}
+
+func TestPrecheck_ReportsEveryFailure(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("receiver", reflect.TypeOf((*brokenReceiver)(nil))))
+	require.NoError(t, c.Register("ServiceBeanConfig", reflect.TypeOf((*Logger)(nil))))
+
+	err := c.Build()
+	require.EqualError(t, err, "bean `cache` is required but not registered\n"+
+		"bean `repo` is required but not registered\n"+
+		"bean 'servicebeanconfig' type mismatch: required iocdi.Config, registered *iocdi.Logger")
+	require.Equal(t, StateBuildFailed, c.State())
+}
+
+func TestPrecheck_CapsFailures(t *testing.T) {
+	fields := make([]reflect.StructField, 0, maxPrecheckErrors+5)
+	for i := 0; i < maxPrecheckErrors+5; i++ {
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Dep%02d", i),
+			Type: reflect.TypeOf((*Service)(nil)),
+			Tag:  reflect.StructTag(fmt.Sprintf(`di.inject:"dep%02d"`, i)),
+		})
+	}
+	c := New()
+	require.NoError(t, c.Register("receiver", reflect.PointerTo(reflect.StructOf(fields))))
+
+	lines := strings.Split(c.Build().Error(), "\n")
+	require.Len(t, lines, maxPrecheckErrors+1)
+	require.Equal(t, "bean `dep00` is required but not registered", lines[0])
+	require.Equal(t, "5 more precheck failures not shown", lines[maxPrecheckErrors])
+}
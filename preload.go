@@ -0,0 +1,59 @@
+package iocdi
+
+import (
+	"context"
+	"strings"
+)
+
+// Preload builds only the named beans and their transitive dependencies: it checks, instantiates, injects,
+// validates and initializes that closure and leaves every other bean untouched. The container is then
+// partially built: registration is closed, preloaded beans resolve immediately, and any other bean is built
+// on its first resolution, together with whatever it needs that is not built yet. A later Build is a no-op.
+// On an already (partially) built container, Preload builds the named beans that are still pending.
+//
+// The Preload that leaves the container built runs the build-complete callbacks, as Build would; callbacks
+// registered afterwards run immediately.
+func (c *Container) Preload(beanIDs ...string) error {
+	completed, err := c.preload(beanIDs)
+	if err != nil || !completed {
+		return err
+	}
+	// Callbacks run outside the build lock so they may resolve or start the container
+	return c.runBuildComplete()
+}
+
+// preload builds the closure of the named beans under the build lock, reporting whether this call left a
+// previously unbuilt container built.
+func (c *Container) preload(beanIDs []string) (bool, error) {
+	c.buildLock.Lock()
+	defer c.buildLock.Unlock()
+
+	if c.closed.Load() {
+		return false, ErrContainerClosed
+	}
+
+	roots := make([]string, 0, len(beanIDs))
+	c.regMu.RLock()
+	for _, id := range beanIDs {
+		id = strings.ToLower(id)
+		if _, ok := c.registeredBeans[id]; !ok {
+			c.regMu.RUnlock()
+			return false, &BeanError{ID: id, Err: ErrBeanNotFound}
+		}
+		roots = append(roots, id)
+	}
+	c.regMu.RUnlock()
+
+	if !c.built.Load() {
+		if err := c.build(context.Background(), roots); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	for _, id := range roots {
+		if err := c.materialize(id); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,122 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type preloadRepo struct {
+	DSN   string `di.inject:"dsn"`
+	inits int
+}
+
+func (p *preloadRepo) Initialize() error {
+	p.inits++
+	return nil
+}
+
+type preloadCommand struct {
+	Repo *preloadRepo `di.inject:"repo"`
+}
+
+type preloadReport struct {
+	Repo  *preloadRepo `di.inject:"repo"`
+	inits int
+}
+
+func (p *preloadReport) Initialize() error {
+	p.inits++
+	return nil
+}
+
+type preloadOrphan struct {
+	Missing *preloadRepo `di.inject:"missing"`
+}
+
+type preloadLoopA struct {
+	B *preloadLoopB `di.inject:"loopb"`
+}
+
+type preloadLoopB struct {
+	A *preloadLoopA `di.inject:"loopa"`
+}
+
+func newPreloadContainer(t *testing.T) *Container {
+	t.Helper()
+	c := New()
+	require.NoError(t, c.Register("command", reflect.TypeOf((*preloadCommand)(nil))))
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*preloadRepo)(nil))))
+	require.NoError(t, c.Register("report", reflect.TypeOf((*preloadReport)(nil))))
+	require.NoError(t, c.Register("orphan", reflect.TypeOf((*preloadOrphan)(nil))))
+	return c
+}
+
+func TestPreload_BuildsOnlyTheClosure(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "postgres://local", id == "dsn", nil
+	})
+
+	c := newPreloadContainer(t)
+	// orphan's missing dependency does not block a preload that doesn't need it
+	require.NoError(t, c.Preload("Command"))
+	require.Nil(t, c.registeredBeans["report"].instance)
+	require.Nil(t, c.registeredBeans["orphan"].instance)
+
+	cmd, err := ResolveAs[*preloadCommand](c, "command")
+	require.NoError(t, err)
+	require.Equal(t, "postgres://local", cmd.Repo.DSN)
+	require.Equal(t, 1, cmd.Repo.inits)
+
+	// An unrelated bean is built on demand, reusing the preloaded repo
+	report, err := ResolveAs[*preloadReport](c, "report")
+	require.NoError(t, err)
+	require.Same(t, cmd.Repo, report.Repo)
+	require.Equal(t, 1, report.inits)
+	require.Equal(t, 1, cmd.Repo.inits)
+
+	_, err = c.ResolveSafe("orphan")
+	require.EqualError(t, err, "bean 'orphan': bean `missing` is required but not registered")
+}
+
+func TestPreload_DetectsCyclesInClosure(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("loopa", reflect.TypeOf((*preloadLoopA)(nil))))
+	require.NoError(t, c.Register("loopb", reflect.TypeOf((*preloadLoopB)(nil))))
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*preloadRepo)(nil))))
+	require.NoError(t, c.RegisterInstance("dsn", "x"))
+
+	require.NoError(t, c.Preload("repo"))
+	_, err := c.ResolveSafe("loopa")
+	require.ErrorContains(t, err, "dependency cycle detected: loopa (field B) -> loopb (field A) -> loopa")
+
+	c = New()
+	require.NoError(t, c.Register("loopa", reflect.TypeOf((*preloadLoopA)(nil))))
+	require.NoError(t, c.Register("loopb", reflect.TypeOf((*preloadLoopB)(nil))))
+	require.ErrorContains(t, c.Preload("loopb"), "dependency cycle detected: loopa (field B) -> loopb (field A) -> loopa")
+}
+
+func TestPreload_RunsBuildCompleteCallbacks(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*preloadRepo)(nil))))
+	require.NoError(t, c.RegisterInstance("dsn", "x"))
+
+	var calls []string
+	require.NoError(t, c.OnBuildComplete(func(*Container) { calls = append(calls, "before") }))
+	require.NoError(t, c.Preload("repo"))
+	require.Equal(t, []string{"before"}, calls)
+
+	// The container is built, so later callbacks run immediately and Build runs none again
+	require.NoError(t, c.OnBuildComplete(func(*Container) { calls = append(calls, "after") }))
+	require.Equal(t, []string{"before", "after"}, calls)
+	require.NoError(t, c.Preload("repo"))
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"before", "after"}, calls)
+}
+
+func TestPreload_UnknownBean(t *testing.T) {
+	c := newPreloadContainer(t)
+	require.EqualError(t, c.Preload("nope"), "bean 'nope' not found")
+}
@@ -0,0 +1,61 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var priorityLog []string
+
+type priorityValidator struct{}
+
+func (p *priorityValidator) Initialize() error {
+	priorityLog = append(priorityLog, "validator")
+	return nil
+}
+
+type priorityCache struct{}
+
+func (p *priorityCache) Initialize() error {
+	priorityLog = append(priorityLog, "cache")
+	return nil
+}
+
+type priorityReady struct{}
+
+func (p *priorityReady) Initialize() error {
+	priorityLog = append(priorityLog, "ready")
+	return nil
+}
+
+// priorityGate depends on the cache, so it cannot run before it whatever its priority
+type priorityGate struct {
+	Cache *priorityCache `di.inject:"cache"`
+}
+
+func (p *priorityGate) Initialize() error {
+	priorityLog = append(priorityLog, "gate")
+	return nil
+}
+
+func TestInitPriority_OrdersIndependentBeans(t *testing.T) {
+	priorityLog = nil
+	c := New()
+	require.NoError(t, c.Register("ready", reflect.TypeOf((*priorityReady)(nil)), InitPriority(100)))
+	require.NoError(t, c.Register("cache", reflect.TypeOf((*priorityCache)(nil))))
+	require.NoError(t, c.Register("validator", reflect.TypeOf((*priorityValidator)(nil)), InitPriority(-100)))
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"validator", "cache", "ready"}, priorityLog)
+}
+
+func TestInitPriority_DependenciesDominate(t *testing.T) {
+	priorityLog = nil
+	c := New()
+	require.NoError(t, c.Register("gate", reflect.TypeOf((*priorityGate)(nil)), InitPriority(-100)))
+	require.NoError(t, c.Register("cache", reflect.TypeOf((*priorityCache)(nil)), InitPriority(100)))
+	require.NoError(t, c.Register("validator", reflect.TypeOf((*priorityValidator)(nil))))
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"validator", "cache", "gate"}, priorityLog)
+}
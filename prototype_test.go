@@ -0,0 +1,195 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type codec struct {
+	Logger *Logger `di.inject:"Logger"`
+	buf    []byte
+	inits  int
+}
+
+func (c *codec) Initialize() error {
+	c.inits++
+	c.buf = make([]byte, 0, 16)
+	return nil
+}
+
+type protoConsumerA struct {
+	Codec *codec `di.inject:"Codec,prototype"`
+}
+
+type protoConsumerB struct {
+	Codec *codec `di.inject:"codec, prototype"`
+}
+
+type sharedConsumer struct {
+	Codec *codec `di.inject:"Codec"`
+}
+
+func TestPrototypeField_FreshInstancePerReceiver(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("A", reflect.TypeOf((*protoConsumerA)(nil))))
+	require.NoError(t, c.Register("B", reflect.TypeOf((*protoConsumerB)(nil))))
+	require.NoError(t, c.Register("Shared", reflect.TypeOf((*sharedConsumer)(nil))))
+	require.NoError(t, c.Register("Codec", reflect.TypeOf((*codec)(nil))))
+	require.NoError(t, c.Register("Logger", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, c.Build())
+
+	a, err := ResolveAs[*protoConsumerA](c, "A")
+	require.NoError(t, err)
+	b, err := ResolveAs[*protoConsumerB](c, "B")
+	require.NoError(t, err)
+	shared, err := ResolveAs[*sharedConsumer](c, "Shared")
+	require.NoError(t, err)
+	singleton, err := ResolveAs[*codec](c, "Codec")
+	require.NoError(t, err)
+
+	require.NotSame(t, a.Codec, b.Codec)
+	require.NotSame(t, a.Codec, singleton)
+	require.NotSame(t, b.Codec, singleton)
+	require.Same(t, singleton, shared.Codec)
+
+	// Prototypes are fully wired and initialized, sharing the singleton dependencies.
+	logger, err := ResolveAs[*Logger](c, "Logger")
+	require.NoError(t, err)
+	for _, p := range []*codec{a.Codec, b.Codec, singleton} {
+		require.Same(t, logger, p.Logger)
+		require.Equal(t, 1, p.inits)
+	}
+}
+
+type protoCycle struct {
+	Self *protoCycle `di.inject:"ProtoCycle,prototype"`
+}
+
+func TestPrototypeField_ParticipatesInCycleDetection(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("ProtoCycle", reflect.TypeOf((*protoCycle)(nil))))
+
+	err := c.Build()
+	require.EqualError(t, err, "dependency cycle detected: protocycle (field Self) -> protocycle")
+}
+
+func TestPrototypeScope_FreshInstancePerResolution(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("Codec", reflect.TypeOf((*codec)(nil)), Prototype()))
+	require.NoError(t, c.Register("Logger", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, c.Register("Shared", reflect.TypeOf((*sharedConsumer)(nil))))
+
+	first, err := ResolveAs[*codec](c, "codec")
+	require.NoError(t, err)
+	second, err := ResolveAs[*codec](c, "codec")
+	require.NoError(t, err)
+
+	require.NotSame(t, first, second)
+	require.Same(t, first.Logger, second.Logger)
+	require.Same(t, c.Resolve("logger"), first.Logger)
+	require.Equal(t, 1, first.inits)
+	require.Equal(t, 1, second.inits)
+
+	// Receivers get their own instance too, and the template stays uninstantiated
+	shared := c.Resolve("shared").(*sharedConsumer)
+	require.NotSame(t, first, shared.Codec)
+	require.Same(t, first.Logger, shared.Codec.Logger)
+	d, err := c.DescribeBean("codec")
+	require.NoError(t, err)
+	require.True(t, d.Prototype)
+	require.False(t, d.Instantiated)
+}
+
+func TestPrototypeScope_ConcurrentResolutions(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("Codec", reflect.TypeOf((*codec)(nil)), Prototype()))
+	require.NoError(t, c.Register("Logger", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, c.Build())
+
+	const n = 16
+	codecs := make([]*codec, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codecs[i] = c.Resolve("codec").(*codec)
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[*codec]bool)
+	for _, cd := range codecs {
+		require.False(t, seen[cd])
+		seen[cd] = true
+		require.Same(t, c.Resolve("logger"), cd.Logger)
+	}
+}
+
+type failingProto struct {
+	Logger *Logger `di.inject:"Logger"`
+}
+
+func (f *failingProto) Initialize() error {
+	return errors.New("no codec available")
+}
+
+func TestPrototypeScope_ErrorCarriesBeanID(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("Failing", reflect.TypeOf((*failingProto)(nil)), Prototype()))
+	require.NoError(t, c.Register("Logger", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, c.Build())
+
+	_, err := c.ResolveSafe("failing")
+	var beanErr *BeanError
+	require.ErrorAs(t, err, &beanErr)
+	require.Equal(t, "failing", beanErr.ID)
+	require.EqualError(t, err, "bean 'failing': initializer for prototype 'failing' failed: no codec available")
+
+	_, ok := c.TryResolve("failing")
+	require.False(t, ok)
+}
+
+type lazyStore struct{}
+
+// storeOwner is the container storeSession instances resolve their store from; set by the test using it.
+var storeOwner *Container
+
+type storeSession struct {
+	store *lazyStore
+}
+
+func (s *storeSession) Initialize() error {
+	store, err := ResolveAs[*lazyStore](storeOwner, "store")
+	s.store = store
+	return err
+}
+
+func TestPrototype_InitializerResolvesDeferredBean(t *testing.T) {
+	c := New()
+	t.Cleanup(func() { storeOwner = nil })
+	storeOwner = c
+	require.NoError(t, c.Register("store", reflect.TypeOf((*lazyStore)(nil)), Lazy()))
+	require.NoError(t, c.Register("session", reflect.TypeOf((*storeSession)(nil)), Prototype()))
+	require.NoError(t, c.Build())
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := c.ResolveSafe("session")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("resolving the prototype deadlocked")
+	}
+	s, err := ResolveAs[*storeSession](c, "session")
+	require.NoError(t, err)
+	require.NotNil(t, s.store)
+}
@@ -0,0 +1,144 @@
+package iocdi
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Contains reports whether a bean is registered under the ID, without building the container or
+// instantiating anything. The ID is matched case-insensitively; an empty ID is never registered.
+func (c *Container) Contains(beanID string) bool {
+	if beanID == emptyString {
+		return false
+	}
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+	_, ok := c.registeredBeans[strings.ToLower(beanID)]
+	return ok
+}
+
+// ContainsType reports whether any registered bean's type is assignable to T, without building the container.
+func ContainsType[T any](c *Container) bool {
+	t := reflect.TypeFor[T]()
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+	for _, bn := range c.registeredBeans {
+		if bn.beanType != nil && bn.beanType.AssignableTo(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// IDOf returns the ID of the bean whose instance is the given value: pointer beans match by identity, value
+// beans (such as literal strings) by equality. When several IDs hold the same instance the one registered first
+// is returned; beans the container synthesized come after registered ones. Secret beans never match unless
+// WithResolvableSecrets is set, so a guessed value does not reveal a secret's ID. The lookup scans the
+// registered beans, so it costs O(n) per call.
+func (c *Container) IDOf(instance any) (string, bool) {
+	if instance == nil {
+		return emptyString, false
+	}
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+	found, foundOrder := emptyString, uint64(0)
+	for id, bn := range c.registeredBeans {
+		if c.withheld(id) || !sameInstance(bn.instance, instance) {
+			continue
+		}
+		order := bn.registration
+		if order == 0 {
+			order = math.MaxUint64
+		}
+		if found == emptyString || order < foundOrder || (order == foundOrder && id < found) {
+			found, foundOrder = id, order
+		}
+	}
+	return found, found != emptyString
+}
+
+// sameInstance reports whether a and b are the same instance, without panicking on values of
+// uncomparable types.
+func sameInstance(a, b any) bool {
+	if a == nil || reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	if !reflect.ValueOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// ForEach calls fn for every bean with an instance, in bean-ID order, until fn returns false. Beans that are
+// not built yet are skipped, as are secret beans unless WithResolvableSecrets is set; ForEach does not build the
+// container. fn runs under the container's read lock,
+// so it must not register, build or replace beans.
+func (c *Container) ForEach(fn func(beanID string, instance any) bool) {
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+	for _, id := range sortedKeys(c.registeredBeans) {
+		instance := c.registeredBeans[id].instance
+		if instance == nil || c.withheld(id) {
+			continue
+		}
+		if !fn(id, instance) {
+			return
+		}
+	}
+}
+
+// ForEachOf is ForEach restricted to the instances that are a T, e.g. every io.Closer.
+func ForEachOf[T any](c *Container, fn func(beanID string, v T) bool) {
+	c.ForEach(func(beanID string, instance any) bool {
+		if v, ok := instance.(T); ok {
+			return fn(beanID, v)
+		}
+		return true
+	})
+}
+
+// Dependents returns the sorted IDs of the beans that depend on the bean directly, to gauge the blast radius of
+// replacing it. It does not build the container.
+func (c *Container) Dependents(beanID string) ([]string, error) {
+	return c.dependents(beanID, false)
+}
+
+// TransitiveDependents is like Dependents but also includes the beans depending on the bean indirectly.
+func (c *Container) TransitiveDependents(beanID string) ([]string, error) {
+	return c.dependents(beanID, true)
+}
+
+func (c *Container) dependents(beanID string, transitive bool) ([]string, error) {
+	if beanID == emptyString {
+		return nil, ErrBeanIdParamIsEmpty
+	}
+	beanID = strings.ToLower(beanID)
+
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	if _, ok := c.registeredBeans[beanID]; !ok {
+		return nil, &BeanError{ID: beanID, Err: ErrBeanNotFound}
+	}
+	if transitive {
+		return c.transitiveDependents(beanID), nil
+	}
+	direct := c.dependentsIndex()[beanID]
+	if direct == nil {
+		return []string{}, nil
+	}
+	return direct, nil
+}
+
+// dependentsIndex inverts the dependency edges, mapping each bean ID to the sorted IDs of the beans depending on it.
+// Callers must hold regMu.
+func (c *Container) dependentsIndex() map[string][]string {
+	dependents := make(map[string][]string)
+	for _, rid := range sortedKeys(c.registeredBeans) {
+		for _, dep := range c.edges(c.registeredBeans[rid]) {
+			dependents[dep] = appendUnique(dependents[dep], rid)
+		}
+	}
+	return dependents
+}
@@ -0,0 +1,195 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContains(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("Mail", reflect.TypeOf((*byTypeMail)(nil))))
+
+	require.True(t, c.Contains("mail"))
+	require.True(t, c.Contains("MAIL"))
+	require.False(t, c.Contains("push"))
+	require.False(t, c.Contains(""))
+	require.Equal(t, StateRegistering, c.State())
+	require.Nil(t, c.registeredBeans["mail"].instance)
+
+	require.NoError(t, c.Build())
+	require.True(t, c.Contains("mail"))
+	require.False(t, c.Contains("push"))
+}
+
+func TestContainsType(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+
+	require.False(t, ContainsType[byTypeNotifier](c))
+	require.True(t, ContainsType[*byTypeClock](c))
+
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	require.True(t, ContainsType[byTypeNotifier](c))
+	require.Equal(t, StateRegistering, c.State())
+
+	require.NoError(t, c.Build())
+	require.True(t, ContainsType[byTypeNotifier](c))
+	require.False(t, ContainsType[*byTypeSMS](c))
+}
+
+func TestIDOf(t *testing.T) {
+	c := New()
+	shared := &byTypeSMS{}
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	require.NoError(t, c.RegisterInstance("sms", shared))
+	require.NoError(t, c.RegisterInstance("notifier", shared))
+	require.NoError(t, c.RegisterInstance("region", "eu-west-1"))
+	require.NoError(t, c.RegisterInstance("ports", []int{80, 443}))
+	require.NoError(t, c.Build())
+
+	id, ok := c.IDOf(c.Resolve("mail"))
+	require.True(t, ok)
+	require.Equal(t, "mail", id)
+
+	// Both IDs hold the same instance; the one registered first wins
+	id, ok = c.IDOf(shared)
+	require.True(t, ok)
+	require.Equal(t, "sms", id)
+
+	id, ok = c.IDOf("eu-west-1")
+	require.True(t, ok)
+	require.Equal(t, "region", id)
+
+	_, ok = c.IDOf(&byTypeMail{})
+	require.False(t, ok)
+	_, ok = c.IDOf("us-east-1")
+	require.False(t, ok)
+	_, ok = c.IDOf([]int{80, 443})
+	require.False(t, ok)
+	_, ok = c.IDOf(nil)
+	require.False(t, ok)
+}
+
+func TestIDOf_SkipsSecrets(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("dbPassword", "hunter2"))
+	require.NoError(t, c.RegisterInstance("greeting", "hello"))
+	c.MarkSecret("dbPassword")
+	require.NoError(t, c.Build())
+
+	_, ok := c.IDOf("hunter2")
+	require.False(t, ok)
+	id, ok := c.IDOf("hello")
+	require.True(t, ok)
+	require.Equal(t, "greeting", id)
+
+	c = New(WithResolvableSecrets())
+	require.NoError(t, c.RegisterInstance("dbPassword", "hunter2"))
+	c.MarkSecret("dbPassword")
+	id, ok = c.IDOf("hunter2")
+	require.True(t, ok)
+	require.Equal(t, "dbpassword", id)
+}
+
+type diamondStore struct{}
+
+type diamondReader struct {
+	Store *diamondStore `di.inject:"store"`
+}
+
+type diamondWriter struct {
+	Store *diamondStore `di.inject:"store"`
+}
+
+type diamondAPI struct {
+	Reader *diamondReader `di.inject:"reader"`
+	Writer *diamondWriter `di.inject:"writer"`
+}
+
+func TestDependents_Diamond(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("store", reflect.TypeOf((*diamondStore)(nil))))
+	require.NoError(t, c.Register("reader", reflect.TypeOf((*diamondReader)(nil))))
+	require.NoError(t, c.Register("writer", reflect.TypeOf((*diamondWriter)(nil))))
+	require.NoError(t, c.Register("api", reflect.TypeOf((*diamondAPI)(nil))))
+
+	direct, err := c.Dependents("Store")
+	require.NoError(t, err)
+	require.Equal(t, []string{"reader", "writer"}, direct)
+
+	all, err := c.TransitiveDependents("store")
+	require.NoError(t, err)
+	require.Equal(t, []string{"api", "reader", "writer"}, all)
+
+	direct, err = c.Dependents("reader")
+	require.NoError(t, err)
+	require.Equal(t, []string{"api"}, direct)
+
+	direct, err = c.Dependents("api")
+	require.NoError(t, err)
+	require.NotNil(t, direct)
+	require.Empty(t, direct)
+	all, err = c.TransitiveDependents("api")
+	require.NoError(t, err)
+	require.Empty(t, all)
+
+	_, err = c.Dependents("cache")
+	require.EqualError(t, err, "bean 'cache' not found")
+	_, err = c.TransitiveDependents("cache")
+	require.EqualError(t, err, "bean 'cache' not found")
+}
+
+func TestForEach(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("sms", reflect.TypeOf((*byTypeSMS)(nil))))
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+	require.NoError(t, c.RegisterInstance("region", "eu-west-1"))
+
+	// Before Build only the registered instance exists
+	var visited []string
+	c.ForEach(func(beanID string, instance any) bool {
+		visited = append(visited, beanID)
+		return true
+	})
+	require.Equal(t, []string{"region"}, visited)
+
+	require.NoError(t, c.Build())
+	visited = nil
+	c.ForEach(func(beanID string, instance any) bool {
+		visited = append(visited, beanID)
+		return true
+	})
+	require.Equal(t, []string{"clock", "mail", "region", "sms"}, visited)
+
+	visited = nil
+	c.ForEach(func(beanID string, instance any) bool {
+		visited = append(visited, beanID)
+		return len(visited) < 2
+	})
+	require.Equal(t, []string{"clock", "mail"}, visited)
+}
+
+func TestForEachOf(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("sms", reflect.TypeOf((*byTypeSMS)(nil))))
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+	require.NoError(t, c.Build())
+
+	var sent []string
+	ForEachOf(c, func(beanID string, n byTypeNotifier) bool {
+		sent = append(sent, n.Notify(beanID))
+		return true
+	})
+	require.Equal(t, []string{"mail:mail", "sms:sms"}, sent)
+
+	sent = nil
+	ForEachOf(c, func(beanID string, n byTypeNotifier) bool {
+		sent = append(sent, beanID)
+		return false
+	})
+	require.Equal(t, []string{"mail"}, sent)
+}
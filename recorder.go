@@ -0,0 +1,153 @@
+package iocdi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Injection records a field set by the container. See Recorder.
+type Injection struct {
+	// Receiver is the ID of the bean owning the field, and Field the field's name.
+	Receiver string
+	Field    string
+	// Dependency is the ID of the injected bean.
+	Dependency string
+	// Literal reports whether the value came from a literal source rather than a registered bean.
+	Literal bool
+}
+
+// SkipReason tells why the container left a field naming a dependency unset.
+type SkipReason int
+
+const (
+	// SkipNotSettable means the field cannot be set, e.g. because it is unexported.
+	SkipNotSettable SkipReason = iota
+	// SkipIncompatible means the dependency's type does not fit the field.
+	SkipIncompatible
+)
+
+func (r SkipReason) String() string {
+	switch r {
+	case SkipNotSettable:
+		return "not settable"
+	case SkipIncompatible:
+		return "incompatible type"
+	}
+	return "unknown"
+}
+
+// Skip records a field the container left unset. See Recorder.
+type Skip struct {
+	Receiver   string
+	Field      string
+	Dependency string
+	Reason     SkipReason
+}
+
+// TestingT is the part of *testing.T the Recorder assertions use.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Recorder collects every field the container injects from a `di.inject` tag or autowiring, and every such field
+// it skips, so tests can assert the wiring without exporting struct internals. Group collections are not
+// recorded. Install it with SetRecorder before Build. It is safe for concurrent use.
+type Recorder struct {
+	mu         sync.Mutex
+	injections []Injection
+	skips      []Skip
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// SetRecorder installs a recorder receiving every field the container injects or skips; nil removes it.
+// Without one, recording costs a single nil check.
+func (c *Container) SetRecorder(r *Recorder) {
+	c.injections.Store(r)
+}
+
+// Injections returns the recorded injections in the order they happened.
+func (r *Recorder) Injections() []Injection {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.injections)
+}
+
+// Skips returns the recorded skipped fields in the order they happened.
+func (r *Recorder) Skips() []Skip {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.skips)
+}
+
+// InjectedInto returns the injections into the bean with the ID, which is case-insensitive.
+func (r *Recorder) InjectedInto(receiverID string) []Injection {
+	receiverID = strings.ToLower(receiverID)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	injected := make([]Injection, 0)
+	for _, in := range r.injections {
+		if in.Receiver == receiverID {
+			injected = append(injected, in)
+		}
+	}
+	return injected
+}
+
+// AssertEdge reports a test error unless the named field of the receiver was injected with the dependency,
+// e.g. rec.AssertEdge(t, "servicebean", "Logger", "loggerb"). It returns whether the assertion held.
+func (r *Recorder) AssertEdge(t TestingT, receiverID, field, depID string) bool {
+	t.Helper()
+	receiverID, depID = strings.ToLower(receiverID), strings.ToLower(depID)
+	var got []string
+	for _, in := range r.InjectedInto(receiverID) {
+		if in.Field != field {
+			continue
+		}
+		if in.Dependency == depID {
+			return true
+		}
+		got = append(got, in.Dependency)
+	}
+	if len(got) == 0 {
+		t.Errorf("field '%s' of bean '%s' was not injected; expected '%s'%s", field, receiverID, depID, r.skipNote(receiverID, field))
+	} else {
+		t.Errorf("field '%s' of bean '%s' was injected with %v; expected '%s'", field, receiverID, got, depID)
+	}
+	return false
+}
+
+// skipNote explains why the field was skipped, if it was.
+func (r *Recorder) skipNote(receiverID, field string) string {
+	for _, s := range r.Skips() {
+		if s.Receiver == receiverID && s.Field == field {
+			return fmt.Sprintf(" (skipped '%s': %s)", s.Dependency, s.Reason)
+		}
+	}
+	return emptyString
+}
+
+// recordInjection emits the injection event and hands the injection to the recorder, if any.
+func (c *Container) recordInjection(e Event, dep bean) {
+	c.emit(e)
+	if r := c.injections.Load(); r != nil {
+		r.mu.Lock()
+		r.injections = append(r.injections, Injection{Receiver: e.BeanID, Field: e.Field, Dependency: e.DependencyID, Literal: dep.literal})
+		r.mu.Unlock()
+	}
+}
+
+// recordSkip hands a skipped field to the recorder, if any.
+func (c *Container) recordSkip(receiverID, field, depID string, reason SkipReason) {
+	if r := c.injections.Load(); r != nil {
+		r.mu.Lock()
+		r.skips = append(r.skips, Skip{Receiver: receiverID, Field: field, Dependency: depID, Reason: reason})
+		r.mu.Unlock()
+	}
+}
@@ -0,0 +1,72 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeT collects the errors of failed Recorder assertions.
+type fakeT struct{ errors []string }
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+type hiddenLoggerReceiver struct {
+	Logger *Logger `di.inject:"ServiceBeanLogger"`
+	hidden *Logger `di.inject:"ServiceBeanLogger"`
+}
+
+func TestRecorder_ServiceGraph(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Register("hidden", reflect.TypeOf((*hiddenLoggerReceiver)(nil))))
+	rec := NewRecorder()
+	c.SetRecorder(rec)
+	require.NoError(t, c.Build())
+
+	require.Equal(t, []Injection{
+		{Receiver: "servicebean", Field: "Config", Dependency: "servicebeanconfig"},
+		{Receiver: "servicebean", Field: "Logger", Dependency: "servicebeanlogger"},
+	}, rec.InjectedInto("ServiceBean"))
+	require.Equal(t, []Injection{
+		{Receiver: "servicebeanconfig", Field: "WorkingDir", Dependency: "workingdir", Literal: true},
+	}, rec.InjectedInto("servicebeanconfig"))
+	require.Equal(t, []Skip{
+		{Receiver: "hidden", Field: "hidden", Dependency: "servicebeanlogger", Reason: SkipNotSettable},
+	}, rec.Skips())
+
+	require.True(t, rec.AssertEdge(t, "ServiceBean", "Logger", "ServiceBeanLogger"))
+	require.True(t, rec.AssertEdge(t, "hidden", "Logger", "servicebeanlogger"))
+
+	ft := &fakeT{}
+	require.False(t, rec.AssertEdge(ft, "servicebean", "Logger", "loggerb"))
+	require.False(t, rec.AssertEdge(ft, "hidden", "hidden", "servicebeanlogger"))
+	require.Equal(t, []string{
+		"field 'Logger' of bean 'servicebean' was injected with [servicebeanlogger]; expected 'loggerb'",
+		"field 'hidden' of bean 'hidden' was not injected; expected 'servicebeanlogger' (skipped 'servicebeanlogger': not settable)",
+	}, ft.errors)
+}
+
+func TestRecorder_IncompatibleField(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Build())
+	rec := NewRecorder()
+	c.SetRecorder(rec)
+
+	var target mistypedTarget
+	require.NoError(t, c.Inject(&target))
+	require.Empty(t, rec.Injections())
+	require.Equal(t, []Skip{
+		{Receiver: "*iocdi.mistypedTarget", Field: "Config", Dependency: "servicebeanconfig", Reason: SkipIncompatible},
+	}, rec.Skips())
+
+	// Removing the recorder stops recording
+	c.SetRecorder(nil)
+	require.NoError(t, c.Inject(&target))
+	require.Len(t, rec.Skips(), 1)
+}
@@ -0,0 +1,121 @@
+package iocdi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// RefreshOption configures a single RefreshLiterals call.
+type RefreshOption func(*refreshConfig)
+
+type refreshConfig struct {
+	reinitialize bool
+	strict       bool
+}
+
+// RefreshReinitialize re-runs the initializer of every bean that received a changed value, in initialization
+// order, after all values are injected.
+func RefreshReinitialize() RefreshOption {
+	return func(cfg *refreshConfig) {
+		cfg.reinitialize = true
+	}
+}
+
+// RefreshStrict makes RefreshLiterals fail when a provider no longer supplies a literal, instead of keeping
+// its old value.
+func RefreshStrict() RefreshOption {
+	return func(cfg *refreshConfig) {
+		cfg.strict = true
+	}
+}
+
+// RefreshLiterals asks the literal providers again for every literal bean synthesized by Build, e.g. after a
+// configuration reload on SIGHUP, with the same LiteralRequest (receiver and field included), and stores the new
+// values. The fields consuming a changed value are
+// re-injected; nothing else is touched. Values the providers no longer supply keep their old value unless
+// RefreshStrict is given. A provider or validator error leaves every literal and field unchanged.
+//
+// RefreshLiterals holds the same locks as Build, so concurrent resolutions wait until it completes.
+func (c *Container) RefreshLiterals(opts ...RefreshOption) error {
+	var cfg refreshConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	c.buildLock.Lock()
+	defer c.buildLock.Unlock()
+
+	if c.closed.Load() {
+		return ErrContainerClosed
+	}
+	if !c.built.Load() {
+		return ErrContainerNotBuilt
+	}
+
+	c.regMu.Lock()
+	defer c.regMu.Unlock()
+	defer c.republishResolved()
+
+	// Fetch every value first so a failure leaves the container as it was
+	var changed []bean
+	old := make(map[string]bean)
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		if !bn.literal {
+			continue
+		}
+		fresh, ok, err := c.literalBean(bn.request)
+		if err == nil && !ok && cfg.strict {
+			err = fmt.Errorf("literal '%s' is no longer supplied", id)
+		}
+		if err != nil {
+			for oid, ob := range old {
+				c.registeredBeans[oid] = ob
+			}
+			return fmt.Errorf("refresh literals: %w", err)
+		}
+		old[id] = bn
+		if ok && !reflect.DeepEqual(fresh.instance, bn.instance) {
+			changed = append(changed, fresh)
+		}
+	}
+
+	dependents := c.dependentsIndex()
+	affected := make(map[string]bool)
+	for _, dep := range changed {
+		if dep.ephemeral {
+			delete(c.registeredBeans, dep.id) // now served per field
+		}
+		for _, rid := range dependents[dep.id] {
+			receiver := c.registeredBeans[rid]
+			if receiver.instance == nil || !receiver.initialized || receiver.prototype {
+				continue // wired with the current value when built
+			}
+			inject := c.injectIntoStruct
+			if dep.ephemeral {
+				inject = c.injectEphemeral
+			}
+			if err := inject(receiver, dep, nil); err != nil {
+				return fmt.Errorf("refresh literals: %w", err)
+			}
+			affected[rid] = true
+		}
+	}
+
+	if !cfg.reinitialize {
+		return nil
+	}
+	for _, id := range c.initOrder {
+		bn := c.registeredBeans[id]
+		if !affected[id] || !isInitializer(bn.instance) {
+			continue
+		}
+		err := initializeWithin(context.Background(), id, bn.instance, c.initTimeoutFor(bn))
+		c.emit(Event{Kind: EventInitialized, BeanID: id, Err: err})
+		if err != nil {
+			return fmt.Errorf("refresh literals: %w", &InitError{BeanID: id, Err: err})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// reloadableConfig is a literal source whose values can change between Build and RefreshLiterals.
+type reloadableConfig struct {
+	mu     sync.Mutex
+	values map[string]string
+	err    error
+}
+
+func (r *reloadableConfig) set(id, v string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values[id] = v
+}
+
+func (r *reloadableConfig) provide(id string, targetType reflect.Type) (any, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return nil, false, r.err
+	}
+	v, ok := r.values[id]
+	return v, ok, nil
+}
+
+type refreshedConfig struct {
+	WorkingDir string `di.inject:"WorkingDir"`
+	LogLevel   string `di.inject:"LogLevel"`
+	inits      int
+}
+
+func (r *refreshedConfig) Initialize() error {
+	r.inits++
+	return nil
+}
+
+func newRefreshContainer(t *testing.T) (*Container, *reloadableConfig) {
+	t.Helper()
+	src := &reloadableConfig{values: map[string]string{"workingdir": "/srv/a", "loglevel": "info"}}
+	c := New()
+	require.NoError(t, c.Register("config", reflect.TypeOf((*refreshedConfig)(nil))))
+	c.SetLiteralProvider(src.provide)
+	require.NoError(t, c.Build())
+	return c, src
+}
+
+func TestRefreshLiterals(t *testing.T) {
+	c, src := newRefreshContainer(t)
+	cfg, err := ResolveAs[*refreshedConfig](c, "config")
+	require.NoError(t, err)
+	require.Equal(t, "/srv/a", cfg.WorkingDir)
+
+	src.set("workingdir", "/srv/b")
+	require.NoError(t, c.RefreshLiterals())
+	require.Equal(t, "/srv/b", cfg.WorkingDir)
+	require.Equal(t, "info", cfg.LogLevel)
+	require.Equal(t, 1, cfg.inits)
+
+	v, err := c.ResolveSafe("workingdir")
+	require.NoError(t, err)
+	require.Equal(t, "/srv/b", v)
+
+	// With RefreshReinitialize the receiver's initializer runs again
+	src.set("loglevel", "debug")
+	require.NoError(t, c.RefreshLiterals(RefreshReinitialize()))
+	require.Equal(t, "debug", cfg.LogLevel)
+	require.Equal(t, 2, cfg.inits)
+}
+
+func TestRefreshLiterals_RepeatsTheBuildRequest(t *testing.T) {
+	src := &reloadableConfig{values: map[string]string{"workingdir": "/srv/a", "loglevel": "info"}}
+	var mu sync.Mutex
+	var requests []LiteralRequest
+	c := New()
+	require.NoError(t, c.Register("config", reflect.TypeOf((*refreshedConfig)(nil))))
+	// Serves only lookups made on behalf of no receiver; the config's fields fall through to src
+	c.AddLiteralProviderV2(func(req LiteralRequest) (any, bool, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if req.ID == "workingdir" {
+			requests = append(requests, req)
+		}
+		return "/elsewhere", req.ReceiverBeanID == emptyString, nil
+	})
+	c.AddLiteralProvider(src.provide)
+	require.NoError(t, c.Build())
+
+	src.set("workingdir", "/srv/b")
+	require.NoError(t, c.RefreshLiterals())
+	cfg, err := ResolveAs[*refreshedConfig](c, "config")
+	require.NoError(t, err)
+	require.Equal(t, "/srv/b", cfg.WorkingDir)
+
+	require.Len(t, requests, 2)
+	require.Equal(t, "config", requests[1].ReceiverBeanID)
+	require.Equal(t, "WorkingDir", requests[1].ReceiverField)
+	require.Equal(t, requests[0], requests[1])
+}
+
+func TestRefreshLiterals_NoLongerSupplied(t *testing.T) {
+	c, src := newRefreshContainer(t)
+	cfg, err := ResolveAs[*refreshedConfig](c, "config")
+	require.NoError(t, err)
+
+	src.mu.Lock()
+	delete(src.values, "workingdir")
+	src.values["loglevel"] = "warn"
+	src.mu.Unlock()
+
+	// Strict mode fails and leaves every value unchanged
+	err = c.RefreshLiterals(RefreshStrict())
+	require.EqualError(t, err, "refresh literals: literal 'workingdir' is no longer supplied")
+	require.Equal(t, "info", cfg.LogLevel)
+	v, err := c.ResolveSafe("loglevel")
+	require.NoError(t, err)
+	require.Equal(t, "info", v)
+
+	// Otherwise the old value is kept
+	require.NoError(t, c.RefreshLiterals())
+	require.Equal(t, "/srv/a", cfg.WorkingDir)
+	require.Equal(t, "warn", cfg.LogLevel)
+}
+
+func TestRefreshLiterals_Errors(t *testing.T) {
+	require.ErrorIs(t, New().RefreshLiterals(), ErrContainerNotBuilt)
+
+	c, src := newRefreshContainer(t)
+	boom := errors.New("boom")
+	src.err = boom
+	require.ErrorIs(t, c.RefreshLiterals(), boom)
+}
+
+func TestRefreshLiterals_ConcurrentResolve(t *testing.T) {
+	c, src := newRefreshContainer(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_, err := c.ResolveSafe("workingdir")
+				require.NoError(t, err)
+			}
+		}()
+	}
+	for _, dir := range []string{"/srv/b", "/srv/c", "/srv/d"} {
+		src.set("workingdir", dir)
+		require.NoError(t, c.RefreshLiterals())
+	}
+	wg.Wait()
+
+	v, err := c.ResolveSafe("workingdir")
+	require.NoError(t, err)
+	require.Equal(t, "/srv/d", v)
+}
@@ -0,0 +1,234 @@
+package iocdi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ReplaceInstance swaps the instance of a registered bean, e.g. after a configuration refresh. The instance must
+// have the bean's registered type (struct values are normalized to pointers). Only the registration is updated:
+// beans already wired to the old instance keep it until they are re-injected by Reinitialize.
+func (c *Container) ReplaceInstance(beanID string, instance any) error {
+	if beanID == emptyString {
+		return ErrBeanIdParamIsEmpty
+	}
+	if instance == nil {
+		return ErrBeanParamIsNil
+	}
+	if c.closed.Load() {
+		return ErrContainerClosed
+	}
+
+	beanID = strings.ToLower(beanID)
+
+	instanceType := reflect.TypeOf(instance)
+	if instanceType.Kind() == reflect.Struct {
+		ptr := reflect.New(instanceType)
+		ptr.Elem().Set(reflect.ValueOf(instance))
+		instance = ptr.Interface()
+		instanceType = ptr.Type()
+	}
+
+	c.buildLock.Lock()
+	defer c.buildLock.Unlock()
+	c.regMu.Lock()
+	defer c.regMu.Unlock()
+
+	bn, ok := c.registeredBeans[beanID]
+	if !ok {
+		return &BeanError{ID: beanID, Err: ErrBeanNotFound}
+	}
+	if bn.beanType != instanceType {
+		return fmt.Errorf("bean '%s' type mismatch: registered %v, replacement %v", beanID, bn.beanType, instanceType)
+	}
+	bn.instance = instance
+	bn.singleton = true
+	bn.supplied = true
+	c.registeredBeans[beanID] = bn
+	c.republishResolved()
+	return nil
+}
+
+// Reinitialize re-runs the initializer of a built bean and, when cascade is true, of every bean that depends on
+// it directly or transitively, in initialization order. Each bean is re-injected from the current instances
+// before its initializer runs, so dependents observe instances swapped in by ReplaceInstance. The first failing
+// initializer stops the sequence and is reported with its bean ID.
+//
+// Reinitialize holds the container's write lock, so concurrent resolutions wait until it completes.
+func (c *Container) Reinitialize(beanID string, cascade bool) error {
+	if beanID == emptyString {
+		return ErrBeanIdParamIsEmpty
+	}
+
+	beanID = strings.ToLower(beanID)
+
+	c.buildLock.Lock()
+	defer c.buildLock.Unlock()
+
+	if c.closed.Load() {
+		return ErrContainerClosed
+	}
+	if !c.built.Load() {
+		return ErrContainerNotBuilt
+	}
+
+	c.regMu.Lock()
+	defer c.regMu.Unlock()
+
+	if _, ok := c.registeredBeans[beanID]; !ok {
+		return &BeanError{ID: beanID, Err: ErrBeanNotFound}
+	}
+
+	targets := map[string]bool{beanID: true}
+	if cascade {
+		for _, id := range c.transitiveDependents(beanID) {
+			targets[id] = true
+		}
+	}
+
+	for _, id := range c.initOrder {
+		if !targets[id] {
+			continue
+		}
+		bn := c.registeredBeans[id]
+		if err := c.reinject(bn, false); err != nil {
+			return fmt.Errorf("reinitialize: %w", err)
+		}
+		if !isInitializer(bn.instance) {
+			continue
+		}
+		err := initializeWithin(context.Background(), id, bn.instance, c.initTimeoutFor(bn))
+		c.emit(Event{Kind: EventInitialized, BeanID: id, Err: err})
+		if err != nil {
+			return fmt.Errorf("reinitialize: %w", &InitError{BeanID: id, Err: err})
+		}
+	}
+	return nil
+}
+
+// Rewire re-runs the injection pass over every built bean using the current instances, e.g. after several
+// ReplaceInstance calls, so every dependent field references the current instance of its dependency. No instance
+// is created and no initializer runs. Beans are rewired in initialization order, dependencies first; fields that
+// already reference the right instance are simply set again. Fields taking a prototype keep their instance.
+//
+// Rewire holds the same locks as Build, so concurrent resolutions wait until it completes.
+func (c *Container) Rewire() error {
+	c.buildLock.Lock()
+	defer c.buildLock.Unlock()
+
+	if c.closed.Load() {
+		return ErrContainerClosed
+	}
+	if !c.built.Load() {
+		return ErrContainerNotBuilt
+	}
+
+	c.regMu.Lock()
+	defer c.regMu.Unlock()
+
+	for _, id := range c.initOrder {
+		bn := c.registeredBeans[id]
+		if err := c.reinject(bn, true); err != nil {
+			return fmt.Errorf("rewire: %w", err)
+		}
+		if bn.instance != nil {
+			if err := c.injectValues(bn); err != nil {
+				return fmt.Errorf("rewire: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// reinject sets the bean's dependency fields and group collections again from the current instances. Fields
+// taking a prototype receive a fresh one, unless keepPrototypes leaves them alone.
+// Callers must hold regMu.
+func (c *Container) reinject(bn bean, keepPrototypes bool) error {
+	if bn.instance == nil {
+		return nil
+	}
+	for _, depID := range c.edges(bn) {
+		depBean, ok := c.registeredBeans[depID]
+		if !ok {
+			// Ephemeral literals are never stored, so the LiteralProvider is asked again
+			var err error
+			if depBean, ok, err = c.literalBean(c.literalRequest(bn.id, depID)); err != nil {
+				return err
+			}
+		}
+		if !ok || (depBean.instance == nil && !depBean.prototype) {
+			return fmt.Errorf("dependency bean '%s' for '%s' receiver bean not instantiated", depID, bn.id)
+		}
+		if keepPrototypes && !depBean.ephemeral {
+			if depBean.prototype {
+				continue
+			}
+			if fields, ok := sharedFields(bn, depID); ok {
+				for _, field := range fields {
+					if err := c.injectIntoField(bn, depBean, nil, field); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+		}
+		inject := c.injectIntoStruct
+		if depBean.ephemeral {
+			inject = c.injectEphemeral
+		}
+		if err := inject(bn, depBean, nil); err != nil {
+			return err
+		}
+	}
+	return c.injectGroups(bn)
+}
+
+// sharedFields returns the names of the bean's fields taking the shared instance of depID when another field
+// takes a prototype of it; ok is false when no field does.
+func sharedFields(bn bean, depID string) (fields []string, ok bool) {
+	for _, fd := range bn.fields {
+		if fd.id != depID {
+			continue
+		}
+		if fd.prototype {
+			ok = true
+		} else {
+			fields = append(fields, fd.field)
+		}
+	}
+	for _, af := range bn.autowired {
+		if af.id == depID {
+			fields = append(fields, af.field)
+		}
+	}
+	return fields, ok
+}
+
+// transitiveDependents returns the IDs of every bean that depends on id directly or transitively, sorted.
+// Callers must hold regMu.
+func (c *Container) transitiveDependents(id string) []string {
+	dependents := c.dependentsIndex()
+
+	seen := make(map[string]bool)
+	queue := []string{id}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		for _, d := range dependents[next] {
+			if d != id && !seen[d] {
+				seen[d] = true
+				queue = append(queue, d)
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for d := range seen {
+		ids = append(ids, d)
+	}
+	slices.Sort(ids)
+	return ids
+}
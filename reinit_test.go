@@ -0,0 +1,206 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type reinitConfig struct {
+	URL string
+}
+
+type reinitService struct {
+	Config  *reinitConfig `di.inject:"config"`
+	seenURL string
+	inits   int
+}
+
+func (s *reinitService) Initialize() error {
+	s.inits++
+	s.seenURL = s.Config.URL
+	if s.Config.URL == "" {
+		return errors.New("empty url")
+	}
+	return nil
+}
+
+type reinitHandler struct {
+	Service *reinitService `di.inject:"service"`
+	inits   int
+}
+
+func (h *reinitHandler) Initialize() error {
+	h.inits++
+	return nil
+}
+
+func newReinitContainer(t *testing.T) *Container {
+	t.Helper()
+	c := New()
+	require.NoError(t, c.RegisterInstance("config", &reinitConfig{URL: "db://old"}))
+	require.NoError(t, c.Register("service", reflect.TypeOf((*reinitService)(nil))))
+	require.NoError(t, c.Register("handler", reflect.TypeOf((*reinitHandler)(nil))))
+	require.NoError(t, c.Register("unrelated", reflect.TypeOf((*fastInit)(nil))))
+	require.NoError(t, c.Build())
+	return c
+}
+
+func TestReinitialize_CascadeSeesReplacement(t *testing.T) {
+	c := newReinitContainer(t)
+	svc, err := ResolveAs[*reinitService](c, "service")
+	require.NoError(t, err)
+	require.Equal(t, "db://old", svc.seenURL)
+
+	fresh := &reinitConfig{URL: "db://new"}
+	require.NoError(t, c.ReplaceInstance("config", fresh))
+	// Dependents are untouched until reinitialized
+	require.Equal(t, "db://old", svc.Config.URL)
+
+	require.NoError(t, c.Reinitialize("config", true))
+	require.Same(t, fresh, svc.Config)
+	require.Equal(t, "db://new", svc.seenURL)
+	require.Equal(t, 2, svc.inits)
+
+	h, err := ResolveAs[*reinitHandler](c, "handler")
+	require.NoError(t, err)
+	require.Equal(t, 2, h.inits)
+}
+
+func TestReinitialize_WithoutCascade(t *testing.T) {
+	c := newReinitContainer(t)
+	require.NoError(t, c.Reinitialize("service", false))
+
+	svc, _ := ResolveAs[*reinitService](c, "service")
+	h, _ := ResolveAs[*reinitHandler](c, "handler")
+	require.Equal(t, 2, svc.inits)
+	require.Equal(t, 1, h.inits)
+}
+
+func TestReinitialize_ReportsFailingBean(t *testing.T) {
+	c := newReinitContainer(t)
+	require.NoError(t, c.ReplaceInstance("config", reinitConfig{}))
+	err := c.Reinitialize("config", true)
+	require.EqualError(t, err, "reinitialize: initializer for bean 'service' failed: empty url")
+
+	h, _ := ResolveAs[*reinitHandler](c, "handler")
+	require.Equal(t, 1, h.inits)
+}
+
+func TestReinitialize_Errors(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("config", &reinitConfig{}))
+	require.ErrorIs(t, c.Reinitialize("config", false), ErrContainerNotBuilt)
+	require.NoError(t, c.Build())
+	require.EqualError(t, c.Reinitialize("missing", false), "bean 'missing' not found")
+	require.ErrorContains(t, c.ReplaceInstance("config", "wrong"), "bean 'config' type mismatch")
+}
+
+func TestReinitialize_ConcurrentWithResolve(t *testing.T) {
+	c := newReinitContainer(t)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := c.ResolveSafe("service")
+			require.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			require.NoError(t, c.Reinitialize("config", true))
+		}()
+	}
+	wg.Wait()
+}
+
+type rewireCache struct {
+	Name string
+}
+
+type rewireRepo struct {
+	Config *reinitConfig `di.inject:"config"`
+	Cache  *rewireCache  `di.inject:"cache"`
+	inits  int
+}
+
+func (r *rewireRepo) Initialize() error {
+	r.inits++
+	return nil
+}
+
+type rewireAPI struct {
+	Repo  *rewireRepo  `di.inject:"repo"`
+	Cache *rewireCache `di.inject:"cache"`
+}
+
+func TestRewire_UpdatesEveryDependentField(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("config", &reinitConfig{URL: "old"}))
+	require.NoError(t, c.RegisterInstance("cache", &rewireCache{Name: "old"}))
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*rewireRepo)(nil))))
+	require.NoError(t, c.Register("api", reflect.TypeOf((*rewireAPI)(nil))))
+	require.ErrorIs(t, c.Rewire(), ErrContainerNotBuilt)
+	require.NoError(t, c.Build())
+
+	repo, _ := ResolveAs[*rewireRepo](c, "repo")
+	api, _ := ResolveAs[*rewireAPI](c, "api")
+
+	cfg := &reinitConfig{URL: "new"}
+	cache := &rewireCache{Name: "new"}
+	require.NoError(t, c.ReplaceInstance("config", cfg))
+	require.NoError(t, c.ReplaceInstance("cache", cache))
+	require.NoError(t, c.Rewire())
+
+	require.Same(t, cfg, repo.Config)
+	require.Same(t, cache, repo.Cache)
+	require.Same(t, cache, api.Cache)
+	require.Same(t, repo, api.Repo)
+	require.Equal(t, 1, repo.inits)
+
+	// Rewiring again is idempotent
+	require.NoError(t, c.Rewire())
+	require.Same(t, cache, api.Cache)
+}
+
+type rewireBuffer struct {
+	inits int
+}
+
+func (b *rewireBuffer) Initialize() error {
+	b.inits++
+	return nil
+}
+
+type rewireWorker struct {
+	Buffer   *rewireBuffer `di.inject:"buffer"`
+	Cache    *rewireCache  `di.inject:"cache"`
+	Snapshot *rewireCache  `di.inject:"cache,copy"`
+}
+
+func TestRewire_KeepsPrototypeFields(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("buffer", reflect.TypeOf((*rewireBuffer)(nil)), Prototype(), TrackPrototypes()))
+	require.NoError(t, c.RegisterInstance("cache", &rewireCache{Name: "old"}))
+	require.NoError(t, c.Register("worker", reflect.TypeOf((*rewireWorker)(nil))))
+	require.NoError(t, c.Build())
+
+	worker, err := ResolveAs[*rewireWorker](c, "worker")
+	require.NoError(t, err)
+	buffer, snapshot := worker.Buffer, worker.Snapshot
+	tracked := len(c.prototypes.tracked)
+
+	cache := &rewireCache{Name: "new"}
+	require.NoError(t, c.ReplaceInstance("cache", cache))
+	require.NoError(t, c.Rewire())
+
+	require.Same(t, cache, worker.Cache)
+	require.Same(t, buffer, worker.Buffer)
+	require.Same(t, snapshot, worker.Snapshot)
+	require.Equal(t, "old", worker.Snapshot.Name)
+	require.Equal(t, 1, buffer.inits, "no initializer runs")
+	require.Len(t, c.prototypes.tracked, tracked)
+}
@@ -0,0 +1,148 @@
+package iocdi
+
+import (
+	"time"
+)
+
+// BuildReport records what a Build did and how long each step took per bean, to find the bean responsible for a
+// slow startup. See BuildReport and LastBuildReport. A report is never modified once returned.
+type BuildReport struct {
+	// Beans lists every bean registered when the Build ended, sorted by ID, including synthesized literals.
+	Beans []BeanReport
+	// Total is the wall time of the Build, including the precheck and phase hooks.
+	Total time.Duration
+	// Instantiation, Injection and Initialization sum the per-bean durations.
+	Instantiation  time.Duration
+	Injection      time.Duration
+	Initialization time.Duration
+	// Err is the error the Build failed with, or nil.
+	Err error
+}
+
+// BeanReport records what a Build did with a single bean.
+type BeanReport struct {
+	ID string
+	// Instantiated, Injected and Initialized report whether the Build created the instance, wired its fields and
+	// ran its Initialize. Beans built earlier, deferred lazy beans and prototypes have none set.
+	Instantiated bool
+	Injected     bool
+	Initialized  bool
+	// Instantiation, Injection and Initialization are the time spent on each step. Injection covers the bean's
+	// own fields only, not the wiring of its dependencies; it includes creating per-field prototypes.
+	Instantiation  time.Duration
+	Injection      time.Duration
+	Initialization time.Duration
+	// Dependencies lists how each dependency edge was satisfied: tagged fields in field order, then autowired
+	// fields. Ephemeral literals are reported as DependencyLiteral although no bean is stored for them.
+	Dependencies []DependencyEdge
+}
+
+// BuildReport builds the container like Build and returns the report of that Build, together with its error.
+// On a container that is already built it returns the report of the Build that built it.
+func (c *Container) BuildReport() (*BuildReport, error) {
+	err := c.Build()
+	return c.LastBuildReport(), err
+}
+
+// LastBuildReport returns the report of the most recent Build or Preload, successful or not, or nil before the
+// first one. Reports are collected for every Build; timing a bean costs a few clock reads.
+func (c *Container) LastBuildReport() *BuildReport {
+	return c.lastReport.Load()
+}
+
+// buildRecorder collects the timings of a Build. Its methods are no-ops on a nil recorder, so the steps can be
+// run outside a Build.
+type buildRecorder struct {
+	start time.Time
+	beans map[string]*BeanReport
+}
+
+func newBuildRecorder() *buildRecorder {
+	return &buildRecorder{start: time.Now(), beans: make(map[string]*BeanReport)}
+}
+
+func (r *buildRecorder) bean(id string) *BeanReport {
+	br, ok := r.beans[id]
+	if !ok {
+		br = &BeanReport{ID: id}
+		r.beans[id] = br
+	}
+	return br
+}
+
+// instantiated records that the bean's instance was created, starting at start.
+func (r *buildRecorder) instantiated(id string, start time.Time) {
+	if r == nil {
+		return
+	}
+	br := r.bean(id)
+	br.Instantiated = true
+	br.Instantiation += time.Since(start)
+}
+
+// injected adds to the time spent wiring the bean's fields, starting at start.
+func (r *buildRecorder) injected(id string, start time.Time) {
+	if r == nil {
+		return
+	}
+	br := r.bean(id)
+	br.Injected = true
+	br.Injection += time.Since(start)
+}
+
+// initialized records that the bean's initializer ran, starting at start.
+func (r *buildRecorder) initialized(id string, start time.Time) {
+	if r == nil {
+		return
+	}
+	br := r.bean(id)
+	br.Initialized = true
+	br.Initialization += time.Since(start)
+}
+
+// report assembles the BuildReport from the recorded timings and the beans now registered.
+// Callers must hold regMu.
+func (c *Container) report(r *buildRecorder, err error) *BuildReport {
+	rep := &BuildReport{Total: time.Since(r.start), Err: err}
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		br := BeanReport{ID: id}
+		if recorded, ok := r.beans[id]; ok {
+			br = *recorded
+		}
+		for _, fd := range bn.fields {
+			br.Dependencies = append(br.Dependencies, DependencyEdge{
+				ID:        fd.id,
+				Field:     fd.field,
+				Prototype: fd.prototype,
+				Source:    c.satisfiedBy(fd.id),
+			})
+		}
+		for _, af := range bn.autowired {
+			br.Dependencies = append(br.Dependencies, DependencyEdge{
+				ID:        af.id,
+				Field:     af.field,
+				Autowired: true,
+				Source:    c.satisfiedBy(af.id),
+			})
+		}
+		rep.Instantiation += br.Instantiation
+		rep.Injection += br.Injection
+		rep.Initialization += br.Initialization
+		rep.Beans = append(rep.Beans, br)
+	}
+	return rep
+}
+
+// satisfiedBy tells how the dependency with the ID is, or will be, satisfied. Unlike DescribeBean it reports
+// dependencies a literal source supplies as literals before Build, and ephemeral literals after it.
+// Callers must hold regMu.
+func (c *Container) satisfiedBy(id string) DependencySource {
+	switch {
+	case c.literalSupplied(id):
+		return DependencyLiteral
+	case c.isRegistered(id):
+		return DependencyRegistered
+	}
+	return DependencyMissing
+}
@@ -0,0 +1,79 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sleepyDelay is how long sleepyInit's initializer takes.
+const sleepyDelay = 20 * time.Millisecond
+
+type sleepyInit struct {
+	Logger *Logger `di.inject:"ServiceBeanLogger"`
+}
+
+func (s *sleepyInit) Initialize() error {
+	time.Sleep(sleepyDelay)
+	return nil
+}
+
+func TestBuildReport(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*sleepyInit)(nil))))
+	require.Nil(t, c.LastBuildReport())
+
+	rep, err := c.BuildReport()
+	require.NoError(t, err)
+	require.NoError(t, rep.Err)
+
+	ids := make([]string, 0, len(rep.Beans))
+	for _, br := range rep.Beans {
+		ids = append(ids, br.ID)
+	}
+	require.Equal(t, []string{"servicebean", "servicebeanconfig", "servicebeanlogger", "slow", "workingdir"}, ids)
+
+	slow := rep.Beans[3]
+	require.True(t, slow.Instantiated)
+	require.True(t, slow.Injected)
+	require.True(t, slow.Initialized)
+	require.GreaterOrEqual(t, slow.Initialization, sleepyDelay)
+	require.GreaterOrEqual(t, rep.Initialization, slow.Initialization)
+	require.GreaterOrEqual(t, rep.Total, rep.Initialization)
+
+	require.Equal(t, []DependencyEdge{
+		{ID: "servicebeanconfig", Field: "Config", Source: DependencyRegistered},
+		{ID: "servicebeanlogger", Field: "Logger", Source: DependencyRegistered},
+	}, rep.Beans[0].Dependencies)
+	require.Equal(t, []DependencyEdge{{ID: "workingdir", Field: "WorkingDir", Source: DependencyLiteral}}, rep.Beans[1].Dependencies)
+	require.False(t, rep.Beans[1].Initialized, "Config has no initializer")
+	require.False(t, rep.Beans[4].Instantiated, "literals are not instantiated by type")
+
+	// The same report after a plain Build, and once built
+	require.Same(t, rep, c.LastBuildReport())
+	again, err := c.BuildReport()
+	require.NoError(t, err)
+	require.Same(t, rep, again)
+}
+
+type failingInit struct{}
+
+func (f *failingInit) Initialize() error { return errors.New("boom") }
+
+func TestBuildReport_Failure(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Register("broken", reflect.TypeOf((*failingInit)(nil))))
+
+	err := c.Build()
+	require.Error(t, err)
+	rep := c.LastBuildReport()
+	require.NotNil(t, rep)
+	require.Equal(t, err, rep.Err)
+	// The literal the failed attempt synthesized is still reported
+	require.Equal(t, "workingdir", rep.Beans[len(rep.Beans)-1].ID)
+	require.Equal(t, "broken", rep.Beans[0].ID)
+	require.True(t, rep.Beans[0].Initialized, "the failing initializer ran")
+}
@@ -0,0 +1,48 @@
+package iocdi
+
+// Reset returns a built container to its registered, unbuilt state so the next Build re-instantiates and
+// re-injects everything: instances the container created are discarded, beans synthesized from the
+// LiteralProvider or the MissingBeanProvider are removed and every bean is marked uninitialized. Instances
+// supplied with RegisterInstance or ReplaceInstance are kept. The next Build captures the literal providers
+// anew. Build-complete callbacks run again after the next successful Build.
+//
+// Reset does not call Stop or Destroy; stop and release the beans first if they hold resources. It is safe to
+// call while other goroutines resolve, but those resolutions may observe either the old or the rebuilt graph,
+// so callers should serialize Reset with the code using the container.
+func (c *Container) Reset() error {
+	c.buildLock.Lock()
+	defer c.buildLock.Unlock()
+
+	if c.closed.Load() {
+		return ErrContainerClosed
+	}
+
+	c.regMu.Lock()
+	defer c.regMu.Unlock()
+
+	c.unpublishResolved()
+	for id, bn := range c.registeredBeans {
+		if bn.literal || bn.synthetic {
+			delete(c.registeredBeans, id)
+			continue
+		}
+		if !bn.supplied {
+			bn.instance = nil
+			bn.singleton = false
+		}
+		bn.initialized = false
+		bn.deferred = false
+		bn.autowired = nil
+		c.registeredBeans[id] = bn
+	}
+	c.initOrder = nil
+	c.capturedLiterals = nil
+	c.started = nil
+	c.built.Store(false)
+	c.buildErr.Store(nil)
+
+	c.completed.mu.Lock()
+	c.completed.fired = false
+	c.completed.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,70 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type resetConfig struct {
+	Retries int
+}
+
+type resetService struct {
+	Config *resetConfig `di.inject:"config"`
+	Name   string       `di.inject:"name"`
+	calls  int
+}
+
+func TestReset_RebuildsFreshInstances(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		if id == "name" {
+			return "svc", true, nil
+		}
+		return nil, false, nil
+	})
+
+	cfg := &resetConfig{Retries: 3}
+	c := New()
+	require.NoError(t, c.RegisterInstance("config", cfg))
+	require.NoError(t, c.Register("service", reflect.TypeOf((*resetService)(nil))))
+	require.NoError(t, c.Build())
+
+	first, err := ResolveAs[*resetService](c, "service")
+	require.NoError(t, err)
+	first.calls = 42
+	first.Config = nil
+	require.Contains(t, c.registeredBeans, "name")
+
+	require.NoError(t, c.Reset())
+	require.False(t, c.built.Load())
+	require.NotContains(t, c.registeredBeans, "name")
+	require.Nil(t, c.registeredBeans["service"].instance)
+	d, err := c.DescribeBean("config")
+	require.NoError(t, err)
+	require.True(t, d.Instantiated)
+	require.False(t, d.Initialized)
+
+	require.NoError(t, c.Build())
+	second, err := ResolveAs[*resetService](c, "service")
+	require.NoError(t, err)
+	require.NotSame(t, first, second)
+	require.Zero(t, second.calls)
+	require.Same(t, cfg, second.Config)
+	require.Equal(t, "svc", second.Name)
+}
+
+func TestReset_RunsBuildCompleteAgain(t *testing.T) {
+	c := New()
+	runs := 0
+	require.NoError(t, c.OnBuildComplete(func(c *Container) { runs++ }))
+	require.NoError(t, c.Build())
+	require.NoError(t, c.Reset())
+	require.NoError(t, c.Build())
+	require.Equal(t, 2, runs)
+
+	require.NoError(t, c.Close())
+	require.ErrorIs(t, c.Reset(), ErrContainerClosed)
+}
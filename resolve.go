@@ -0,0 +1,230 @@
+package iocdi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ResolveByType returns the single bean whose type is assignable to T, for beans that are the only one of
+// their kind and need no ID. When several beans qualify, the one registered with Primary wins; otherwise the
+// error lists the candidate IDs. Secret beans are not candidates unless WithResolvableSecrets is set. Like
+// ResolveSafe, it builds the container if needed.
+func ResolveByType[T any](c *Container) (T, error) {
+	var zero T
+	t := reflect.TypeFor[T]()
+	if err := c.ensureBuilt(); err != nil {
+		return zero, err
+	}
+
+	c.regMu.RLock()
+	candidates, primaries := c.assignableBeans(t, emptyString)
+	candidates = slices.DeleteFunc(candidates, c.withheld)
+	primaries = slices.DeleteFunc(primaries, c.withheld)
+	c.regMu.RUnlock()
+
+	var id string
+	switch {
+	case len(candidates) == 0:
+		return zero, fmt.Errorf("no bean of type %v found", t)
+	case len(candidates) == 1:
+		id = candidates[0]
+	case len(primaries) == 1:
+		id = primaries[0]
+	case len(primaries) > 1:
+		return zero, fmt.Errorf("type %v has multiple primary beans: %s", t, strings.Join(primaries, ", "))
+	default:
+		return zero, fmt.Errorf("type %v is ambiguous; candidates: %s", t, strings.Join(candidates, ", "))
+	}
+	return ResolveAs[T](c, id)
+}
+
+// ResolveAll returns every bean whose type is assignable to T, in bean-ID order, for plugin-style code that
+// works with all implementations of an interface. No match yields an empty slice, not an error. Secret beans
+// are left out unless WithResolvableSecrets is set. Like ResolveSafe, it builds the container if needed.
+func ResolveAll[T any](c *Container) ([]T, error) {
+	if err := c.ensureBuilt(); err != nil {
+		return nil, err
+	}
+
+	c.regMu.RLock()
+	ids, _ := c.assignableBeans(reflect.TypeFor[T](), emptyString)
+	ids = slices.DeleteFunc(ids, c.withheld)
+	c.regMu.RUnlock()
+
+	all := make([]T, 0, len(ids))
+	for _, id := range ids {
+		v, err := ResolveAs[T](c, id)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, v)
+	}
+	return all, nil
+}
+
+// ResolveMany resolves several beans in one call, keyed by the requested IDs. Every ID is attempted: the error
+// joins the failures for all missing or uninitialized beans, and the map still holds the beans that resolved.
+func (c *Container) ResolveMany(beanIDs ...string) (map[string]any, error) {
+	resolved := make(map[string]any, len(beanIDs))
+	if len(beanIDs) == 0 {
+		return resolved, nil
+	}
+	// A failed Build would otherwise be reported once per ID
+	if err := c.ensureBuilt(); err != nil {
+		return resolved, err
+	}
+
+	var errs []error
+	for _, id := range beanIDs {
+		v, err := c.ResolveSafe(id)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		resolved[id] = v
+	}
+	return resolved, errors.Join(errs...)
+}
+
+// ResolveOrDefault returns the bean as T, or def when no bean is registered under the ID or it is not a T.
+// It is meant for optional integrations. Genuine failures, such as a failed Build, panic like Resolve; use
+// ResolveOrDefaultE to handle them.
+func ResolveOrDefault[T any](r Resolver, beanID string, def T) T {
+	v, err := ResolveOrDefaultE(r, beanID, def)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ResolveOrDefaultE is ResolveOrDefault returning genuine failures, such as a failed Build, as an error
+// instead of panicking. A missing (ErrBeanNotFound) or differently typed bean still yields def.
+func ResolveOrDefaultE[T any](r Resolver, beanID string, def T) (T, error) {
+	v, err := r.ResolveSafe(beanID)
+	if errors.Is(err, ErrBeanNotFound) {
+		return def, nil
+	}
+	if err != nil {
+		return def, err
+	}
+	x, ok := v.(T)
+	if !ok {
+		return def, nil
+	}
+	return x, nil
+}
+
+// TryResolve is ResolveSafe for hot paths and optional lookups: instead of an error it reports whether the
+// bean resolved, so a missing bean costs no error allocation or formatting. An empty ID, a failed Build and a
+// bean without an instance all report false.
+func (c *Container) TryResolve(beanID string) (instance any, ok bool) {
+	if s := c.metricsSink(); s != nil {
+		defer func() {
+			s.IncResolve(strings.ToLower(beanID), ok)
+		}()
+	}
+	if beanID == emptyString {
+		return nil, false
+	}
+	if err := c.ensureBuilt(); err != nil {
+		return nil, false
+	}
+
+	beanID = strings.ToLower(beanID)
+	c.regMu.RLock()
+	bn, ok := c.registeredBeans[beanID]
+	withheld := c.withheld(beanID)
+	c.regMu.RUnlock()
+	if !ok || withheld {
+		return nil, false
+	}
+
+	if bn.deferred && !bn.initialized {
+		if err := c.materialize(beanID); err != nil {
+			return nil, false
+		}
+		c.regMu.RLock()
+		bn = c.registeredBeans[beanID]
+		c.regMu.RUnlock()
+	}
+	instance = bn.instance
+	if bn.prototype {
+		var err error
+		if instance, err = c.prototypeInstance(bn); err != nil {
+			return nil, false
+		}
+	}
+	if instance == nil {
+		return nil, false
+	}
+
+	c.emit(Event{Kind: EventResolved, BeanID: beanID})
+	return instance, true
+}
+
+// TryResolveAs is TryResolve with a type assertion; a bean that is not a T reports false.
+func TryResolveAs[T any](c *Container, beanID string) (T, bool) {
+	v, ok := c.TryResolve(beanID)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	x, ok := v.(T)
+	return x, ok
+}
+
+// ResolveCtx is ResolveSafe bounded by ctx: an implicit Build runs with BuildContext(ctx), so cancellation
+// stops slow initializers and is reported with the bean that was initializing, and ctx is checked again before
+// returning. On a built container it costs little more than ResolveSafe.
+func (c *Container) ResolveCtx(ctx context.Context, beanID string) (any, error) {
+	if err := c.ensureBuiltContext(ctx); err != nil {
+		return nil, err
+	}
+	v, err := c.ResolveSafe(beanID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("resolve bean '%s': %w", strings.ToLower(beanID), err)
+	}
+	return v, nil
+}
+
+// ResolveAsCtx is ResolveAs bounded by ctx. See ResolveCtx.
+func ResolveAsCtx[T any](ctx context.Context, c *Container, beanID string) (T, error) {
+	v, err := c.ResolveCtx(ctx, beanID)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	x, ok := v.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("bean '%s' is not of requested type", beanID)
+	}
+	return x, nil
+}
+
+// ensureBuilt builds the container unless it is already built, failing once it is closed. With
+// WithNoImplicitBuild it fails with ErrContainerNotBuilt instead of building.
+func (c *Container) ensureBuilt() error {
+	return c.ensureBuiltContext(context.Background())
+}
+
+// ensureBuiltContext is ensureBuilt with the build bounded by ctx.
+func (c *Container) ensureBuiltContext(ctx context.Context) error {
+	if c.closed.Load() {
+		return ErrContainerClosed
+	}
+	if c.built.Load() {
+		return nil
+	}
+	if c.noImplicitBuild {
+		return ErrContainerNotBuilt
+	}
+	return c.BuildContext(ctx)
+}
@@ -0,0 +1,334 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type byTypeNotifier interface {
+	Notify(msg string) string
+}
+
+type byTypeMail struct{}
+
+func (m *byTypeMail) Notify(msg string) string { return "mail:" + msg }
+
+type byTypeSMS struct{}
+
+func (s *byTypeSMS) Notify(msg string) string { return "sms:" + msg }
+
+type byTypeClock struct{}
+
+func TestResolveByType_PointerToStruct(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+
+	clock, err := ResolveByType[*byTypeClock](c)
+	require.NoError(t, err)
+	require.Same(t, c.Resolve("clock"), clock)
+	require.True(t, c.IsBuilt())
+}
+
+func TestResolveByType_Interface(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+
+	n, err := ResolveByType[byTypeNotifier](c)
+	require.NoError(t, err)
+	require.Equal(t, "mail:hi", n.Notify("hi"))
+}
+
+func TestResolveByType_Ambiguous(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("sms", reflect.TypeOf((*byTypeSMS)(nil))))
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+
+	_, err := ResolveByType[byTypeNotifier](c)
+	require.EqualError(t, err, "type iocdi.byTypeNotifier is ambiguous; candidates: mail, sms")
+}
+
+func TestResolveByType_PrimaryBreaksTie(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("sms", reflect.TypeOf((*byTypeSMS)(nil)), Primary()))
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+
+	n, err := ResolveByType[byTypeNotifier](c)
+	require.NoError(t, err)
+	require.Equal(t, "sms:hi", n.Notify("hi"))
+}
+
+func TestResolveByType_NotFound(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+
+	_, err := ResolveByType[byTypeNotifier](c)
+	require.EqualError(t, err, "no bean of type iocdi.byTypeNotifier found")
+}
+
+func TestResolveByType_SkipsSecretBeans(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("sms", reflect.TypeOf((*byTypeSMS)(nil))))
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	c.MarkSecret("sms")
+
+	n, err := ResolveByType[byTypeNotifier](c)
+	require.NoError(t, err)
+	require.Equal(t, "mail:hi", n.Notify("hi"))
+
+	_, err = ResolveByType[*byTypeSMS](c)
+	require.EqualError(t, err, "no bean of type *iocdi.byTypeSMS found")
+}
+
+type byTypePush struct{}
+
+func (p *byTypePush) Notify(msg string) string { return "push:" + msg }
+
+func TestResolveAll(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("sms", reflect.TypeOf((*byTypeSMS)(nil))))
+	require.NoError(t, c.Register("push", reflect.TypeOf((*byTypePush)(nil))))
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+
+	all, err := ResolveAll[byTypeNotifier](c)
+	require.NoError(t, err)
+	require.True(t, c.IsBuilt())
+
+	sent := make([]string, 0, len(all))
+	for _, n := range all {
+		sent = append(sent, n.Notify("hi"))
+	}
+	require.Equal(t, []string{"mail:hi", "push:hi", "sms:hi"}, sent)
+}
+
+func TestResolveAll_NoMatch(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+
+	all, err := ResolveAll[byTypeNotifier](c)
+	require.NoError(t, err)
+	require.NotNil(t, all)
+	require.Empty(t, all)
+}
+
+func TestResolveMany(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("sms", reflect.TypeOf((*byTypeSMS)(nil))))
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+
+	beans, err := c.ResolveMany("sms", "queue", "mail", "clock", "cache")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bean 'queue' not found")
+	require.Contains(t, err.Error(), "bean 'cache' not found")
+
+	require.Len(t, beans, 3)
+	require.Same(t, c.Resolve("sms"), beans["sms"])
+	require.Same(t, c.Resolve("mail"), beans["mail"])
+	require.Same(t, c.Resolve("clock"), beans["clock"])
+}
+
+func TestResolveMany_Empty(t *testing.T) {
+	beans, err := New().ResolveMany()
+	require.NoError(t, err)
+	require.NotNil(t, beans)
+	require.Empty(t, beans)
+}
+
+func TestResolveOrDefault(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+	fallback := &byTypeSMS{}
+
+	// found
+	n := ResolveOrDefault[byTypeNotifier](c, "mail", fallback)
+	require.Same(t, c.Resolve("mail"), n)
+
+	// missing uses the default
+	n = ResolveOrDefault[byTypeNotifier](c, "push", fallback)
+	require.Same(t, fallback, n)
+
+	// wrong type uses the default
+	n = ResolveOrDefault[byTypeNotifier](c, "clock", fallback)
+	require.Same(t, fallback, n)
+}
+
+func TestResolveOrDefault_BuildError(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*preloadRepo)(nil))))
+	fallback := &byTypeSMS{}
+
+	n, err := ResolveOrDefaultE[byTypeNotifier](c, "push", fallback)
+	require.EqualError(t, err, "bean `dsn` is required but not registered")
+	require.Same(t, fallback, n)
+
+	require.Panics(t, func() {
+		ResolveOrDefault[byTypeNotifier](c, "push", fallback)
+	})
+}
+
+func TestTryResolve(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("Mail", reflect.TypeOf((*byTypeMail)(nil))))
+
+	v, ok := c.TryResolve("MAIL")
+	require.True(t, ok)
+	require.Same(t, c.Resolve("mail"), v)
+
+	v, ok = c.TryResolve("push")
+	require.False(t, ok)
+	require.Nil(t, v)
+
+	require.NotPanics(t, func() {
+		_, ok = c.TryResolve("")
+	})
+	require.False(t, ok)
+}
+
+func TestTryResolveAs(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	require.NoError(t, c.Register("clock", reflect.TypeOf((*byTypeClock)(nil))))
+
+	n, ok := TryResolveAs[byTypeNotifier](c, "mail")
+	require.True(t, ok)
+	require.Equal(t, "mail:hi", n.Notify("hi"))
+
+	_, ok = TryResolveAs[byTypeNotifier](c, "clock")
+	require.False(t, ok)
+	_, ok = TryResolveAs[byTypeNotifier](c, "push")
+	require.False(t, ok)
+}
+
+func TestTryResolve_BuildFailure(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*preloadRepo)(nil))))
+
+	_, ok := c.TryResolve("repo")
+	require.False(t, ok)
+}
+
+func benchmarkContainer(b *testing.B) *Container {
+	c := New()
+	require.NoError(b, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	require.NoError(b, c.Build())
+	return c
+}
+
+func BenchmarkResolveSafe_Missing(b *testing.B) {
+	c := benchmarkContainer(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = c.ResolveSafe("push")
+	}
+}
+
+func BenchmarkTryResolve_Missing(b *testing.B) {
+	c := benchmarkContainer(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = c.TryResolve("push")
+	}
+}
+
+func TestResolveSafe_SentinelErrors(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+
+	_, err := c.ResolveSafe("Push")
+	require.ErrorIs(t, err, ErrBeanNotFound)
+	require.EqualError(t, err, "bean 'push' not found")
+	var beanErr *BeanError
+	require.ErrorAs(t, err, &beanErr)
+	require.Equal(t, "push", beanErr.ID)
+
+	_, err = ResolveAs[*byTypeMail](c, "push")
+	require.ErrorIs(t, err, ErrBeanNotFound)
+
+	func() {
+		defer func() {
+			perr, ok := recover().(error)
+			require.True(t, ok)
+			require.ErrorIs(t, perr, ErrBeanNotFound)
+		}()
+		c.Resolve("push")
+	}()
+
+	// A registration whose instance is nil, as left by hand-built containers
+	c.regMu.Lock()
+	c.registeredBeans["pending"] = bean{id: "pending"}
+	c.regMu.Unlock()
+	_, err = c.ResolveSafe("pending")
+	require.ErrorIs(t, err, ErrBeanNotInitialized)
+	require.EqualError(t, err, "bean 'pending' is not initialized")
+}
+
+func TestWithNoImplicitBuild(t *testing.T) {
+	c := New(WithNoImplicitBuild())
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+
+	_, err := c.ResolveSafe("mail")
+	require.ErrorIs(t, err, ErrContainerNotBuilt)
+	_, err = ResolveAs[*byTypeMail](c, "mail")
+	require.Same(t, ErrContainerNotBuilt, err)
+	require.PanicsWithValue(t, ErrContainerNotBuilt, func() { c.Resolve("mail") })
+	require.Equal(t, StateRegistering, c.State())
+
+	require.NoError(t, c.Build())
+	m, err := ResolveAs[*byTypeMail](c, "mail")
+	require.NoError(t, err)
+	require.Same(t, c.Resolve("mail"), m)
+}
+
+// fakeResolver serves beans from a map, standing in for a container in tests.
+type fakeResolver map[string]any
+
+func (f fakeResolver) ResolveSafe(beanID string) (any, error) {
+	v, ok := f[beanID]
+	if !ok {
+		return nil, &BeanError{ID: beanID, Err: ErrBeanNotFound}
+	}
+	return v, nil
+}
+
+func (f fakeResolver) Contains(beanID string) bool {
+	_, ok := f[beanID]
+	return ok
+}
+
+func TestResolver_Fake(t *testing.T) {
+	mail := &byTypeMail{}
+	r := fakeResolver{"mail": mail, "clock": &byTypeClock{}}
+
+	n, err := ResolveAs[byTypeNotifier](r, "mail")
+	require.NoError(t, err)
+	require.Same(t, mail, n)
+
+	_, err = ResolveAs[byTypeNotifier](r, "clock")
+	require.EqualError(t, err, "bean 'clock' is not of requested type")
+
+	fallback := &byTypeSMS{}
+	require.Same(t, fallback, ResolveOrDefault[byTypeNotifier](r, "push", fallback))
+	require.Same(t, mail, ResolveOrDefault[byTypeNotifier](r, "mail", fallback))
+}
+
+func TestResolver_ScopeAndComposite(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	scope := c.NewScope()
+	require.NoError(t, scope.RegisterInstance("region", "eu-west-1"))
+
+	region, err := ResolveAs[string](scope, "region")
+	require.NoError(t, err)
+	require.Equal(t, "eu-west-1", region)
+	require.True(t, scope.Contains("mail"))
+
+	n, err := ResolveAs[byTypeNotifier](NewComposite(New(), c), "mail")
+	require.NoError(t, err)
+	require.Same(t, c.Resolve("mail"), n)
+}
@@ -0,0 +1,18 @@
+package iocdi
+
+// Resolver is the lookup side of a container, for code that only resolves beans and should not depend on the
+// registration API. Container, Scope and Composite implement it, and tests can supply a fake. ResolveAs and
+// ResolveOrDefault accept any Resolver.
+type Resolver interface {
+	// ResolveSafe returns the bean registered under the ID; a missing bean yields an error matching
+	// ErrBeanNotFound.
+	ResolveSafe(beanID string) (any, error)
+	// Contains reports whether a bean is registered under the ID.
+	Contains(beanID string) bool
+}
+
+var (
+	_ Resolver = (*Container)(nil)
+	_ Resolver = (*Scope)(nil)
+	_ Resolver = (*Composite)(nil)
+)
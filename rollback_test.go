@@ -0,0 +1,135 @@
+package iocdi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	rollbackLog  []string
+	rollbackFail bool
+)
+
+type rollbackFiles struct{}
+
+func (r *rollbackFiles) Initialize() error {
+	rollbackLog = append(rollbackLog, "init:files")
+	return nil
+}
+
+func (r *rollbackFiles) Destroy() error {
+	rollbackLog = append(rollbackLog, "destroy:files")
+	return errors.New("close files")
+}
+
+type rollbackWorkers struct {
+	Files *rollbackFiles `di.inject:"files"`
+}
+
+func (r *rollbackWorkers) Initialize() error {
+	rollbackLog = append(rollbackLog, "init:workers")
+	return nil
+}
+
+func (r *rollbackWorkers) Stop(ctx context.Context) error {
+	rollbackLog = append(rollbackLog, "stop:workers")
+	return nil
+}
+
+func (r *rollbackWorkers) Destroy() error {
+	rollbackLog = append(rollbackLog, "destroy:workers")
+	return nil
+}
+
+type rollbackServer struct {
+	Workers *rollbackWorkers `di.inject:"workers"`
+}
+
+func (r *rollbackServer) Initialize() error {
+	rollbackLog = append(rollbackLog, "init:server")
+	if rollbackFail {
+		return errors.New("port in use")
+	}
+	return nil
+}
+
+func (r *rollbackServer) Destroy() error {
+	rollbackLog = append(rollbackLog, "destroy:server")
+	return nil
+}
+
+func TestBuild_RollbackOnInitializerFailure(t *testing.T) {
+	rollbackLog, rollbackFail = nil, true
+	c := New()
+	require.NoError(t, c.Register("files", reflect.TypeOf((*rollbackFiles)(nil))))
+	require.NoError(t, c.Register("workers", reflect.TypeOf((*rollbackWorkers)(nil))))
+	require.NoError(t, c.Register("server", reflect.TypeOf((*rollbackServer)(nil))))
+
+	err := c.Build()
+	require.Error(t, err)
+	var joined interface{ Unwrap() []error }
+	require.ErrorAs(t, err, &joined)
+	primary := joined.Unwrap()[0]
+	require.EqualError(t, primary, "initializer for bean 'server' failed: port in use")
+	require.Contains(t, err.Error(), "rollback: destroyer for bean 'files' failed: close files")
+
+	// The failing bean itself is not torn down; the others are, dependents first
+	require.Equal(t, []string{
+		"init:files", "init:workers", "init:server",
+		"stop:workers", "destroy:workers", "destroy:files",
+	}, rollbackLog)
+	require.False(t, c.built.Load())
+
+	// The container can be retried once the cause is fixed
+	rollbackLog, rollbackFail = nil, false
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"init:files", "init:workers", "init:server"}, rollbackLog)
+}
+
+type retryDB struct{}
+
+type retryService struct {
+	DB      *retryDB `di.inject:"db"`
+	Region  string   `di.inject:"region"`
+	Account string   `di.inject:"account"`
+}
+
+func TestBuild_FailureRestoresBeans(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		if id == "region" {
+			return "eu-west-1", true, nil
+		}
+		return nil, false, nil
+	})
+
+	c := New()
+	require.NoError(t, c.Register("db", reflect.TypeOf((*retryDB)(nil))))
+	require.NoError(t, c.Register("service", reflect.TypeOf((*retryService)(nil))))
+
+	// account passes the precheck because a LiteralProvider is set, then fails during injection
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "account")
+
+	c.regMu.RLock()
+	require.Len(t, c.registeredBeans, 2)
+	require.Nil(t, c.registeredBeans["db"].instance)
+	require.Nil(t, c.registeredBeans["service"].instance)
+	c.regMu.RUnlock()
+
+	require.NoError(t, c.RegisterInstance("account", "acme"))
+	require.NoError(t, c.Build())
+
+	svc, err := ResolveAs[*retryService](c, "service")
+	require.NoError(t, err)
+	db, err := ResolveAs[*retryDB](c, "db")
+	require.NoError(t, err)
+	require.Same(t, db, svc.DB)
+	require.Equal(t, "eu-west-1", svc.Region)
+	require.Equal(t, "acme", svc.Account)
+}
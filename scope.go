@@ -0,0 +1,264 @@
+package iocdi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Scope is a lightweight child of a container for per-request dependencies such as a request ID, a tenant or a
+// unit of work. Beans registered on a scope are visible only to that scope: resolution consults them first and
+// falls back to the parent's beans, and the parent never sees them. A scope layers its own beans over the
+// parent's instead of copying them, so creating one per request is cheap.
+//
+// Scope-local beans may be registered after the parent is built. They are built on first resolution, wired
+// from the scope and the parent alike, validated and initialized. Prototype and copy tags only apply to
+// dependencies on parent beans.
+type Scope struct {
+	parent *Container
+
+	mu    sync.Mutex
+	beans map[string]bean
+	// built records, in build order, the scope-local beans resolved so far; Close destroys them in reverse.
+	built  []string
+	closed bool
+}
+
+// NewScope returns an empty child scope of the container. See Scope.
+func (c *Container) NewScope() *Scope {
+	return &Scope{parent: c}
+}
+
+// Register registers a scope-local bean by its reflect.Type, with the same rules as Container.Register.
+func (s *Scope) Register(beanID string, beanType reflect.Type, opts ...RegisterOption) error {
+	if beanID == emptyString {
+		return ErrBeanIdParamIsEmpty
+	}
+	if beanType == nil {
+		return ErrBeanTypeParamIsNil
+	}
+	b, err := s.parent.typeBean(beanID, beanType, opts)
+	if err != nil {
+		return err
+	}
+	return s.store(b)
+}
+
+// RegisterInstance registers a scope-local instance, with the same rules as Container.RegisterInstance.
+func (s *Scope) RegisterInstance(beanID string, instance any, opts ...RegisterOption) error {
+	if beanID == emptyString {
+		return ErrBeanIdParamIsEmpty
+	}
+	if instance == nil {
+		return ErrBeanParamIsNil
+	}
+	b, err := s.parent.instanceBean(beanID, instance, opts)
+	if err != nil {
+		return err
+	}
+	return s.store(b)
+}
+
+// store adds a scope-local bean, replacing a previous registration that has not been built yet.
+func (s *Scope) store(b bean) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrScopeClosed
+	}
+	if slices.Contains(s.built, b.id) {
+		return fmt.Errorf("%w: scoped bean '%s' is already built", ErrRegistrationClosed, b.id)
+	}
+	if s.beans == nil {
+		s.beans = make(map[string]bean)
+	}
+	s.beans[b.id] = b
+	return nil
+}
+
+// Contains reports whether the bean is registered on the scope or its parent, without building anything.
+func (s *Scope) Contains(beanID string) bool {
+	s.mu.Lock()
+	_, ok := s.beans[strings.ToLower(beanID)]
+	s.mu.Unlock()
+	return ok || s.parent.Contains(beanID)
+}
+
+// Resolve returns a bean instance by its ID or panics if it cannot be resolved.
+// Prefer ResolveSafe in production code to handle errors gracefully.
+func (s *Scope) Resolve(beanID string) any {
+	v, err := s.ResolveSafe(beanID)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// ResolveSafe returns the scope-local bean with the ID, building it on first use, or else the parent's bean,
+// building the parent if needed.
+func (s *Scope) ResolveSafe(beanID string) (any, error) {
+	if beanID == emptyString {
+		return nil, ErrBeanIdParamIsEmpty
+	}
+	beanID = strings.ToLower(beanID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrScopeClosed
+	}
+	if _, ok := s.beans[beanID]; !ok {
+		return s.parent.ResolveSafe(beanID)
+	}
+	bn, err := s.resolveLocked(beanID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return bn.instance, nil
+}
+
+// resolveLocked returns the bean for the ID, building a scope-local bean on first use and falling back to the
+// parent's bean otherwise. Dependencies follow the same edges as in the parent, including autowired fields. The
+// path lists the scope-local beans being built, for cycle detection.
+// Callers must hold s.mu.
+func (s *Scope) resolveLocked(id string, path []string) (bean, error) {
+	bn, ok := s.beans[id]
+	if !ok {
+		return s.parentBean(id)
+	}
+	if slices.Contains(s.built, id) {
+		return bn, nil
+	}
+	if slices.Contains(path, id) {
+		s.parent.regMu.RLock()
+		err := s.parent.cycleError(path, id)
+		s.parent.regMu.RUnlock()
+		return bean{}, err
+	}
+	path = append(path, id)
+
+	edges, err := s.edges(&bn)
+	if err != nil {
+		return bean{}, fmt.Errorf("scoped bean '%s': %w", id, err)
+	}
+	deps := make([]bean, 0, len(edges))
+	for _, depID := range edges {
+		dep, err := s.resolveLocked(depID, path)
+		if err != nil {
+			return bean{}, fmt.Errorf("scoped bean '%s': %w", id, err)
+		}
+		deps = append(deps, dep)
+	}
+
+	if bn.instance == nil {
+		instance, err := createInstance(id, bn.beanType)
+		if err != nil {
+			return bean{}, fmt.Errorf("scoped bean '%s': %w", id, err)
+		}
+		bn.instance = instance
+		bn.singleton = true
+	}
+	if err := s.wire(bn, deps); err != nil {
+		return bean{}, fmt.Errorf("scoped bean '%s': %w", id, err)
+	}
+
+	if v, ok := bn.instance.(Validator); ok {
+		if err := v.ValidateWiring(); err != nil {
+			return bean{}, fmt.Errorf("validation for scoped bean '%s' failed: %w", id, err)
+		}
+	}
+	if err := initializeWithin(context.Background(), id, bn.instance, s.parent.initTimeoutFor(bn)); err != nil {
+		return bean{}, fmt.Errorf("initializer for scoped bean '%s' failed: %w", id, err)
+	}
+
+	s.beans[id] = bn
+	s.built = append(s.built, id)
+	return bn, nil
+}
+
+// edges chooses the autowired fields of a scope-local bean among the parent's beans, on first use, and returns
+// its dependency IDs.
+func (s *Scope) edges(bn *bean) ([]string, error) {
+	s.parent.regMu.RLock()
+	defer s.parent.regMu.RUnlock()
+	if bn.autowired == nil {
+		autowired, err := s.parent.autowireFields(*bn)
+		if err != nil {
+			return nil, err
+		}
+		bn.autowired = autowired
+	}
+	return s.parent.edges(*bn), nil
+}
+
+// parentBean returns the parent's bean for the ID without resolving it, building the parent and a deferred
+// bean first if needed. A prototype bean is returned as registered: injection creates its instance per field.
+func (s *Scope) parentBean(id string) (bean, error) {
+	if err := s.parent.ensureBuilt(); err != nil {
+		return bean{}, err
+	}
+	s.parent.regMu.RLock()
+	bn, ok := s.parent.registeredBeans[id]
+	s.parent.regMu.RUnlock()
+	if !ok {
+		return bean{}, &BeanError{ID: id, Err: ErrBeanNotFound}
+	}
+	if bn.deferred && !bn.initialized {
+		if err := s.parent.materialize(id); err != nil {
+			return bean{}, err
+		}
+		s.parent.regMu.RLock()
+		bn = s.parent.registeredBeans[id]
+		s.parent.regMu.RUnlock()
+	}
+	return bn, nil
+}
+
+// wire injects the resolved dependencies, group members and values into a scope-local bean. The parent's read
+// lock is held because injection may create prototypes from the parent's beans.
+func (s *Scope) wire(bn bean, deps []bean) error {
+	s.parent.regMu.RLock()
+	defer s.parent.regMu.RUnlock()
+
+	for _, dep := range deps {
+		if err := s.parent.injectIntoStruct(bn, dep, nil); err != nil {
+			return err
+		}
+	}
+	if err := s.parent.injectGroups(bn); err != nil {
+		return err
+	}
+	return s.parent.injectValues(bn)
+}
+
+// Close destroys the scope-local beans built so far in reverse build order, calling Destroy on those
+// implementing Destroyer, and joins the errors. The parent's beans are left alone. Afterward the scope fails
+// registration and resolution with ErrScopeClosed. Close is idempotent; calls after the first return nil.
+func (s *Scope) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	var errs []error
+	for i := len(s.built) - 1; i >= 0; i-- {
+		id := s.built[i]
+		instance := s.beans[id].instance
+		if !isDestroyer(instance) {
+			continue
+		}
+		if err := destroyWithin(context.Background(), id, instance); err != nil {
+			errs = append(errs, fmt.Errorf("destroyer for scoped bean '%s' failed: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
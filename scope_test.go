@@ -0,0 +1,158 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type scopeDB struct {
+	destroyed bool
+}
+
+func (d *scopeDB) Destroy() error {
+	d.destroyed = true
+	return nil
+}
+
+type scopeHandler struct {
+	DB        *scopeDB `di.inject:"db"`
+	RequestID string   `di.inject:"requestID"`
+	destroyed bool
+}
+
+func (h *scopeHandler) Destroy() error {
+	h.destroyed = true
+	return nil
+}
+
+func TestScope_IsolationAndParentFallback(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("db", reflect.TypeOf((*scopeDB)(nil))))
+	require.NoError(t, c.Build())
+	db := c.Resolve("db").(*scopeDB)
+
+	const workers = 2
+	handlers := make([]*scopeHandler, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			scope := c.NewScope()
+			require.NoError(t, scope.RegisterInstance("requestID", fmt.Sprintf("req-%d", i)))
+			require.NoError(t, scope.Register("handler", reflect.TypeOf((*scopeHandler)(nil))))
+
+			h, err := scope.ResolveSafe("handler")
+			require.NoError(t, err)
+			require.Same(t, h, scope.Resolve("handler"))
+			handlers[i] = h.(*scopeHandler)
+			require.NoError(t, scope.Close())
+		}()
+	}
+	wg.Wait()
+
+	for i, h := range handlers {
+		require.Equal(t, fmt.Sprintf("req-%d", i), h.RequestID)
+		require.Same(t, db, h.DB)
+		require.True(t, h.destroyed)
+	}
+	require.NotSame(t, handlers[0], handlers[1])
+
+	// The parent never sees scope registrations, and scopes leave its beans alone
+	require.False(t, c.Contains("handler"))
+	require.False(t, c.Contains("requestid"))
+	require.False(t, db.destroyed)
+}
+
+func TestScope_Closed(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("db", reflect.TypeOf((*scopeDB)(nil))))
+	scope := c.NewScope()
+
+	// The parent is built on first resolution through the scope
+	_, err := scope.ResolveSafe("db")
+	require.NoError(t, err)
+	require.True(t, c.IsBuilt())
+
+	require.NoError(t, scope.Close())
+	require.NoError(t, scope.Close())
+	_, err = scope.ResolveSafe("db")
+	require.ErrorIs(t, err, ErrScopeClosed)
+	require.ErrorIs(t, scope.RegisterInstance("requestID", "req-1"), ErrScopeClosed)
+}
+
+func TestScope_MissingDependency(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("db", reflect.TypeOf((*scopeDB)(nil))))
+	scope := c.NewScope()
+	require.NoError(t, scope.Register("handler", reflect.TypeOf((*scopeHandler)(nil))))
+
+	_, err := scope.ResolveSafe("handler")
+	require.EqualError(t, err, "scoped bean 'handler': bean 'requestid' not found")
+}
+
+type scopeBuffer struct {
+	inits int
+}
+
+func (b *scopeBuffer) Initialize() error {
+	b.inits++
+	return nil
+}
+
+type scopeUnit struct {
+	Buffer *scopeBuffer `di.inject:"buffer"`
+}
+
+func TestScope_ParentPrototypeCreatedOnce(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("buffer", reflect.TypeOf((*scopeBuffer)(nil)), Prototype(), TrackPrototypes()))
+	require.NoError(t, c.Build())
+
+	scope := c.NewScope()
+	require.NoError(t, scope.Register("unit", reflect.TypeOf((*scopeUnit)(nil))))
+	unit, err := scope.ResolveSafe("unit")
+	require.NoError(t, err)
+	require.Equal(t, 1, unit.(*scopeUnit).Buffer.inits)
+	require.Len(t, c.prototypes.tracked, 1, "a single prototype is created for the field")
+}
+
+type scopeNotifierUser struct {
+	Notifier byTypeNotifier
+}
+
+func TestScope_AutowiresFromParent(t *testing.T) {
+	c := New(WithAutowire())
+	require.NoError(t, c.Register("mail", reflect.TypeOf((*byTypeMail)(nil))))
+	require.NoError(t, c.Build())
+
+	scope := c.NewScope()
+	require.NoError(t, scope.Register("user", reflect.TypeOf((*scopeNotifierUser)(nil))))
+	user, err := scope.ResolveSafe("user")
+	require.NoError(t, err)
+	require.Same(t, c.Resolve("mail"), user.(*scopeNotifierUser).Notifier)
+}
+
+type scopePing struct {
+	Pong *scopePong `di.inject:"pong"`
+}
+
+type scopePong struct {
+	Ping *scopePing `di.inject:"ping"`
+}
+
+func TestScope_CycleError(t *testing.T) {
+	c := New()
+	scope := c.NewScope()
+	require.NoError(t, scope.Register("ping", reflect.TypeOf((*scopePing)(nil))))
+	require.NoError(t, scope.Register("pong", reflect.TypeOf((*scopePong)(nil))))
+
+	_, err := scope.ResolveSafe("ping")
+	var cycle *CycleError
+	require.ErrorAs(t, err, &cycle)
+	require.Equal(t, []string{"ping", "pong", "ping"}, cycle.Path)
+}
@@ -0,0 +1,52 @@
+package iocdi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// redacted replaces the value of a secret bean wherever the container renders it.
+const redacted = "«redacted»"
+
+// MarkSecret marks the beans with the IDs as secret, typically literals such as API keys or DSNs with
+// passwords. Receivers are still injected with the real value, but introspection renders «redacted» in its
+// place, the container's own error messages never embed it, and resolution APIs (ResolveSafe, TryResolve,
+// ResolveAll, ForEach) do not hand it out unless WithResolvableSecrets is set: ResolveSafe fails with
+// ErrSecretBean. IDs may be marked before the beans are registered or synthesized.
+func (c *Container) MarkSecret(ids ...string) {
+	c.regMu.Lock()
+	defer c.regMu.Unlock()
+	if c.secrets == nil {
+		c.secrets = make(map[string]bool, len(ids))
+	}
+	for _, id := range ids {
+		c.secrets[strings.ToLower(id)] = true
+	}
+	c.republishResolved()
+}
+
+// WithResolvableSecrets lets the resolution APIs return beans marked with MarkSecret. Introspection still
+// redacts them.
+func WithResolvableSecrets() Option {
+	return func(c *Container) {
+		c.resolvableSecrets = true
+	}
+}
+
+// withheld reports whether resolution must not return the bean with the ID because it is secret.
+// Callers must hold regMu.
+func (c *Container) withheld(id string) bool {
+	return c.secrets[id] && !c.resolvableSecrets
+}
+
+// displayValue renders a bean value for introspection and error messages, or «redacted» for a secret bean.
+// Callers must hold regMu.
+func (c *Container) displayValue(id string, v any) string {
+	if c.secrets[id] {
+		return redacted
+	}
+	if s, ok := v.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,112 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type secretClient struct {
+	APIKey string `di.inject:"apiKey"`
+	Region string `di.inject:"region"`
+}
+
+func newSecretContainer(t *testing.T, opts ...Option) *Container {
+	t.Helper()
+	c := New(opts...)
+	require.NoError(t, c.Register("client", reflect.TypeOf((*secretClient)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		switch id {
+		case "apikey":
+			return "sk-live-123", true, nil
+		case "region":
+			return "eu-west-1", true, nil
+		}
+		return nil, false, nil
+	})
+	c.MarkSecret("APIKey")
+	return c
+}
+
+// dumpContainer renders everything the introspection APIs report about the container.
+func dumpContainer(t *testing.T, c *Container) string {
+	t.Helper()
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%+v\n", c.Beans())
+	for _, id := range c.BeanIDs() {
+		d, err := c.DescribeBean(id)
+		require.NoError(t, err)
+		fmt.Fprintf(&sb, "%+v\n", d)
+		tree, err := c.Explain(id)
+		require.NoError(t, err)
+		sb.WriteString(tree + "\n")
+	}
+	c.ForEach(func(id string, instance any) bool {
+		if _, ok := instance.(string); ok {
+			fmt.Fprintf(&sb, "%s=%v\n", id, instance)
+		}
+		return true
+	})
+	return sb.String()
+}
+
+func TestMarkSecret_Redacted(t *testing.T) {
+	c := newSecretContainer(t)
+
+	client, err := ResolveAs[*secretClient](c, "client")
+	require.NoError(t, err)
+	require.Equal(t, "sk-live-123", client.APIKey)
+
+	dump := dumpContainer(t, c)
+	require.NotContains(t, dump, "sk-live-123")
+	require.Contains(t, dump, redacted)
+	require.Contains(t, dump, `"eu-west-1"`)
+
+	d, err := c.DescribeBean("apikey")
+	require.NoError(t, err)
+	require.True(t, d.Secret)
+	require.Equal(t, redacted, d.Value)
+
+	_, err = c.ResolveSafe("apiKey")
+	require.ErrorIs(t, err, ErrSecretBean)
+	require.EqualError(t, err, "bean 'apikey' is secret and cannot be resolved")
+	_, ok := c.TryResolve("apikey")
+	require.False(t, ok)
+	all, err := ResolveAll[string](c)
+	require.NoError(t, err)
+	require.Equal(t, []string{"eu-west-1"}, all)
+}
+
+func TestMarkSecret_Resolvable(t *testing.T) {
+	c := newSecretContainer(t, WithResolvableSecrets())
+
+	v, err := c.ResolveSafe("apikey")
+	require.NoError(t, err)
+	require.Equal(t, "sk-live-123", v)
+
+	// Introspection still redacts
+	d, err := c.DescribeBean("apikey")
+	require.NoError(t, err)
+	require.Equal(t, redacted, d.Value)
+}
+
+type secretPorts struct {
+	Ports []int `di.inject:"ports"`
+}
+
+func TestMarkSecret_ErrorsOmitValue(t *testing.T) {
+	c := New(WithCommaSeparatedLiterals())
+	require.NoError(t, c.Register("server", reflect.TypeOf((*secretPorts)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "8080,hunter2", true, nil
+	})
+	c.MarkSecret("ports")
+
+	err := c.Build()
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "hunter2")
+	require.Contains(t, err.Error(), "cannot convert «redacted» to int")
+}
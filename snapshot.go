@@ -0,0 +1,40 @@
+package iocdi
+
+// publishResolved stores the instances ResolveSafe may return without locking: every initialized singleton that
+// is not withheld as a secret. Prototypes, deferred beans and unknown IDs are left to the locked path. The map
+// is never modified once published; changes publish a new one.
+// Callers must hold regMu.
+func (c *Container) publishResolved() {
+	resolved := make(map[string]any, len(c.registeredBeans))
+	for id, bn := range c.registeredBeans {
+		if bn.instance == nil || bn.prototype || (bn.deferred && !bn.initialized) || c.withheld(id) {
+			continue
+		}
+		resolved[id] = bn.instance
+	}
+	c.resolved.Store(&resolved)
+}
+
+// republishResolved replaces the published instances after a change to a built container; it does nothing
+// while none are published.
+// Callers must hold regMu.
+func (c *Container) republishResolved() {
+	if c.resolved.Load() != nil {
+		c.publishResolved()
+	}
+}
+
+// unpublishResolved sends every resolution through the locked path, e.g. while the container is rebuilt.
+func (c *Container) unpublishResolved() {
+	c.resolved.Store(nil)
+}
+
+// resolvePublished returns the published instance of the bean with the lowercase ID, if any.
+func (c *Container) resolvePublished(id string) (any, bool) {
+	resolved := c.resolved.Load()
+	if resolved == nil {
+		return nil, false
+	}
+	instance, ok := (*resolved)[id]
+	return instance, ok
+}
@@ -0,0 +1,155 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSafe_ServesPublishedInstances(t *testing.T) {
+	c := newReinitContainer(t)
+	require.NotNil(t, c.resolved.Load())
+	svc, err := c.ResolveSafe("Service")
+	require.NoError(t, err)
+	published, ok := c.resolvePublished("service")
+	require.True(t, ok)
+	require.Same(t, svc, published)
+
+	// ReplaceInstance republishes
+	fresh := &reinitConfig{URL: "db://new"}
+	require.NoError(t, c.ReplaceInstance("config", fresh))
+	cfg, err := ResolveAs[*reinitConfig](c, "config")
+	require.NoError(t, err)
+	require.Same(t, fresh, cfg)
+
+	// So does marking a secret
+	c.MarkSecret("config")
+	_, err = c.ResolveSafe("config")
+	require.ErrorIs(t, err, ErrSecretBean)
+
+	// Unknown IDs still fail through the locked path
+	_, err = c.ResolveSafe("missing")
+	require.ErrorIs(t, err, ErrBeanNotFound)
+
+	require.NoError(t, c.Reset())
+	require.Nil(t, c.resolved.Load())
+	require.NoError(t, c.Build())
+	require.NoError(t, c.Close())
+	_, err = c.ResolveSafe("service")
+	require.ErrorIs(t, err, ErrContainerClosed)
+}
+
+func TestResolveSafe_PublishesLazyAndExtendedBeans(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("config", &reinitConfig{URL: "db://old"}))
+	require.NoError(t, c.Register("service", reflect.TypeOf((*reinitService)(nil)), Lazy()))
+	require.NoError(t, c.Build())
+	_, ok := c.resolvePublished("service")
+	require.False(t, ok, "deferred beans are left to the locked path")
+
+	svc, err := c.ResolveSafe("service")
+	require.NoError(t, err)
+	published, ok := c.resolvePublished("service")
+	require.True(t, ok)
+	require.Same(t, svc, published)
+
+	require.NoError(t, c.Extend(func(c *Container) error {
+		require.Nil(t, c.resolved.Load(), "resolution waits while the container is extended")
+		return c.Register("handler", reflect.TypeOf((*reinitHandler)(nil)))
+	}))
+	_, ok = c.resolvePublished("handler")
+	require.True(t, ok)
+
+	require.Error(t, c.Extend(func(c *Container) error { return errors.New("abandoned") }))
+	_, ok = c.resolvePublished("handler")
+	require.True(t, ok, "a failed extension publishes the previous instances again")
+}
+
+func TestResolveSafe_ConcurrentWithReplaceInstance(t *testing.T) {
+	c := newReinitContainer(t)
+	old, err := ResolveAs[*reinitConfig](c, "config")
+	require.NoError(t, err)
+	replacements := make([]*reinitConfig, 50)
+	known := map[*reinitConfig]bool{old: true}
+	for i := range replacements {
+		replacements[i] = &reinitConfig{URL: "db://replaced"}
+		known[replacements[i]] = true
+	}
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				cfg, err := ResolveAs[*reinitConfig](c, "config")
+				if err != nil || !known[cfg] {
+					t.Errorf("resolved %v, %v", cfg, err)
+					return
+				}
+			}
+		}()
+	}
+	for _, cfg := range replacements {
+		require.NoError(t, c.ReplaceInstance("config", cfg))
+	}
+	close(done)
+	wg.Wait()
+
+	cfg, err := ResolveAs[*reinitConfig](c, "config")
+	require.NoError(t, err)
+	require.Same(t, replacements[len(replacements)-1], cfg)
+}
+
+// BenchmarkResolveSafe_16Goroutines compares, from at least 16 goroutines, the published, lock-free lookup with
+// the locked path ResolveSafe takes while no instances are published.
+func BenchmarkResolveSafe_16Goroutines(b *testing.B) {
+	for _, bc := range []struct {
+		name    string
+		publish bool
+	}{
+		{"locked", false},
+		{"published", true},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			c := benchmarkContainer(b)
+			if !bc.publish {
+				c.unpublishResolved()
+			}
+			b.SetParallelism((16 + runtime.GOMAXPROCS(0) - 1) / runtime.GOMAXPROCS(0))
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_, _ = c.ResolveSafe("mail")
+				}
+			})
+		})
+	}
+}
+
+type lazyServiceTarget struct {
+	Service *reinitService `di.inject:"service"`
+}
+
+func TestInject_PublishesMaterializedBeans(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("config", &reinitConfig{URL: "db://old"}))
+	require.NoError(t, c.Register("service", reflect.TypeOf((*reinitService)(nil)), Lazy()))
+	require.NoError(t, c.Build())
+
+	var target lazyServiceTarget
+	require.NoError(t, c.Inject(&target))
+	published, ok := c.resolvePublished("service")
+	require.True(t, ok)
+	require.Same(t, target.Service, published)
+}
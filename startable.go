@@ -0,0 +1,18 @@
+package iocdi
+
+import "context"
+
+// Startable is an optional interface for beans that run in the background once the whole graph is ready,
+// such as servers and consumers.
+//
+// The container calls Start(ctx) from Start(), in dependency order, after Build has completed.
+type Startable interface {
+	Start(ctx context.Context) error
+}
+
+// Stoppable is an optional interface for beans that must be stopped on shutdown.
+//
+// The container calls Stop(ctx) from Stop(), in reverse dependency order, on the beans reached by Start().
+type Stoppable interface {
+	Stop(ctx context.Context) error
+}
@@ -0,0 +1,114 @@
+package iocdi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	startLog    []string
+	startFailOn string
+	startCancel context.CancelFunc
+)
+
+func recordStart(name string) error {
+	startLog = append(startLog, "start:"+name)
+	if startCancel != nil && name == "broker" {
+		startCancel()
+	}
+	if name == startFailOn {
+		return errors.New(name + " unavailable")
+	}
+	return nil
+}
+
+func recordStop(name string) error {
+	startLog = append(startLog, "stop:"+name)
+	return nil
+}
+
+type startBroker struct{}
+
+func (b *startBroker) Start(ctx context.Context) error { return recordStart("broker") }
+func (b *startBroker) Stop(ctx context.Context) error  { return recordStop("broker") }
+
+type startConsumer struct {
+	Broker *startBroker `di.inject:"broker"`
+}
+
+func (s *startConsumer) Start(ctx context.Context) error { return recordStart("consumer") }
+func (s *startConsumer) Stop(ctx context.Context) error  { return recordStop("consumer") }
+
+// startServer is only Startable; it has nothing to stop
+type startServer struct {
+	Consumer *startConsumer `di.inject:"consumer"`
+}
+
+func (s *startServer) Start(ctx context.Context) error { return recordStart("server") }
+
+func newStartContainer(t *testing.T) *Container {
+	t.Helper()
+	startLog, startFailOn, startCancel = nil, "", nil
+	c := New()
+	require.NoError(t, c.Register("server", reflect.TypeOf((*startServer)(nil))))
+	require.NoError(t, c.Register("consumer", reflect.TypeOf((*startConsumer)(nil))))
+	require.NoError(t, c.Register("broker", reflect.TypeOf((*startBroker)(nil))))
+	return c
+}
+
+func TestStartStop_Order(t *testing.T) {
+	c := newStartContainer(t)
+	require.NoError(t, c.Start(context.Background()))
+	require.Equal(t, []string{"start:broker", "start:consumer", "start:server"}, startLog)
+
+	// Starting again does not restart beans
+	require.NoError(t, c.Start(context.Background()))
+	require.Len(t, startLog, 3)
+
+	startLog = nil
+	require.NoError(t, c.Stop(context.Background()))
+	require.Equal(t, []string{"stop:consumer", "stop:broker"}, startLog)
+}
+
+func TestStart_FailureStopsStartedBeans(t *testing.T) {
+	c := newStartContainer(t)
+	startFailOn = "server"
+	err := c.Start(context.Background())
+	require.EqualError(t, err, "start for bean 'server' failed: server unavailable")
+	require.Equal(t, []string{"start:broker", "start:consumer", "start:server", "stop:consumer", "stop:broker"}, startLog)
+
+	// Nothing is left to stop
+	startLog = nil
+	require.NoError(t, c.Stop(context.Background()))
+	require.Empty(t, startLog)
+}
+
+func TestStart_CancellationAbortsRemainingStarts(t *testing.T) {
+	c := newStartContainer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	startCancel = cancel
+
+	err := c.Start(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Contains(t, err.Error(), "start of bean 'consumer' aborted")
+	require.Equal(t, []string{"start:broker", "stop:broker"}, startLog)
+}
+
+func TestStop_CancelledContextLeavesBeansRunning(t *testing.T) {
+	c := newStartContainer(t)
+	require.NoError(t, c.Start(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	startLog = nil
+	err := c.Stop(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, startLog)
+
+	require.NoError(t, c.Stop(context.Background()))
+	require.Equal(t, []string{"stop:consumer", "stop:broker"}, startLog)
+}
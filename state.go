@@ -0,0 +1,63 @@
+package iocdi
+
+// ContainerState describes where a container is in its lifecycle. See Container.State.
+type ContainerState int
+
+const (
+	// StateRegistering is the initial state: beans may be registered and Build has not been attempted.
+	StateRegistering ContainerState = iota
+	// StateBuilding means a Build is in progress.
+	StateBuilding
+	// StateBuilt means Build (or Preload) succeeded; registration is closed.
+	StateBuilt
+	// StateBuildFailed means the last Build failed; BuildError returns its error. Registration is still open.
+	StateBuildFailed
+	// StateClosed means Close was called; the container can no longer be used.
+	StateClosed
+)
+
+func (s ContainerState) String() string {
+	switch s {
+	case StateRegistering:
+		return "Registering"
+	case StateBuilding:
+		return "Building"
+	case StateBuilt:
+		return "Built"
+	case StateBuildFailed:
+		return "BuildFailed"
+	case StateClosed:
+		return "Closed"
+	}
+	return "Unknown"
+}
+
+// State reports the container's lifecycle state, so code handed a container can tell whether it may still
+// register beans, needs building, or failed a previous Build.
+func (c *Container) State() ContainerState {
+	switch {
+	case c.closed.Load():
+		return StateClosed
+	case c.building.Load():
+		return StateBuilding
+	case c.built.Load():
+		return StateBuilt
+	case c.buildErr.Load() != nil:
+		return StateBuildFailed
+	}
+	return StateRegistering
+}
+
+// IsBuilt reports whether the container has been built and not closed.
+func (c *Container) IsBuilt() bool {
+	return c.State() == StateBuilt
+}
+
+// BuildError returns the error of the last failed Build, or nil if no Build failed since the last successful
+// one (or since Reset).
+func (c *Container) BuildError() error {
+	if err := c.buildErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestState_Lifecycle(t *testing.T) {
+	c := New()
+	require.Equal(t, StateRegistering, c.State())
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*preloadRepo)(nil))))
+	require.Equal(t, StateRegistering, c.State())
+	require.NoError(t, c.BuildError())
+
+	// repo requires dsn, which is missing
+	require.Error(t, c.Build())
+	require.Equal(t, StateBuildFailed, c.State())
+	require.EqualError(t, c.BuildError(), "bean `dsn` is required but not registered")
+	require.False(t, c.IsBuilt())
+
+	// The fix is registered while the failure is still reported
+	require.NoError(t, c.RegisterInstance("dsn", "postgres://"))
+	require.Equal(t, StateBuildFailed, c.State())
+
+	c.OnPhase(PhasePreInit, func(c *Container) error {
+		require.Equal(t, StateBuilding, c.State())
+		return nil
+	})
+	require.NoError(t, c.Build())
+	require.Equal(t, StateBuilt, c.State())
+	require.True(t, c.IsBuilt())
+	require.NoError(t, c.BuildError())
+
+	require.NoError(t, c.Close())
+	require.Equal(t, StateClosed, c.State())
+	require.False(t, c.IsBuilt())
+}
+
+func TestContainerState_String(t *testing.T) {
+	require.Equal(t, "BuildFailed", StateBuildFailed.String())
+	require.Equal(t, "Unknown", ContainerState(42).String())
+}
@@ -0,0 +1,178 @@
+package iocdi
+
+import (
+	"errors"
+	"slices"
+	"sort"
+)
+
+// defaultTopN is the number of beans GraphStats ranks by fan-in and fan-out unless TopN says otherwise.
+const defaultTopN = 5
+
+// StatsOption configures a single GraphStats call.
+type StatsOption func(*statsConfig)
+
+type statsConfig struct {
+	topN int
+}
+
+// TopN sets how many beans GraphStats ranks by fan-in and fan-out; the default is 5.
+func TopN(n int) StatsOption {
+	return func(cfg *statsConfig) {
+		cfg.topN = max(n, 0)
+	}
+}
+
+// GraphStats summarizes the shape of the dependency graph. See Container.GraphStats.
+type GraphStats struct {
+	// Beans is the number of registered beans and Edges the number of distinct dependency edges between them.
+	Beans int
+	Edges int
+	// MaxDepth is the number of edges on the longest dependency chain, and DeepestPath the beans along it,
+	// from the receiver down to the last dependency.
+	MaxDepth    int
+	DeepestPath []string
+	// FanIn ranks the beans with the most dependents and FanOut those with the most dependencies, by count and
+	// then by ID. Beans with no edge are not ranked.
+	FanIn  []BeanCount
+	FanOut []BeanCount
+	// Components is the number of groups of beans connected by edges in either direction.
+	Components int
+	// Cycles lists each dependency cycle once, starting at its smallest bean ID and ending with it again.
+	Cycles [][]string
+}
+
+// BeanCount pairs a bean ID with a count, such as its number of dependents.
+type BeanCount struct {
+	ID    string
+	Count int
+}
+
+// GraphStats computes statistics over the declared dependency edges, for architecture reviews: the size of the
+// graph, its deepest dependency chain, the beans with the most dependents and dependencies and the number of
+// connected components. Only edges between registered beans count, and edges from autowired fields are only
+// known after Build. Cycles are reported rather than followed; the edge closing a cycle does not add to the
+// depth. It does not build the container.
+func (c *Container) GraphStats(opts ...StatsOption) GraphStats {
+	cfg := statsConfig{topN: defaultTopN}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	ids := sortedKeys(c.registeredBeans)
+	deps := make(map[string][]string, len(ids))
+	fanIn := make(map[string]int)
+	stats := GraphStats{Beans: len(ids)}
+	for _, id := range ids {
+		for _, dep := range c.edges(c.registeredBeans[id]) {
+			if c.isRegistered(dep) && !slices.Contains(deps[id], dep) {
+				deps[id] = append(deps[id], dep)
+				fanIn[dep]++
+			}
+		}
+		stats.Edges += len(deps[id])
+	}
+	fanOut := make(map[string]int, len(deps))
+	for id, d := range deps {
+		fanOut[id] = len(d)
+	}
+
+	stats.DeepestPath = deepestPath(ids, deps)
+	if len(stats.DeepestPath) > 0 {
+		stats.MaxDepth = len(stats.DeepestPath) - 1
+	}
+	stats.FanIn = topCounts(fanIn, cfg.topN)
+	stats.FanOut = topCounts(fanOut, cfg.topN)
+	stats.Components = components(ids, deps)
+	for _, err := range c.declaredCycles() {
+		var cycle *CycleError
+		if errors.As(err, &cycle) {
+			stats.Cycles = append(stats.Cycles, cycle.Path)
+		}
+	}
+	return stats
+}
+
+// deepestPath returns the longest dependency chain, preferring the smallest IDs among chains of equal length.
+// Edges back onto the chain being explored are ignored.
+func deepestPath(ids []string, deps map[string][]string) []string {
+	longest := make(map[string][]string, len(ids)) // deepest chain starting at each finished bean
+	onPath := make(map[string]bool)
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		if chain, ok := longest[id]; ok {
+			return chain
+		}
+		onPath[id] = true
+		var best []string
+		for _, dep := range slices.Sorted(slices.Values(deps[id])) {
+			if onPath[dep] {
+				continue
+			}
+			if chain := visit(dep); len(chain) > len(best) {
+				best = chain
+			}
+		}
+		onPath[id] = false
+		longest[id] = append([]string{id}, best...)
+		return longest[id]
+	}
+
+	var deepest []string
+	for _, id := range ids {
+		if chain := visit(id); len(chain) > len(deepest) {
+			deepest = chain
+		}
+	}
+	return deepest
+}
+
+// topCounts returns up to n of the non-zero counts, largest first and then by ID.
+func topCounts(counts map[string]int, n int) []BeanCount {
+	ranked := make([]BeanCount, 0, len(counts))
+	for id, count := range counts {
+		if count > 0 {
+			ranked = append(ranked, BeanCount{ID: id, Count: count})
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Count != ranked[j].Count {
+			return ranked[i].Count > ranked[j].Count
+		}
+		return ranked[i].ID < ranked[j].ID
+	})
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
+
+// components counts the groups of beans connected by edges, ignoring their direction.
+func components(ids []string, deps map[string][]string) int {
+	parent := make(map[string]string, len(ids))
+	var find func(id string) string
+	find = func(id string) string {
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+	for _, id := range ids {
+		parent[id] = id
+	}
+
+	n := len(ids)
+	for _, id := range ids {
+		for _, dep := range deps[id] {
+			if a, b := find(id), find(dep); a != b {
+				parent[a] = b
+				n--
+			}
+		}
+	}
+	return n
+}
@@ -0,0 +1,75 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// dependsOn returns a pointer-to-struct type with one `di.inject` field per dependency ID.
+func dependsOn(ids ...string) reflect.Type {
+	fields := make([]reflect.StructField, 0, len(ids))
+	for i, id := range ids {
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Dep%d", i),
+			Type: reflect.TypeOf((*Logger)(nil)),
+			Tag:  reflect.StructTag(fmt.Sprintf(`di.inject:"%s"`, id)),
+		})
+	}
+	return reflect.PointerTo(reflect.StructOf(fields))
+}
+
+func TestGraphStats_DiamondAndChain(t *testing.T) {
+	c := New()
+	beans := map[string]reflect.Type{
+		"top":    dependsOn("left", "right"),
+		"left":   dependsOn("bottom"),
+		"right":  dependsOn("bottom"),
+		"bottom": dependsOn(),
+		"c1":     dependsOn("c2"),
+		"c2":     dependsOn("c3"),
+		"c3":     dependsOn("c4"),
+		"c4":     dependsOn("c5"),
+		"c5":     dependsOn(),
+		"solo":   dependsOn(),
+	}
+	for id, typ := range beans {
+		require.NoError(t, c.Register(id, typ))
+	}
+
+	stats := c.GraphStats(TopN(3))
+	require.Equal(t, 10, stats.Beans)
+	require.Equal(t, 8, stats.Edges)
+	require.Equal(t, 4, stats.MaxDepth)
+	require.Equal(t, []string{"c1", "c2", "c3", "c4", "c5"}, stats.DeepestPath)
+	require.Equal(t, []BeanCount{{"bottom", 2}, {"c2", 1}, {"c3", 1}}, stats.FanIn)
+	require.Equal(t, []BeanCount{{"top", 2}, {"c1", 1}, {"c2", 1}}, stats.FanOut)
+	require.Equal(t, 3, stats.Components)
+	require.Empty(t, stats.Cycles)
+
+	require.Len(t, c.GraphStats().FanIn, defaultTopN)
+}
+
+func TestGraphStats_Cycles(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("b", reflect.TypeOf((*cycleB)(nil))))
+	require.NoError(t, c.Register("a", reflect.TypeOf((*cycleA)(nil))))
+	require.NoError(t, c.Register("aself", reflect.TypeOf((*selfCycleA)(nil))))
+
+	stats := c.GraphStats()
+	require.Equal(t, 3, stats.Edges)
+	require.Equal(t, 1, stats.MaxDepth)
+	require.Equal(t, []string{"a", "b"}, stats.DeepestPath)
+	require.Equal(t, 2, stats.Components)
+	require.Equal(t, [][]string{{"a", "b", "a"}, {"aself", "aself"}}, stats.Cycles)
+}
+
+func TestGraphStats_Empty(t *testing.T) {
+	stats := New().GraphStats()
+	require.Zero(t, stats.Beans)
+	require.Zero(t, stats.MaxDepth)
+	require.Empty(t, stats.DeepestPath)
+	require.Zero(t, stats.Components)
+}
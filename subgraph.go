@@ -0,0 +1,102 @@
+package iocdi
+
+import (
+	"maps"
+	"reflect"
+	"strings"
+)
+
+// BuildSubgraph returns a new, unbuilt container holding only the root bean and its transitive dependencies,
+// for building part of the graph in isolation while bisecting wiring problems. Registrations are copied, not
+// instances: beans registered by type start without an instance, while struct instances given to RegisterInstance
+// are copied (shallowly, as for the Copy prototype mode) so the subgraph injects into its own copies. Other
+// supplied values, such as strings, are shared. The new container has the same options, converters and logger,
+// but no subscribers, hooks or callbacks. Dependencies left to the LiteralProvider or the MissingBeanProvider
+// stay unregistered so the providers satisfy them in the subgraph too.
+//
+// The source container may be built or not, and is left untouched.
+func (c *Container) BuildSubgraph(rootID string) (*Container, error) {
+	if rootID == emptyString {
+		return nil, ErrBeanIdParamIsEmpty
+	}
+
+	rootID = strings.ToLower(rootID)
+
+	c.regMu.RLock()
+	defer c.regMu.RUnlock()
+
+	if _, ok := c.registeredBeans[rootID]; !ok {
+		return nil, &BeanError{ID: rootID, Err: ErrBeanNotFound}
+	}
+
+	closure := make(map[string]bool)
+	var visit func(id string) error
+	visit = func(id string) error {
+		bn, ok := c.registeredBeans[id]
+		if !ok || closure[id] || bn.literal || bn.synthetic {
+			return nil
+		}
+		closure[id] = true
+
+		// Autowired edges are only known after Build, so choose them here for an unbuilt source
+		if !bn.initialized {
+			autowired, err := c.autowireFields(bn)
+			if err != nil {
+				return err
+			}
+			bn.autowired = autowired
+		}
+		for _, dep := range c.edges(bn) {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := visit(rootID); err != nil {
+		return nil, err
+	}
+
+	sub := &Container{
+		requiredDependency: make(map[string]reflect.Type),
+		registeredBeans:    make(map[string]bean, len(closure)),
+		converters:         maps.Clone(c.converters),
+		autowire:           c.autowire,
+		strictAutowire:     c.strictAutowire,
+		strictGroups:       c.strictGroups,
+		strictWarnings:     c.strictWarnings,
+		eagerCycleCheck:    c.eagerCycleCheck,
+		lenientTags:        c.lenientTags,
+		noImplicitBuild:    c.noImplicitBuild,
+		splitLiterals:      c.splitLiterals,
+		validateStrings:    c.validateStrings,
+		literalTemplates:   c.literalTemplates,
+		eagerLiterals:      c.eagerLiterals,
+		staticLiterals:     c.staticLiterals,
+		secrets:            maps.Clone(c.secrets),
+		resolvableSecrets:  c.resolvableSecrets,
+		initTimeout:        c.initTimeout,
+		healthTimeout:      c.healthTimeout,
+		healthConcurrency:  c.healthConcurrency,
+		envLookuper:        c.envLookuper,
+	}
+	sub.literalProviders.Store(c.literalProviders.Load())
+	sub.missingBeanProvider.Store(c.missingBeanProvider.Load())
+	sub.literalValidator.Store(c.literalValidator.Load())
+	sub.logger.Store(c.logger.Load())
+	for id := range closure {
+		bn := c.registeredBeans[id]
+		if !bn.supplied {
+			bn.instance = nil
+			bn.singleton = false
+		} else if bn.beanType.Kind() == reflect.Ptr && bn.beanType.Elem().Kind() == reflect.Struct {
+			bn.instance = cloneInstance(bn.instance, copyShallowPointers)
+		}
+		bn.initialized = false
+		bn.deferred = false
+		bn.autowired = nil
+		sub.registeredBeans[id] = bn
+		sub.requireDependencies(bn.fields)
+	}
+	return sub, nil
+}
@@ -0,0 +1,109 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type subDB struct {
+	URL string `di.inject:"dburl"`
+}
+
+type subCache struct{}
+
+type subRepo struct {
+	DB    *subDB    `di.inject:"db"`
+	Cache *subCache `di.inject:"cache"`
+}
+
+type subUnrelated struct {
+	Repo *subRepo `di.inject:"repo"`
+}
+
+func TestBuildSubgraph_ExtractsClosure(t *testing.T) {
+	t.Cleanup(func() { SetLiteralProvider(nil) })
+	SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "db://sub", id == "dburl", nil
+	})
+
+	c := New()
+	require.NoError(t, c.Register("db", reflect.TypeOf((*subDB)(nil))))
+	require.NoError(t, c.Register("cache", reflect.TypeOf((*subCache)(nil))))
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*subRepo)(nil))))
+	for _, id := range []string{"api", "admin", "jobs", "metrics", "mailer", "billing", "audit"} {
+		require.NoError(t, c.Register(id, reflect.TypeOf((*subUnrelated)(nil))))
+	}
+	require.Len(t, c.registeredBeans, 10)
+
+	sub, err := c.BuildSubgraph("Repo")
+	require.NoError(t, err)
+	require.Equal(t, []string{"cache", "db", "repo"}, sortedKeys(sub.registeredBeans))
+	require.NoError(t, sub.Build())
+
+	repo, err := ResolveAs[*subRepo](sub, "repo")
+	require.NoError(t, err)
+	require.Equal(t, "db://sub", repo.DB.URL)
+	_, err = sub.ResolveSafe("api")
+	require.EqualError(t, err, "bean 'api' not found")
+
+	// The source is untouched
+	require.False(t, c.built.Load())
+	require.Nil(t, c.registeredBeans["repo"].instance)
+}
+
+func TestBuildSubgraph_FromBuiltSource(t *testing.T) {
+	cache := &subCache{}
+	c := New()
+	require.NoError(t, c.RegisterInstance("cache", cache))
+	require.NoError(t, c.RegisterInstance("dburl", "db://built"))
+	require.NoError(t, c.Register("db", reflect.TypeOf((*subDB)(nil))))
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*subRepo)(nil))))
+	require.NoError(t, c.Build())
+
+	sub, err := c.BuildSubgraph("repo")
+	require.NoError(t, err)
+	require.NoError(t, sub.Build())
+
+	original, _ := ResolveAs[*subRepo](c, "repo")
+	copied, _ := ResolveAs[*subRepo](sub, "repo")
+	require.NotSame(t, original, copied)
+	require.NotSame(t, original.DB, copied.DB)
+}
+
+type subService struct {
+	Repo *subRepo `di.inject:"repo"`
+}
+
+func TestBuildSubgraph_LeavesSuppliedInstancesAlone(t *testing.T) {
+	svc := &subService{}
+	c := New()
+	require.NoError(t, c.RegisterInstance("svc", svc))
+	require.NoError(t, c.RegisterInstance("dburl", "db://built"))
+	require.NoError(t, c.Register("db", reflect.TypeOf((*subDB)(nil))))
+	require.NoError(t, c.Register("cache", reflect.TypeOf((*subCache)(nil))))
+	require.NoError(t, c.Register("repo", reflect.TypeOf((*subRepo)(nil))))
+	require.NoError(t, c.Build())
+	repo, err := ResolveAs[*subRepo](c, "repo")
+	require.NoError(t, err)
+	require.Same(t, repo, svc.Repo)
+
+	sub, err := c.BuildSubgraph("svc")
+	require.NoError(t, err)
+	require.NoError(t, sub.Build())
+
+	// The source keeps its wiring; the subgraph wired a copy of the supplied instance
+	require.Same(t, repo, svc.Repo)
+	copied, err := ResolveAs[*subService](sub, "svc")
+	require.NoError(t, err)
+	require.NotSame(t, svc, copied)
+	require.NotSame(t, repo, copied.Repo)
+	require.Equal(t, "db://built", copied.Repo.DB.URL)
+}
+
+func TestBuildSubgraph_UnknownRoot(t *testing.T) {
+	c := New()
+	_, err := c.BuildSubgraph("missing")
+	require.EqualError(t, err, "bean 'missing' not found")
+}
@@ -0,0 +1,165 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// tagOptions holds the comma-separated options that follow the name in a struct tag value, e.g. the
+// `required` and `default=8080` in `di.env:"PORT,required,default=8080"`. Flag options map to an empty string.
+type tagOptions map[string]string
+
+// parseTag splits a struct tag value into its leading name and its options.
+func parseTag(raw string) (string, tagOptions) {
+	parts := strings.Split(raw, ",")
+	name := strings.TrimSpace(parts[0])
+	if len(parts) == 1 {
+		return name, nil
+	}
+
+	opts := make(tagOptions, len(parts)-1)
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		if part == emptyString {
+			continue
+		}
+		key, val, _ := strings.Cut(part, "=")
+		opts[key] = val
+	}
+	return name, opts
+}
+
+// has reports whether the option is present, with or without a value.
+func (o tagOptions) has(option string) bool {
+	_, ok := o[option]
+	return ok
+}
+
+// injectTag returns the lower-cased dependency ID and the options of a field's `di.inject` tag.
+// The boolean result reports whether the tag is present at all.
+func injectTag(sf reflect.StructField) (string, tagOptions, bool) {
+	raw, ok := sf.Tag.Lookup(string(inject))
+	if !ok {
+		return emptyString, nil, false
+	}
+	id, opts := parseTag(raw)
+	return strings.ToLower(id), opts, true
+}
+
+// dependencyID returns the dependency ID a field's `di.inject` tag names for the receiver: the tag name, prefixed
+// with the receiver's bean ID under `scope=bean`, e.g. "servicebean.timeout".
+func dependencyID(receiverID, tagName string, opts tagOptions) string {
+	if opts[injectScope] == scopeBean && receiverID != emptyString {
+		return receiverID + "." + tagName
+	}
+	return tagName
+}
+
+// knownTagOptions lists, per tag, the options the container understands and whether each requires a value.
+var knownTagOptions = map[tag]map[string]bool{
+	inject: {injectPrototype: false, injectCopy: false, injectScope: true},
+	env:    {envRequired: false, envDefault: true},
+}
+
+// checkTags validates the tags of the bean with the ID, collecting and logging a WarningUnknownTagOption for
+// every unknown option WithLenientTags ignores.
+func (c *Container) checkTags(beanID string, beanType reflect.Type) error {
+	ignored, err := validateTags(beanType, c.lenientTags)
+	for _, w := range ignored {
+		w.BeanID = beanID
+		if l := c.log(); l != nil {
+			l.Warn("iocdi: unknown tag option ignored", "bean", beanID, "field", w.Field, "detail", w.Message)
+		}
+		c.warn(w)
+	}
+	return err
+}
+
+// validateTags checks the options of every `di.inject` and `di.env` tag on the struct behind beanType, and that no
+// field carries both `di.value` and `di.inject`.
+// Unknown options are reported with a did-you-mean suggestion unless lenient is set, in which case they are
+// returned as warnings without a bean ID; malformed options (e.g. `default=` without a value) are always reported.
+func validateTags(beanType reflect.Type, lenient bool) (ignored []Warning, err error) {
+	if beanType.Kind() == reflect.Ptr {
+		beanType = beanType.Elem()
+	}
+	if beanType.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	for i := 0; i < beanType.NumField(); i++ {
+		sf := beanType.Field(i)
+		if _, hasValue := sf.Tag.Lookup(string(value)); hasValue {
+			if id, _, hasInject := injectTag(sf); hasInject && id != excluded {
+				return nil, fmt.Errorf("%w: %v field '%s' has both %s and %s tags", ErrMalformedTag, beanType, sf.Name, value, inject)
+			}
+		}
+		for _, t := range []tag{inject, env} {
+			raw, ok := sf.Tag.Lookup(string(t))
+			if !ok {
+				continue
+			}
+			parts := strings.Split(raw, ",")
+			for _, part := range parts[1:] {
+				part = strings.TrimSpace(part)
+				if part == emptyString {
+					continue
+				}
+				key, val, hasValue := strings.Cut(part, "=")
+				needsValue, known := knownTagOptions[t][key]
+				switch {
+				case !known:
+					msg := fmt.Sprintf("%v field '%s': unknown %s option '%s'", beanType, sf.Name, t, key)
+					if suggestion := closestOption(key, knownTagOptions[t]); suggestion != emptyString {
+						msg += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+					}
+					if lenient {
+						ignored = append(ignored, Warning{Code: WarningUnknownTagOption, Field: sf.Name, Message: msg + "; ignored"})
+						continue
+					}
+					return nil, fmt.Errorf("%w: %s", ErrMalformedTag, msg)
+				case needsValue && (!hasValue || val == emptyString):
+					return nil, fmt.Errorf("%w: %v field '%s': %s option '%s=' requires a value", ErrMalformedTag, beanType, sf.Name, t, key)
+				case !needsValue && hasValue:
+					return nil, fmt.Errorf("%w: %v field '%s': %s option '%s' does not take a value", ErrMalformedTag, beanType, sf.Name, t, key)
+				case t == inject && key == injectScope && val != scopeBean:
+					return nil, fmt.Errorf("%w: %v field '%s': %s option '%s=%s' is not supported; use '%s=%s'", ErrMalformedTag, beanType, sf.Name, t, key, val, injectScope, scopeBean)
+				}
+			}
+		}
+	}
+	return ignored, nil
+}
+
+// closestOption returns the known option nearest to the misspelled one, if it is within two edits.
+func closestOption(option string, known map[string]bool) string {
+	best, bestDist := emptyString, 3
+	for _, k := range sortedKeys(known) {
+		if d := levenshtein(option, k); d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+// levenshtein computes the edit distance between a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,80 @@
+package iocdi
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type validTagOptions struct {
+	Codec *codec `di.inject:"Codec, prototype"`
+	Port  int    `di.env:"PORT,required,default=8080"`
+}
+
+type typoTagOption struct {
+	Codec *codec `di.inject:"Codec,prototyp"`
+}
+
+type unknownTagOption struct {
+	Codec *codec `di.inject:"Codec,optional"`
+}
+
+type emptyDefaultOption struct {
+	Port int `di.env:"PORT,default="`
+}
+
+func TestTagValidation_ValidOptions(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("Valid", reflect.TypeOf((*validTagOptions)(nil))))
+}
+
+func TestTagValidation_UnknownOptionWithSuggestion(t *testing.T) {
+	c := New()
+	err := c.Register("Typo", reflect.TypeOf((*typoTagOption)(nil)))
+	require.ErrorIs(t, err, ErrMalformedTag)
+	require.EqualError(t, err, "malformed tag: iocdi.typoTagOption field 'Codec': unknown di.inject option 'prototyp' (did you mean 'prototype'?)")
+	require.NotContains(t, c.registeredBeans, "typo")
+}
+
+func TestTagValidation_UnknownOptionWithoutSuggestion(t *testing.T) {
+	c := New()
+	err := c.RegisterInstance("Unknown", &unknownTagOption{})
+	require.ErrorIs(t, err, ErrMalformedTag)
+	require.EqualError(t, err, "malformed tag: iocdi.unknownTagOption field 'Codec': unknown di.inject option 'optional'")
+}
+
+func TestTagValidation_DefaultWithoutValue(t *testing.T) {
+	c := New()
+	err := c.Register("EmptyDefault", reflect.TypeOf((*emptyDefaultOption)(nil)))
+	require.ErrorIs(t, err, ErrMalformedTag)
+	require.Contains(t, err.Error(), "field 'Port': di.env option 'default=' requires a value")
+
+	// Lenient mode does not excuse malformed options.
+	c = New(WithLenientTags())
+	require.ErrorIs(t, c.Register("EmptyDefault", reflect.TypeOf((*emptyDefaultOption)(nil))), ErrMalformedTag)
+}
+
+func TestTagValidation_LenientIgnoresUnknownOptions(t *testing.T) {
+	c := New(WithLenientTags())
+	var buf bytes.Buffer
+	c.SetLogger(captureLogger(&buf))
+	require.NoError(t, c.Register("Typo", reflect.TypeOf((*typoTagOption)(nil))))
+
+	warning := Warning{
+		Code:    WarningUnknownTagOption,
+		BeanID:  "typo",
+		Field:   "Codec",
+		Message: "iocdi.typoTagOption field 'Codec': unknown di.inject option 'prototyp' (did you mean 'prototype'?); ignored",
+	}
+	require.Equal(t, []Warning{warning}, c.Warnings())
+	require.Contains(t, buf.String(), `level=WARN msg="iocdi: unknown tag option ignored" bean=typo field=Codec `+
+		`detail="iocdi.typoTagOption field 'Codec': unknown di.inject option 'prototyp' (did you mean 'prototype'?); ignored"`)
+
+	// The warning outlives the Build that discards the previous injection warnings
+	require.NoError(t, c.Register("Codec", reflect.TypeOf((*codec)(nil))))
+	require.NoError(t, c.Register("Logger", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, c.Build())
+	require.Equal(t, []Warning{warning}, c.Warnings())
+}
@@ -0,0 +1,144 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// maxTemplateDepth bounds how deeply literal templates may reference other templates.
+const maxTemplateDepth = 16
+
+// WithLiteralTemplates expands `${beanID}` placeholders in string values from a LiteralProvider and in
+// registered string beans before they are injected, e.g. "${WorkingDir}/logs". A placeholder is replaced with
+// the value of the bean or literal with that ID, itself expanded, up to 16 levels deep. An unknown placeholder
+// or a cycle between templates fails Build. A value expanded from a bean marked with MarkSecret is itself
+// secret.
+func WithLiteralTemplates() Option {
+	return func(c *Container) {
+		c.literalTemplates = true
+	}
+}
+
+// expandLiteral expands the placeholders in a string value supplied for the dependency ID, keeping the value's
+// type. Other values, and every value without WithLiteralTemplates, are returned unchanged. When a placeholder
+// names a secret, the ID is marked secret too.
+// Callers must hold regMu for writing.
+func (c *Container) expandLiteral(id string, val any) (any, error) {
+	rv := reflect.ValueOf(val)
+	if !c.literalTemplates || rv.Kind() != reflect.String {
+		return val, nil
+	}
+	expanded, secret, err := c.expandTemplate(rv.String(), []string{id})
+	if err != nil {
+		return nil, fmt.Errorf("literal template for '%s': %w", id, err)
+	}
+	if secret && !c.secrets[id] {
+		if c.secrets == nil {
+			c.secrets = make(map[string]bool)
+		}
+		c.secrets[id] = true
+	}
+	out := reflect.New(rv.Type()).Elem()
+	out.SetString(expanded)
+	return out.Interface(), nil
+}
+
+// expandStringBeans expands the placeholders in every registered string bean with WithLiteralTemplates.
+// Callers must hold regMu for writing.
+func (c *Container) expandStringBeans() error {
+	if !c.literalTemplates {
+		return nil
+	}
+	expanded := make(map[string]any)
+	for _, id := range sortedKeys(c.registeredBeans) {
+		bn := c.registeredBeans[id]
+		if bn.literal || bn.instance == nil || bn.beanType.Kind() != reflect.String {
+			continue
+		}
+		val, err := c.expandLiteral(id, bn.instance)
+		if err != nil {
+			return err
+		}
+		expanded[id] = val
+	}
+	// Stored only once all are expanded, so every template is expanded from the raw values
+	for id, val := range expanded {
+		bn := c.registeredBeans[id]
+		bn.instance = val
+		c.registeredBeans[id] = bn
+	}
+	return nil
+}
+
+// expandTemplate replaces the placeholders in s, the value of the last ID on the path. The path lists the IDs
+// whose values are being expanded, for cycle detection. The boolean result reports whether a placeholder named
+// a secret, directly or through another template.
+// Callers must hold regMu.
+func (c *Container) expandTemplate(s string, path []string) (string, bool, error) {
+	if len(path) > maxTemplateDepth {
+		return emptyString, false, fmt.Errorf("templates nested deeper than %d levels: %s", maxTemplateDepth, strings.Join(path, pathSep))
+	}
+
+	var sb strings.Builder
+	secret := false
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			sb.WriteString(s)
+			return sb.String(), secret, nil
+		}
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			return emptyString, false, fmt.Errorf("unterminated placeholder in %s", c.displayValue(path[len(path)-1], s))
+		}
+		name := strings.ToLower(strings.TrimSpace(s[start+2 : start+end]))
+		if slices.Contains(path, name) {
+			return emptyString, false, fmt.Errorf("template cycle: %s", strings.Join(slices.Concat(path[slices.Index(path, name):], []string{name}), pathSep))
+		}
+		val, valSecret, err := c.templateValue(name, append(path, name))
+		if err != nil {
+			return emptyString, false, err
+		}
+		secret = secret || valSecret
+		sb.WriteString(s[:start])
+		sb.WriteString(val)
+		s = s[start+end+1:]
+	}
+}
+
+// templateValue returns the expanded value of the bean or literal a placeholder names. Literals are looked up
+// without being stored; the dependency naming them stores them when it is injected. The boolean result reports
+// whether the value is secret or was expanded from one.
+// Callers must hold regMu.
+func (c *Container) templateValue(name string, path []string) (string, bool, error) {
+	var val any
+	if bn, ok := c.registeredBeans[name]; ok {
+		if bn.instance == nil || !isBasicKind(bn.beanType.Kind()) {
+			return emptyString, false, fmt.Errorf("placeholder ${%s} does not name a string or scalar value", name)
+		}
+		if bn.literal {
+			return fmt.Sprint(bn.instance), c.secrets[name], nil // stored already expanded
+		}
+		val = bn.instance
+	} else {
+		lv, found, _, err := c.lookupLiteral(LiteralRequest{ID: name, TargetType: reflect.TypeFor[string]()})
+		if err != nil {
+			return emptyString, false, fmt.Errorf("placeholder ${%s}: literal provider error: %w", name, err)
+		}
+		if eph, ok := lv.(EphemeralLiteral); ok {
+			lv = eph.Value
+		}
+		if !found || lv == nil {
+			return emptyString, false, fmt.Errorf("unknown placeholder ${%s}", name)
+		}
+		val = lv
+	}
+
+	if rv := reflect.ValueOf(val); rv.Kind() == reflect.String {
+		expanded, secret, err := c.expandTemplate(rv.String(), path)
+		return expanded, secret || c.secrets[name], err
+	}
+	return fmt.Sprint(val), c.secrets[name], nil
+}
@@ -0,0 +1,114 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type templatedPaths struct {
+	LogPath     string `di.inject:"LogPath"`
+	ArchivePath string `di.inject:"ArchivePath"`
+}
+
+func templateContainer(t *testing.T, literals map[string]string) *Container {
+	t.Helper()
+	c := New(WithLiteralTemplates())
+	require.NoError(t, c.Register("paths", reflect.TypeOf((*templatedPaths)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		v, ok := literals[id]
+		return v, ok, nil
+	})
+	return c
+}
+
+func TestLiteralTemplates_Expand(t *testing.T) {
+	c := templateContainer(t, map[string]string{
+		"workingdir":  "/srv/app",
+		"logpath":     "${WorkingDir}/logs",
+		"archivepath": "${LogPath}/archive",
+	})
+
+	paths, err := ResolveAs[*templatedPaths](c, "paths")
+	require.NoError(t, err)
+	require.Equal(t, "/srv/app/logs", paths.LogPath)
+	require.Equal(t, "/srv/app/logs/archive", paths.ArchivePath)
+}
+
+func TestLiteralTemplates_RegisteredStringBeans(t *testing.T) {
+	c := New(WithLiteralTemplates())
+	require.NoError(t, c.Register("paths", reflect.TypeOf((*templatedPaths)(nil))))
+	require.NoError(t, c.RegisterInstance("WorkingDir", "/srv/app"))
+	require.NoError(t, c.RegisterInstance("LogPath", "${WorkingDir}/logs"))
+	require.NoError(t, c.RegisterInstance("ArchivePath", "${logpath}/archive"))
+
+	paths, err := ResolveAs[*templatedPaths](c, "paths")
+	require.NoError(t, err)
+	require.Equal(t, "/srv/app/logs", paths.LogPath)
+	require.Equal(t, "/srv/app/logs/archive", paths.ArchivePath)
+}
+
+func TestLiteralTemplates_Disabled(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("paths", reflect.TypeOf((*templatedPaths)(nil))))
+	c.SetLiteralProvider(func(id string, targetType reflect.Type) (any, bool, error) {
+		return "${WorkingDir}/logs", true, nil
+	})
+
+	paths, err := ResolveAs[*templatedPaths](c, "paths")
+	require.NoError(t, err)
+	require.Equal(t, "${WorkingDir}/logs", paths.LogPath)
+}
+
+func TestLiteralTemplates_UnknownPlaceholder(t *testing.T) {
+	c := templateContainer(t, map[string]string{
+		"logpath":     "${DataDir}/logs",
+		"archivepath": "/archive",
+	})
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "literal template for 'logpath': unknown placeholder ${datadir}")
+}
+
+func TestLiteralTemplates_Cycle(t *testing.T) {
+	c := templateContainer(t, map[string]string{
+		"logpath":     "${ArchivePath}/logs",
+		"archivepath": "${LogPath}/archive",
+	})
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "literal template for 'logpath': template cycle: logpath -> archivepath -> logpath")
+}
+
+func TestLiteralTemplates_Secrets(t *testing.T) {
+	c := templateContainer(t, map[string]string{
+		"dbpassword":  "hunter2",
+		"logpath":     "postgres://app:${DBPassword}@db/logs",
+		"archivepath": "/archive",
+	})
+	c.MarkSecret("DBPassword")
+	require.NoError(t, c.Build())
+
+	paths, err := ResolveAs[*templatedPaths](c, "paths")
+	require.NoError(t, err)
+	require.Equal(t, "postgres://app:hunter2@db/logs", paths.LogPath)
+	_, err = c.ResolveSafe("LogPath")
+	require.ErrorIs(t, err, ErrSecretBean)
+	d, err := c.DescribeBean("LogPath")
+	require.NoError(t, err)
+	require.Equal(t, redacted, d.Value)
+
+	// A malformed secret is not quoted in the error.
+	c = templateContainer(t, map[string]string{
+		"dbpassword":  "hunter2${oops",
+		"logpath":     "${DBPassword}",
+		"archivepath": "/archive",
+	})
+	c.MarkSecret("DBPassword")
+	err = c.Build()
+	require.ErrorContains(t, err, "unterminated placeholder in «redacted»")
+	require.NotContains(t, err.Error(), "hunter2")
+}
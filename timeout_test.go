@@ -0,0 +1,78 @@
+package iocdi
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type slowInit struct{}
+
+func (s *slowInit) Initialize() error {
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+type fastInit struct{ done bool }
+
+func (f *fastInit) Initialize() error {
+	f.done = true
+	return nil
+}
+
+type slowCtxInit struct{ err error }
+
+func (s *slowCtxInit) InitializeCtx(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		s.err = ctx.Err()
+		return ctx.Err()
+	case <-time.After(500 * time.Millisecond):
+		return nil
+	}
+}
+
+func TestInitTimeout_OnlySlowBeanTrips(t *testing.T) {
+	c := New(WithInitTimeout(50 * time.Millisecond))
+	require.NoError(t, c.Register("fast", reflect.TypeOf((*fastInit)(nil))))
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*slowInit)(nil))))
+
+	err := c.Build()
+	require.ErrorIs(t, err, ErrInitTimeout)
+	require.Contains(t, err.Error(), "initializer for bean 'slow' failed: initializer timed out after")
+}
+
+func TestInitTimeout_FastBeanPasses(t *testing.T) {
+	c := New(WithInitTimeout(time.Second))
+	require.NoError(t, c.Register("fast", reflect.TypeOf((*fastInit)(nil))))
+	require.NoError(t, c.Build())
+
+	f, err := ResolveAs[*fastInit](c, "fast")
+	require.NoError(t, err)
+	require.True(t, f.done)
+}
+
+func TestInitTimeout_PerBeanOverride(t *testing.T) {
+	c := New(WithInitTimeout(time.Second))
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*slowInit)(nil)), InitTimeout(20*time.Millisecond)))
+	require.ErrorIs(t, c.Build(), ErrInitTimeout)
+
+	// Without a container default, the override alone applies
+	c = New()
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*slowInit)(nil)), InitTimeout(20*time.Millisecond)))
+	require.ErrorIs(t, c.Build(), ErrInitTimeout)
+}
+
+func TestInitTimeout_ContextInitializerGetsDeadline(t *testing.T) {
+	c := New(WithInitTimeout(20 * time.Millisecond))
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*slowCtxInit)(nil))))
+
+	err := c.Build()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.False(t, errors.Is(err, ErrInitTimeout))
+	require.Contains(t, err.Error(), "initializer for bean 'slow' failed")
+}
@@ -0,0 +1,72 @@
+package iocdi
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// TraceHook starts spans in the application's tracing system, such as OpenTelemetry, without the container
+// depending on it. StartSpan is called when a step starts and returns the function the container calls with the
+// step's error, nil on success, when it ends. Spans are started and ended in nested order on the calling
+// goroutine, so an adapter can keep the current span on a stack. Like event subscribers, hooks run with the
+// container's locks held and must not call back into the container.
+type TraceHook interface {
+	StartSpan(name string, attrs map[string]string) (end func(err error))
+}
+
+// Span names and attribute keys used by the container.
+const (
+	spanBuild   = "build"
+	spanInject  = "inject"
+	spanInit    = "init"
+	spanResolve = "resolve"
+
+	// AttrBeanID and AttrBeanType carry the bean a span is about; AttrBeanCount the number of beans a build span
+	// covers.
+	AttrBeanID    = "bean.id"
+	AttrBeanType  = "bean.type"
+	AttrBeanCount = "bean.count"
+)
+
+// SetTraceHook installs the hook the container reports spans to; nil removes it. Build is a "build" span;
+// inside it, wiring each bean's fields is an "inject:<id>" span and each initializer an "init:<id>" span, both
+// with the bean ID and type as attributes. A lazy bean's initializer is traced on its first resolution, and a
+// ResolveSafe that builds the container implicitly is a "resolve:<id>" span around the build. Without a hook
+// tracing costs one branch per step.
+func (c *Container) SetTraceHook(h TraceHook) {
+	if h == nil {
+		c.tracer.Store(nil)
+		return
+	}
+	c.tracer.Store(&h)
+}
+
+// endNothing is returned for spans that were not started.
+func endNothing(error) {}
+
+// startSpan starts the span "kind:beanID", or "kind" without a bean ID, when a trace hook is installed.
+func (c *Container) startSpan(kind, beanID string, beanType reflect.Type) func(error) {
+	h := c.tracer.Load()
+	if h == nil {
+		return endNothing
+	}
+	name := kind
+	attrs := make(map[string]string, 2)
+	if beanID != emptyString {
+		name += ":" + beanID
+		attrs[AttrBeanID] = beanID
+	}
+	if beanType != nil {
+		attrs[AttrBeanType] = beanType.String()
+	}
+	return (*h).StartSpan(name, attrs)
+}
+
+// startBuildSpan starts the span of a Build covering n beans when a trace hook is installed.
+func (c *Container) startBuildSpan(n int) func(error) {
+	h := c.tracer.Load()
+	if h == nil {
+		return endNothing
+	}
+	return (*h).StartSpan(spanBuild, map[string]string{AttrBeanCount: strconv.Itoa(n)})
+}
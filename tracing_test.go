@@ -0,0 +1,105 @@
+package iocdi
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordedSpan struct {
+	path  string // names of the enclosing spans and this one, joined with " > "
+	attrs map[string]string
+	err   error
+	ended bool
+}
+
+// spanRecorder is a TraceHook keeping every span with its nesting.
+type spanRecorder struct {
+	mu    sync.Mutex
+	stack []string
+	spans []*recordedSpan
+}
+
+func (r *spanRecorder) StartSpan(name string, attrs map[string]string) func(error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stack = append(r.stack, name)
+	s := &recordedSpan{path: strings.Join(r.stack, " > "), attrs: attrs}
+	r.spans = append(r.spans, s)
+	return func(err error) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.stack = r.stack[:len(r.stack)-1]
+		s.err, s.ended = err, true
+	}
+}
+
+func (r *spanRecorder) span(path string) *recordedSpan {
+	for _, s := range r.spans {
+		if s.path == path {
+			return s
+		}
+	}
+	return nil
+}
+
+func (r *spanRecorder) paths() []string {
+	paths := make([]string, 0, len(r.spans))
+	for _, s := range r.spans {
+		paths = append(paths, s.path)
+	}
+	return paths
+}
+
+func TestTraceHook_Build(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Register("slow", reflect.TypeOf((*sleepyInit)(nil))))
+	rec := &spanRecorder{}
+	c.SetTraceHook(rec)
+
+	_, err := c.ResolveSafe("ServiceBean")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"resolve:servicebean",
+		"resolve:servicebean > build",
+		"resolve:servicebean > build > inject:servicebeanconfig",
+		"resolve:servicebean > build > inject:servicebeanlogger",
+		"resolve:servicebean > build > inject:servicebean",
+		"resolve:servicebean > build > inject:slow",
+		"resolve:servicebean > build > init:slow",
+	}, rec.paths())
+	for _, s := range rec.spans {
+		require.True(t, s.ended, s.path)
+		require.NoError(t, s.err, s.path)
+	}
+	require.Equal(t, map[string]string{AttrBeanCount: "4"}, rec.span("resolve:servicebean > build").attrs)
+	require.Equal(t, map[string]string{AttrBeanID: "slow", AttrBeanType: "*iocdi.sleepyInit"},
+		rec.span("resolve:servicebean > build > init:slow").attrs)
+
+	// Once built, resolving is not traced
+	_, err = c.ResolveSafe("ServiceBean")
+	require.NoError(t, err)
+	require.Len(t, rec.spans, 7)
+}
+
+func TestTraceHook_InitializerFails(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("broken", reflect.TypeOf((*failingInit)(nil))))
+	rec := &spanRecorder{}
+	c.SetTraceHook(rec)
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Equal(t, []string{"build", "build > inject:broken", "build > init:broken"}, rec.paths())
+	require.EqualError(t, rec.span("build > init:broken").err, "boom")
+	require.Equal(t, err, rec.span("build").err)
+	require.NoError(t, rec.span("build > inject:broken").err)
+
+	c.SetTraceHook(nil)
+	require.Error(t, c.Build())
+	require.Len(t, rec.spans, 3)
+}
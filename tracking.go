@@ -0,0 +1,69 @@
+package iocdi
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// TrackPrototypes makes the container remember the prototype instances it creates from this bean, so Close can
+// destroy them after the singletons, most recent first. Tracked instances are kept until Close or until they are
+// handed back with ReleasePrototype, which callers creating many prototypes should do to bound memory.
+func TrackPrototypes() RegisterOption {
+	return func(b *bean) {
+		b.trackPrototypes = true
+	}
+}
+
+// trackedPrototype is a prototype instance created from the bean with the given ID.
+type trackedPrototype struct {
+	id       string
+	instance any
+}
+
+// prototypeTracker holds the prototypes of beans registered with TrackPrototypes, in creation order.
+type prototypeTracker struct {
+	mu      sync.Mutex
+	tracked []trackedPrototype
+}
+
+// track records a prototype created from the bean with the given ID.
+func (t *prototypeTracker) track(id string, instance any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tracked = append(t.tracked, trackedPrototype{id: id, instance: instance})
+}
+
+// drain removes and returns every tracked prototype, in creation order.
+func (t *prototypeTracker) drain() []trackedPrototype {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tracked := t.tracked
+	t.tracked = nil
+	return tracked
+}
+
+// ReleasePrototype stops tracking a prototype instance and destroys it if it implements Destroyer. Use it when
+// the prototype's owner is done with it, instead of waiting for Close.
+func (c *Container) ReleasePrototype(instance any) error {
+	c.prototypes.mu.Lock()
+	i := slices.IndexFunc(c.prototypes.tracked, func(tp trackedPrototype) bool { return tp.instance == instance })
+	if i < 0 {
+		c.prototypes.mu.Unlock()
+		return ErrPrototypeNotTracked
+	}
+	tp := c.prototypes.tracked[i]
+	c.prototypes.tracked = slices.Delete(c.prototypes.tracked, i, i+1)
+	c.prototypes.mu.Unlock()
+
+	d, ok := tp.instance.(Destroyer)
+	if !ok {
+		return nil
+	}
+	err := callRecovered(tp.id, d.Destroy)
+	c.emit(Event{Kind: EventDestroyed, BeanID: tp.id, Err: err})
+	if err != nil {
+		return fmt.Errorf("destroyer for prototype '%s' failed: %w", tp.id, err)
+	}
+	return nil
+}
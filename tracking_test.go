@@ -0,0 +1,69 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var trackedLog []string
+
+type trackedSession struct {
+	ID int
+}
+
+func (s *trackedSession) Destroy() error {
+	trackedLog = append(trackedLog, "session")
+	return nil
+}
+
+type sessionOwner struct {
+	A *trackedSession `di.inject:"session,prototype"`
+	B *trackedSession `di.inject:"session,prototype"`
+	C *trackedSession `di.inject:"session,prototype"`
+}
+
+func (o *sessionOwner) Destroy() error {
+	trackedLog = append(trackedLog, "owner")
+	return nil
+}
+
+func TestTrackPrototypes_DestroyedOnClose(t *testing.T) {
+	trackedLog = nil
+	c := New()
+	require.NoError(t, c.Register("session", reflect.TypeOf((*trackedSession)(nil)), TrackPrototypes()))
+	require.NoError(t, c.Register("owner", reflect.TypeOf((*sessionOwner)(nil))))
+	require.NoError(t, c.Build())
+
+	require.NoError(t, c.Close())
+	// Singletons (owner, then the session template) first, then the three prototypes
+	require.Equal(t, []string{"owner", "session", "session", "session", "session"}, trackedLog)
+}
+
+func TestTrackPrototypes_Release(t *testing.T) {
+	trackedLog = nil
+	c := New()
+	require.NoError(t, c.Register("session", reflect.TypeOf((*trackedSession)(nil)), TrackPrototypes()))
+	require.NoError(t, c.Register("owner", reflect.TypeOf((*sessionOwner)(nil))))
+	require.NoError(t, c.Build())
+
+	owner, err := ResolveAs[*sessionOwner](c, "owner")
+	require.NoError(t, err)
+	require.NoError(t, c.ReleasePrototype(owner.B))
+	require.Equal(t, []string{"session"}, trackedLog)
+	require.ErrorIs(t, c.ReleasePrototype(owner.B), ErrPrototypeNotTracked)
+	require.Len(t, c.prototypes.tracked, 2)
+
+	trackedLog = nil
+	require.NoError(t, c.Close())
+	require.Len(t, trackedLog, 4)
+}
+
+func TestTrackPrototypes_UntrackedByDefault(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("session", reflect.TypeOf((*trackedSession)(nil))))
+	require.NoError(t, c.Register("owner", reflect.TypeOf((*sessionOwner)(nil))))
+	require.NoError(t, c.Build())
+	require.Empty(t, c.prototypes.tracked)
+}
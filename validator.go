@@ -0,0 +1,11 @@
+package iocdi
+
+// Validator is an optional interface that a bean may implement to assert its own invariants
+// (non-nil dependencies, sane literal values) once wiring is complete.
+//
+// The container calls ValidateWiring() during Build() on every bean implementing it, in dependency
+// order, after dependency injection and before any Initializer runs. Failures from all beans are
+// collected into a single error; if any bean fails, no Initialize() is called.
+type Validator interface {
+	ValidateWiring() error
+}
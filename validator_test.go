@@ -0,0 +1,92 @@
+package iocdi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var validationLog []string
+
+type validatedConfig struct {
+	Dir string `di.inject:"WorkingDir"`
+}
+
+func (v *validatedConfig) ValidateWiring() error {
+	validationLog = append(validationLog, "validate:config")
+	if v.Dir == "" {
+		return errors.New("working dir is empty")
+	}
+	return nil
+}
+
+func (v *validatedConfig) Initialize() error {
+	validationLog = append(validationLog, "init:config")
+	return nil
+}
+
+type validatedService struct {
+	Config *validatedConfig `di.inject:"Config"`
+	Logger *Logger          `di.inject:"Logger"`
+}
+
+func (v *validatedService) ValidateWiring() error {
+	validationLog = append(validationLog, "validate:service")
+	if v.Logger == nil {
+		return errors.New("logger is nil")
+	}
+	return nil
+}
+
+func (v *validatedService) Initialize() error {
+	validationLog = append(validationLog, "init:service")
+	return nil
+}
+
+func TestValidator_PassesBeforeInitialize(t *testing.T) {
+	validationLog = nil
+	c := New()
+	require.NoError(t, c.Register("Service", reflect.TypeOf((*validatedService)(nil))))
+	require.NoError(t, c.Register("Config", reflect.TypeOf((*validatedConfig)(nil))))
+	require.NoError(t, c.Register("Logger", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, c.RegisterInstance("WorkingDir", "/srv"))
+
+	require.NoError(t, c.Build())
+	require.Equal(t, []string{"validate:config", "validate:service", "init:config", "init:service"}, validationLog)
+}
+
+func TestValidator_FailureStopsAllInitializers(t *testing.T) {
+	validationLog = nil
+	c := New()
+	require.NoError(t, c.Register("Service", reflect.TypeOf((*validatedService)(nil))))
+	require.NoError(t, c.Register("Config", reflect.TypeOf((*validatedConfig)(nil))))
+	require.NoError(t, c.Register("Logger", reflect.TypeOf((*Logger)(nil))))
+	require.NoError(t, c.RegisterInstance("WorkingDir", ""))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "validation for bean 'config' failed: working dir is empty")
+	require.NotContains(t, validationLog, "init:config")
+	require.NotContains(t, validationLog, "init:service")
+}
+
+type failingValidator struct {
+	Name string
+}
+
+func (f *failingValidator) ValidateWiring() error {
+	return errors.New(f.Name + " is broken")
+}
+
+func TestValidator_AggregatesAllFailures(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("First", &failingValidator{Name: "first"}))
+	require.NoError(t, c.RegisterInstance("Second", &failingValidator{Name: "second"}))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "validation for bean 'first' failed: first is broken")
+	require.Contains(t, err.Error(), "validation for bean 'second' failed: second is broken")
+}
@@ -0,0 +1,139 @@
+package iocdi
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	int64Type    = reflect.TypeOf(int64(0))
+)
+
+// convertString parses s into a value of targetType. Strings are used verbatim, numbers and bools are
+// parsed with strconv and time.Duration is parsed with time.ParseDuration. Named int64 types, such as
+// `type Timeout time.Duration`, are parsed as durations too, unless s is a plain integer. The returned value is
+// always of exactly targetType.
+func convertString(s string, targetType reflect.Type) (reflect.Value, error) {
+	out := reflect.New(targetType).Elem()
+
+	if targetType.Kind() == reflect.Int64 && targetType != int64Type {
+		if targetType != durationType {
+			if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+				out.SetInt(n)
+				return out, nil
+			}
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(d).Convert(targetType), nil
+	}
+
+	switch targetType.Kind() {
+	case reflect.String:
+		out.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, targetType.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, targetType.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, targetType.Bits())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out.SetFloat(f)
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported kind %v", targetType.Kind())
+	}
+	return out, nil
+}
+
+// injectValues sets the exported fields of the receiver bean that carry a `di.value` or `di.env` tag.
+//
+// A field may not carry both `di.value` and `di.inject`. For `di.env` fields the precedence is:
+//  1. the environment variable, when set;
+//  2. an error when unset and the `required` option is given;
+//  3. the value already provided by `di.inject` or `di.value` on the same field;
+//  4. the `default=` option;
+//  5. otherwise the field is left untouched (typically its zero value).
+//
+// Fields tagged `di.inject:"-"` are never touched.
+func (c *Container) injectValues(receiverBean bean) error {
+	rv := reflect.ValueOf(receiverBean.instance)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	rv = rv.Elem()
+
+	for i := 0; i < rv.NumField(); i++ {
+		sf := rv.Type().Field(i)
+		fv := rv.Field(i)
+		injectID, _, hasInject := injectTag(sf)
+		if injectID == excluded {
+			continue
+		}
+
+		raw, hasValue := sf.Tag.Lookup(string(value))
+		if hasValue {
+			if hasInject {
+				return fmt.Errorf("field '%s' of bean '%s' has both %s and %s tags", sf.Name, receiverBean.id, value, inject)
+			}
+			if fv.CanSet() {
+				v, err := convertString(raw, sf.Type)
+				if err != nil {
+					return fmt.Errorf("field '%s' of bean '%s': cannot parse %s %q as %v: %w", sf.Name, receiverBean.id, value, raw, sf.Type, err)
+				}
+				fv.Set(v)
+			}
+		}
+
+		envTag, hasEnv := sf.Tag.Lookup(string(env))
+		if !hasEnv || !fv.CanSet() {
+			continue
+		}
+		name, opts := parseTag(envTag)
+		raw, set := c.lookupEnv(name)
+		if !set {
+			if opts.has(envRequired) {
+				return fmt.Errorf("field '%s' of bean '%s': required environment variable '%s' is not set", sf.Name, receiverBean.id, name)
+			}
+			if hasInject || hasValue || !opts.has(envDefault) {
+				continue
+			}
+			raw = opts[envDefault]
+		}
+		v, err := convertString(raw, sf.Type)
+		if err != nil {
+			return fmt.Errorf("field '%s' of bean '%s': cannot parse environment variable '%s' value %q as %v: %w", sf.Name, receiverBean.id, name, raw, sf.Type, err)
+		}
+		fv.Set(v)
+	}
+
+	return nil
+}
+
+// lookupEnv resolves an environment variable through the configured Lookuper.
+func (c *Container) lookupEnv(key string) (string, bool) {
+	if c.envLookuper == nil {
+		return defaultLookuper.LookupEnv(key)
+	}
+	return c.envLookuper.LookupEnv(key)
+}
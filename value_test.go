@@ -0,0 +1,83 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type valueBean struct {
+	Name    string        `di.value:"station-manager"`
+	Port    int           `di.value:"8080"`
+	Debug   bool          `di.value:"true"`
+	Timeout time.Duration `di.value:"1m30s"`
+}
+
+func TestValueTag_ParsesFieldKinds(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("ValueBean", reflect.TypeOf((*valueBean)(nil))))
+	require.NoError(t, c.Build())
+
+	vb, err := ResolveAs[*valueBean](c, "ValueBean")
+	require.NoError(t, err)
+	require.Equal(t, "station-manager", vb.Name)
+	require.Equal(t, 8080, vb.Port)
+	require.True(t, vb.Debug)
+	require.Equal(t, 90*time.Second, vb.Timeout)
+}
+
+type valueTimeout time.Duration
+
+type namedDurationValueBean struct {
+	Timeout valueTimeout `di.value:"5s"`
+	Retries int64        `di.value:"3"`
+}
+
+func TestValueTag_NamedDuration(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("ValueBean", reflect.TypeOf((*namedDurationValueBean)(nil))))
+
+	vb, err := ResolveAs[*namedDurationValueBean](c, "ValueBean")
+	require.NoError(t, err)
+	require.Equal(t, valueTimeout(5*time.Second), vb.Timeout)
+	require.Equal(t, int64(3), vb.Retries)
+
+	v, err := convertString("250", reflect.TypeOf(valueTimeout(0)))
+	require.NoError(t, err)
+	require.Equal(t, valueTimeout(250), v.Interface())
+}
+
+type badValueBean struct {
+	Port int `di.value:"eighty"`
+}
+
+func TestValueTag_ParseFailureNamesField(t *testing.T) {
+	c := New()
+	require.NoError(t, c.Register("BadValueBean", reflect.TypeOf((*badValueBean)(nil))))
+
+	err := c.Build()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "field 'Port' of bean 'badvaluebean'")
+	require.Contains(t, err.Error(), `"eighty"`)
+}
+
+type conflictingValueBean struct {
+	Dir string `di.value:"/tmp" di.inject:"WorkingDir"`
+}
+
+func TestValueTag_ConflictWithInject(t *testing.T) {
+	c := New()
+	require.NoError(t, c.RegisterInstance("WorkingDir", "/var/app"))
+
+	err := c.Register("ConflictBean", reflect.TypeOf((*conflictingValueBean)(nil)))
+	require.ErrorIs(t, err, ErrMalformedTag)
+	require.EqualError(t, err, "malformed tag: iocdi.conflictingValueBean field 'Dir' has both di.value and di.inject tags")
+	require.NotContains(t, c.registeredBeans, "conflictbean")
+
+	// Excluded fields are never injected, so the value tag stands alone.
+	require.NoError(t, c.RegisterInstance("Excluded", &struct {
+		Dir string `di.value:"/tmp" di.inject:"-"`
+	}{}))
+}
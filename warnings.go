@@ -0,0 +1,123 @@
+package iocdi
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// WarningCode classifies a Warning.
+type WarningCode int
+
+const (
+	// WarningIncompatibleType means the dependency's type does not fit the field naming it.
+	WarningIncompatibleType WarningCode = iota
+	// WarningUnexportedField means the field naming the dependency is unexported.
+	WarningUnexportedField
+	// WarningUnsettableField means the field naming the dependency is exported but cannot be set, e.g. because
+	// the receiver is not addressable.
+	WarningUnsettableField
+	// WarningUnknownTagOption means WithLenientTags ignored an unknown tag option. It is raised on registration
+	// and kept across builds.
+	WarningUnknownTagOption
+)
+
+func (w WarningCode) String() string {
+	switch w {
+	case WarningIncompatibleType:
+		return "incompatible type"
+	case WarningUnexportedField:
+		return "unexported field"
+	case WarningUnsettableField:
+		return "unsettable field"
+	case WarningUnknownTagOption:
+		return "unknown tag option"
+	}
+	return "unknown"
+}
+
+// Warning reports a field the container left unset although it names a dependency. See Warnings.
+type Warning struct {
+	Code WarningCode
+	// BeanID is the ID of the bean owning the field, Field the field's name and DependencyID the bean it names.
+	BeanID       string
+	Field        string
+	DependencyID string
+	// Message describes the condition, e.g. "field 'hidden' of bean 'app' is unexported; 'logger' left unset".
+	Message string
+}
+
+func (w Warning) String() string {
+	return w.Code.String() + ": " + w.Message
+}
+
+// warnings collects the Warnings raised since the current or most recent Build started.
+type warnings struct {
+	mu    sync.Mutex
+	found []Warning
+}
+
+// Warnings returns, in the order they were raised, the unknown tag options WithLenientTags ignored, the fields the
+// most recent Build left unset although they name a dependency, and those left unset since by Inject and by
+// lazy, scoped and prototype beans. Each condition is reported once. The same conditions are logged at warn
+// level; WithStrictWarnings turns them into Build errors. A tagged field whose type cannot take its dependency at
+// all already fails the Build precheck, so WarningIncompatibleType is mostly raised by Inject.
+func (c *Container) Warnings() []Warning {
+	c.warnings.mu.Lock()
+	defer c.warnings.mu.Unlock()
+	return slices.Clone(c.warnings.found)
+}
+
+// warn collects w unless the same condition was already reported.
+func (c *Container) warn(w Warning) {
+	c.warnings.mu.Lock()
+	defer c.warnings.mu.Unlock()
+	for _, seen := range c.warnings.found {
+		if seen.Code == w.Code && seen.BeanID == w.BeanID && seen.Field == w.Field && seen.DependencyID == w.DependencyID {
+			return
+		}
+	}
+	c.warnings.found = append(c.warnings.found, w)
+}
+
+// resetWarnings discards the warnings of the previous Build, keeping those raised on registration.
+func (c *Container) resetWarnings() {
+	c.warnings.mu.Lock()
+	c.warnings.found = slices.DeleteFunc(c.warnings.found, func(w Warning) bool {
+		return w.Code != WarningUnknownTagOption
+	})
+	c.warnings.mu.Unlock()
+}
+
+// warnIncompatible collects a WarningIncompatibleType.
+func (c *Container) warnIncompatible(receiverID, field, depID string, fieldType, depType reflect.Type) {
+	c.warn(Warning{Code: WarningIncompatibleType, BeanID: receiverID, Field: field, DependencyID: depID,
+		Message: fmt.Sprintf("field '%s' of bean '%s' (%v) cannot take '%s' (%v); left unset", field, receiverID, fieldType, depID, depType)})
+}
+
+// warnNotSettable collects a WarningUnexportedField or WarningUnsettableField.
+func (c *Container) warnNotSettable(receiverID string, sf reflect.StructField, depID string) {
+	w := Warning{Code: WarningUnsettableField, BeanID: receiverID, Field: sf.Name, DependencyID: depID,
+		Message: fmt.Sprintf("field '%s' of bean '%s' is not settable; '%s' left unset", sf.Name, receiverID, depID)}
+	if !sf.IsExported() {
+		w.Code = WarningUnexportedField
+		w.Message = fmt.Sprintf("field '%s' of bean '%s' is unexported; '%s' left unset", sf.Name, receiverID, depID)
+	}
+	c.warn(w)
+}
+
+// checkWarnings fails with every warning whose code was made strict with WithStrictWarnings.
+func (c *Container) checkWarnings() error {
+	if len(c.strictWarnings) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, w := range c.Warnings() {
+		if c.strictWarnings[w.Code] {
+			errs = append(errs, errors.New("strict: "+w.String()))
+		}
+	}
+	return errors.Join(errs...)
+}
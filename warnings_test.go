@@ -0,0 +1,79 @@
+package iocdi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// exportedLoggerReceiver is injected into as a struct value below, so its exported field cannot be set.
+type exportedLoggerReceiver struct {
+	Logger *Logger `di.inject:"logger"`
+}
+
+func TestWarnings_UnexportedField(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Register("hidden", reflect.TypeOf((*hiddenLoggerReceiver)(nil))))
+	require.NoError(t, c.Build())
+
+	require.Equal(t, []Warning{{
+		Code:         WarningUnexportedField,
+		BeanID:       "hidden",
+		Field:        "hidden",
+		DependencyID: "servicebeanlogger",
+		Message:      "field 'hidden' of bean 'hidden' is unexported; 'servicebeanlogger' left unset",
+	}}, c.Warnings())
+
+	// A rebuild starts a fresh collection instead of adding to it
+	require.NoError(t, c.Reset())
+	require.NoError(t, c.Build())
+	require.Len(t, c.Warnings(), 1)
+}
+
+func TestWarnings_IncompatibleType(t *testing.T) {
+	c := newServiceGraph(t)
+	require.NoError(t, c.Build())
+	require.Empty(t, c.Warnings())
+
+	var target mistypedTarget
+	require.NoError(t, c.Inject(&target))
+	require.NoError(t, c.Inject(&target))
+	require.Equal(t, []Warning{{
+		Code:         WarningIncompatibleType,
+		BeanID:       "*iocdi.mistypedTarget",
+		Field:        "Config",
+		DependencyID: "servicebeanconfig",
+		Message:      "field 'Config' of bean '*iocdi.mistypedTarget' (*iocdi.Logger) cannot take 'servicebeanconfig' (*iocdi.Config); left unset",
+	}}, c.Warnings(), "the condition is reported once")
+}
+
+func TestWarnings_UnsettableField(t *testing.T) {
+	c := New()
+	logger := bean{id: "logger", instance: &Logger{}, beanType: reflect.TypeOf(&Logger{})}
+	receiver := bean{id: "receiver", instance: exportedLoggerReceiver{}, beanType: reflect.TypeOf(exportedLoggerReceiver{})}
+
+	require.NoError(t, c.injectIntoStruct(receiver, logger, nil))
+	require.Equal(t, []Warning{{
+		Code:         WarningUnsettableField,
+		BeanID:       "receiver",
+		Field:        "Logger",
+		DependencyID: "logger",
+		Message:      "field 'Logger' of bean 'receiver' is not settable; 'logger' left unset",
+	}}, c.Warnings())
+}
+
+func TestWarnings_Strict(t *testing.T) {
+	c := newServiceGraph(t, WithStrictWarnings(WarningUnexportedField))
+	require.NoError(t, c.Register("hidden", reflect.TypeOf((*hiddenLoggerReceiver)(nil))))
+
+	require.EqualError(t, c.Build(), "strict: unexported field: field 'hidden' of bean 'hidden' is unexported; 'servicebeanlogger' left unset")
+	require.Equal(t, StateBuildFailed, c.State())
+	require.Len(t, c.Warnings(), 1, "the warnings of a failed Build stay available")
+
+	// Codes that are not strict are only collected
+	c = newServiceGraph(t, WithStrictWarnings(WarningIncompatibleType, WarningUnsettableField))
+	require.NoError(t, c.Register("hidden", reflect.TypeOf((*hiddenLoggerReceiver)(nil))))
+	require.NoError(t, c.Build())
+	require.Len(t, c.Warnings(), 1)
+}
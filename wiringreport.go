@@ -0,0 +1,211 @@
+package iocdi
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ReportFormat selects the document ExportReport writes.
+type ReportFormat int
+
+const (
+	// ReportMarkdown writes GitHub-flavored Markdown with the graph as a Mermaid code block.
+	ReportMarkdown ReportFormat = iota
+	// ReportHTML writes a self-contained HTML page with the graph in a `<pre class="mermaid">` block, which
+	// renders where the Mermaid script is loaded.
+	ReportHTML
+)
+
+func (f ReportFormat) String() string {
+	switch f {
+	case ReportMarkdown:
+		return "Markdown"
+	case ReportHTML:
+		return "HTML"
+	}
+	return "Unknown"
+}
+
+// ExportReport writes a page describing the container's wiring, for documentation regenerated alongside a
+// service: a table of the beans with their type, scope and registration options, each bean's dependencies with
+// how they are satisfied, the initialization order and a Mermaid diagram of the graph. Secret values are
+// redacted. The output is sorted and does not vary between runs, so it can be kept in version control. It does
+// not build the container.
+func (c *Container) ExportReport(w io.Writer, format ReportFormat) error {
+	if format != ReportMarkdown && format != ReportHTML {
+		return fmt.Errorf("unsupported report format %d", format)
+	}
+
+	d := c.dump()
+	var diagram strings.Builder
+	if err := c.ExportMermaid(&diagram); err != nil {
+		return err
+	}
+	c.regMu.RLock()
+	order := slices.Clone(c.initOrder)
+	c.regMu.RUnlock()
+
+	var sb strings.Builder
+	if format == ReportHTML {
+		writeHTMLReport(&sb, d, order, diagram.String())
+	} else {
+		writeMarkdownReport(&sb, d, order, diagram.String())
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// reportScope names how the bean's instances are shared, like bean.scope.
+func reportScope(b BeanDump) string {
+	switch {
+	case b.Metadata.Prototype:
+		return "prototype"
+	case b.Metadata.Literal:
+		return "literal"
+	case b.Metadata.Lazy:
+		return "lazy"
+	}
+	return "singleton"
+}
+
+// reportOptions lists the registration options and markers of the bean, e.g. "primary, groups: a b".
+func reportOptions(b BeanDump) string {
+	m := b.Metadata
+	var opts []string
+	for _, flag := range []struct {
+		set  bool
+		name string
+	}{
+		{m.Primary, "primary"},
+		{m.Secret, "secret"},
+		{m.Supplied, "supplied"},
+		{m.Synthetic, "synthetic"},
+	} {
+		if flag.set {
+			opts = append(opts, flag.name)
+		}
+	}
+	if len(m.Groups) > 0 {
+		opts = append(opts, "groups: "+strings.Join(m.Groups, " "))
+	}
+	if m.InitPriority != 0 {
+		opts = append(opts, "priority: "+strconv.Itoa(m.InitPriority))
+	}
+	return strings.Join(opts, ", ")
+}
+
+// reportEdge renders how a dependency is satisfied, e.g. "bean" or "literal, autowired".
+func reportEdge(dep DependencyDump) string {
+	source := dep.Source
+	if dep.Autowired {
+		source += ", autowired"
+	}
+	if dep.Prototype {
+		source += ", prototype"
+	}
+	return source
+}
+
+// yesNo renders a flag of the report.
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// mdEscaper keeps table cells from breaking the Markdown table.
+var mdEscaper = strings.NewReplacer("|", `\|`, "\n", " ")
+
+// mdCode renders s as inline code, or nothing when it is empty.
+func mdCode(s string) string {
+	if s == emptyString {
+		return emptyString
+	}
+	return "`" + mdEscaper.Replace(s) + "`"
+}
+
+func writeMarkdownReport(sb *strings.Builder, d ContainerDump, order []string, diagram string) {
+	sb.WriteString("# Container wiring\n\n")
+	fmt.Fprintf(sb, "State: %s\n\n", d.State)
+
+	sb.WriteString("## Beans\n\n")
+	sb.WriteString("| ID | Type | Scope | Initialized | Options | Value |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+	for _, b := range d.Beans {
+		fmt.Fprintf(sb, "| %s | %s | %s | %s | %s | %s |\n", mdEscaper.Replace(b.ID), mdCode(b.GoType), reportScope(b),
+			yesNo(b.Initialized), mdEscaper.Replace(reportOptions(b)), mdCode(b.Metadata.Value))
+	}
+
+	sb.WriteString("\n## Dependencies\n")
+	for _, b := range d.Beans {
+		if len(b.Dependencies) == 0 {
+			continue
+		}
+		fmt.Fprintf(sb, "\n### %s\n\n", mdEscaper.Replace(b.ID))
+		sb.WriteString("| Field | Dependency | Source |\n")
+		sb.WriteString("| --- | --- | --- |\n")
+		for _, dep := range b.Dependencies {
+			fmt.Fprintf(sb, "| %s | %s | %s |\n", dep.Field, mdEscaper.Replace(dep.ID), reportEdge(dep))
+		}
+	}
+
+	sb.WriteString("\n## Initialization order\n\n")
+	if len(order) == 0 {
+		sb.WriteString("Nothing has been initialized.\n")
+	}
+	for i, id := range order {
+		fmt.Fprintf(sb, "%d. %s\n", i+1, id)
+	}
+
+	sb.WriteString("\n## Graph\n\n```mermaid\n")
+	sb.WriteString(diagram)
+	sb.WriteString("```\n")
+}
+
+func writeHTMLReport(sb *strings.Builder, d ContainerDump, order []string, diagram string) {
+	esc := html.EscapeString
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Container wiring</title>\n</head>\n<body>\n")
+	sb.WriteString("<h1>Container wiring</h1>\n")
+	fmt.Fprintf(sb, "<p>State: %s</p>\n", esc(d.State))
+
+	sb.WriteString("<h2>Beans</h2>\n<table>\n")
+	sb.WriteString("<tr><th>ID</th><th>Type</th><th>Scope</th><th>Initialized</th><th>Options</th><th>Value</th></tr>\n")
+	for _, b := range d.Beans {
+		fmt.Fprintf(sb, "<tr><td>%s</td><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			esc(b.ID), esc(b.GoType), reportScope(b), yesNo(b.Initialized), esc(reportOptions(b)), esc(b.Metadata.Value))
+	}
+	sb.WriteString("</table>\n")
+
+	sb.WriteString("<h2>Dependencies</h2>\n")
+	for _, b := range d.Beans {
+		if len(b.Dependencies) == 0 {
+			continue
+		}
+		fmt.Fprintf(sb, "<h3>%s</h3>\n<table>\n", esc(b.ID))
+		sb.WriteString("<tr><th>Field</th><th>Dependency</th><th>Source</th></tr>\n")
+		for _, dep := range b.Dependencies {
+			fmt.Fprintf(sb, "<tr><td>%s</td><td>%s</td><td>%s</td></tr>\n", esc(dep.Field), esc(dep.ID), reportEdge(dep))
+		}
+		sb.WriteString("</table>\n")
+	}
+
+	sb.WriteString("<h2>Initialization order</h2>\n")
+	if len(order) == 0 {
+		sb.WriteString("<p>Nothing has been initialized.</p>\n")
+	} else {
+		sb.WriteString("<ol>\n")
+		for _, id := range order {
+			fmt.Fprintf(sb, "<li>%s</li>\n", esc(id))
+		}
+		sb.WriteString("</ol>\n")
+	}
+
+	sb.WriteString("<h2>Graph</h2>\n<pre class=\"mermaid\">\n")
+	sb.WriteString(esc(diagram))
+	sb.WriteString("</pre>\n</body>\n</html>\n")
+}
@@ -0,0 +1,68 @@
+package iocdi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportReport_Markdown(t *testing.T) {
+	c := newServiceGraph(t)
+	c.MarkSecret("WorkingDir")
+	require.NoError(t, c.Build())
+
+	var sb strings.Builder
+	require.NoError(t, c.ExportReport(&sb, ReportMarkdown))
+	want := "# Container wiring\n\n" +
+		"State: Built\n\n" +
+		"## Beans\n\n" +
+		"| ID | Type | Scope | Initialized | Options | Value |\n" +
+		"| --- | --- | --- | --- | --- | --- |\n" +
+		"| servicebean | `*iocdi.Service` | singleton | yes |  |  |\n" +
+		"| servicebeanconfig | `*iocdi.Config` | singleton | yes |  |  |\n" +
+		"| servicebeanlogger | `*iocdi.Logger` | singleton | yes |  |  |\n" +
+		"| workingdir | `string` | literal | yes | secret | `«redacted»` |\n" +
+		"\n## Dependencies\n" +
+		"\n### servicebean\n\n" +
+		"| Field | Dependency | Source |\n" +
+		"| --- | --- | --- |\n" +
+		"| Config | servicebeanconfig | bean |\n" +
+		"| Logger | servicebeanlogger | bean |\n" +
+		"\n### servicebeanconfig\n\n" +
+		"| Field | Dependency | Source |\n" +
+		"| --- | --- | --- |\n" +
+		"| WorkingDir | workingdir | literal |\n" +
+		"\n## Initialization order\n\n" +
+		"1. servicebeanlogger\n" +
+		"2. workingdir\n" +
+		"3. servicebeanconfig\n" +
+		"4. servicebean\n" +
+		"\n## Graph\n\n" +
+		"```mermaid\n" +
+		exportMermaid(t, c) +
+		"```\n"
+	require.Equal(t, want, sb.String())
+	require.NotContains(t, sb.String(), "/srv/app")
+
+	var again strings.Builder
+	require.NoError(t, c.ExportReport(&again, ReportMarkdown))
+	require.Equal(t, sb.String(), again.String())
+}
+
+func TestExportReport_HTML(t *testing.T) {
+	c := newServiceGraph(t)
+
+	var sb strings.Builder
+	require.NoError(t, c.ExportReport(&sb, ReportHTML))
+	out := sb.String()
+	require.True(t, strings.HasPrefix(out, "<!DOCTYPE html>\n"))
+	require.Contains(t, out, "<tr><td>servicebean</td><td><code>*iocdi.Service</code></td><td>singleton</td><td>no</td><td></td><td></td></tr>\n")
+	require.Contains(t, out, "<tr><td>WorkingDir</td><td>workingdir</td><td>literal</td></tr>\n")
+	require.Contains(t, out, "<p>Nothing has been initialized.</p>\n")
+	require.Contains(t, out, "<pre class=\"mermaid\">\ngraph TD\n  servicebean[&#34;servicebean&lt;br/&gt;*iocdi.Service&#34;]\n")
+}
+
+func TestExportReport_UnsupportedFormat(t *testing.T) {
+	require.EqualError(t, New().ExportReport(&strings.Builder{}, ReportFormat(7)), "unsupported report format 7")
+}